@@ -16,6 +16,90 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
+// Defines values for AuditEntryAction.
+const (
+	Create  AuditEntryAction = "create"
+	Delete  AuditEntryAction = "delete"
+	Purge   AuditEntryAction = "purge"
+	Restore AuditEntryAction = "restore"
+	Update  AuditEntryAction = "update"
+)
+
+// Defines values for AuditEntryEntityType.
+const (
+	AuditEntryEntityTypeLabel   AuditEntryEntityType = "label"
+	AuditEntryEntityTypeProject AuditEntryEntityType = "project"
+	AuditEntryEntityTypeTask    AuditEntryEntityType = "task"
+)
+
+// Defines values for BulkTaskRequestAction.
+const (
+	BulkTaskRequestActionAddLabels      BulkTaskRequestAction = "add_labels"
+	BulkTaskRequestActionDelete         BulkTaskRequestAction = "delete"
+	BulkTaskRequestActionRemoveLabels   BulkTaskRequestAction = "remove_labels"
+	BulkTaskRequestActionShiftDates     BulkTaskRequestAction = "shift_dates"
+	BulkTaskRequestActionUpdatePriority BulkTaskRequestAction = "update_priority"
+	BulkTaskRequestActionUpdateStatus   BulkTaskRequestAction = "update_status"
+)
+
+// Defines values for BulkTaskRequestPriority.
+const (
+	BulkTaskRequestPriorityHigh   BulkTaskRequestPriority = "High"
+	BulkTaskRequestPriorityLow    BulkTaskRequestPriority = "Low"
+	BulkTaskRequestPriorityMiddle BulkTaskRequestPriority = "Middle"
+)
+
+// Defines values for BulkTaskRequestStatus.
+const (
+	BulkTaskRequestStatusCompleted  BulkTaskRequestStatus = "Completed"
+	BulkTaskRequestStatusInProgress BulkTaskRequestStatus = "InProgress"
+	BulkTaskRequestStatusNotStarted BulkTaskRequestStatus = "NotStarted"
+)
+
+// Defines values for FieldErrorLocation.
+const (
+	Body    FieldErrorLocation = "body"
+	Header  FieldErrorLocation = "header"
+	Path    FieldErrorLocation = "path"
+	Query   FieldErrorLocation = "query"
+	Request FieldErrorLocation = "request"
+)
+
+// Defines values for PermissionDenialRequired.
+const (
+	PermissionDenialRequiredLabelsWrite   PermissionDenialRequired = "labels:write"
+	PermissionDenialRequiredProjectManage PermissionDenialRequired = "project:manage"
+	PermissionDenialRequiredProjectRead   PermissionDenialRequired = "project:read"
+	PermissionDenialRequiredTasksWrite    PermissionDenialRequired = "tasks:write"
+)
+
+// Defines values for ProblemCode.
+const (
+	ProblemCodeConflict             ProblemCode = "conflict"
+	ProblemCodeConstraintViolation  ProblemCode = "constraint_violation"
+	ProblemCodeForbidden            ProblemCode = "forbidden"
+	ProblemCodeInternalError        ProblemCode = "internal_error"
+	ProblemCodeInvalidBody          ProblemCode = "invalid_body"
+	ProblemCodeInvalidParameter     ProblemCode = "invalid_parameter"
+	ProblemCodeInvalidReference     ProblemCode = "invalid_reference"
+	ProblemCodeMethodNotAllowed     ProblemCode = "method_not_allowed"
+	ProblemCodeNotFound             ProblemCode = "not_found"
+	ProblemCodePayloadTooLarge      ProblemCode = "payload_too_large"
+	ProblemCodePreconditionFailed   ProblemCode = "precondition_failed"
+	ProblemCodeRequestCanceled      ProblemCode = "request_canceled"
+	ProblemCodeTimeout              ProblemCode = "timeout"
+	ProblemCodeUnauthorized         ProblemCode = "unauthorized"
+	ProblemCodeUnsupportedMediaType ProblemCode = "unsupported_media_type"
+	ProblemCodeValidationFailed     ProblemCode = "validation_failed"
+)
+
+// Defines values for ProjectRole.
+const (
+	ProjectRoleAdmin  ProjectRole = "admin"
+	ProjectRoleEditor ProjectRole = "editor"
+	ProjectRoleViewer ProjectRole = "viewer"
+)
+
 // Defines values for TaskPriority.
 const (
 	TaskPriorityHigh   TaskPriority = "High"
@@ -44,6 +128,47 @@ const (
 	TaskInputStatusNotStarted TaskInputStatus = "NotStarted"
 )
 
+// Defines values for TaskMoveMissingLabels.
+const (
+	TaskMoveMissingLabelsCreate TaskMoveMissingLabels = "create"
+	TaskMoveMissingLabelsDrop   TaskMoveMissingLabels = "drop"
+)
+
+// Defines values for TaskPatchPriority.
+const (
+	TaskPatchPriorityHigh   TaskPatchPriority = "High"
+	TaskPatchPriorityLow    TaskPatchPriority = "Low"
+	TaskPatchPriorityMiddle TaskPatchPriority = "Middle"
+)
+
+// Defines values for TaskPatchStatus.
+const (
+	TaskPatchStatusCompleted  TaskPatchStatus = "Completed"
+	TaskPatchStatusInProgress TaskPatchStatus = "InProgress"
+	TaskPatchStatusNotStarted TaskPatchStatus = "NotStarted"
+)
+
+// Defines values for TokenPairTokenType.
+const (
+	Bearer TokenPairTokenType = "Bearer"
+)
+
+// Defines values for GetAuditParamsEntityType.
+const (
+	GetAuditParamsEntityTypeLabel   GetAuditParamsEntityType = "label"
+	GetAuditParamsEntityTypeProject GetAuditParamsEntityType = "project"
+	GetAuditParamsEntityTypeTask    GetAuditParamsEntityType = "task"
+)
+
+// Defines values for GetAuditParamsAction.
+const (
+	GetAuditParamsActionCreate  GetAuditParamsAction = "create"
+	GetAuditParamsActionDelete  GetAuditParamsAction = "delete"
+	GetAuditParamsActionPurge   GetAuditParamsAction = "purge"
+	GetAuditParamsActionRestore GetAuditParamsAction = "restore"
+	GetAuditParamsActionUpdate  GetAuditParamsAction = "update"
+)
+
 // Defines values for GetTasksParamsStatus.
 const (
 	Completed  GetTasksParamsStatus = "Completed"
@@ -51,19 +176,204 @@ const (
 	NotStarted GetTasksParamsStatus = "NotStarted"
 )
 
-// Defines values for GetTasksParamsSort.
+// Defines values for GetTasksParamsPriority.
 const (
-	EndDate  GetTasksParamsSort = "end_date"
-	Priority GetTasksParamsSort = "priority"
+	GetTasksParamsPriorityHigh   GetTasksParamsPriority = "High"
+	GetTasksParamsPriorityLow    GetTasksParamsPriority = "Low"
+	GetTasksParamsPriorityMiddle GetTasksParamsPriority = "Middle"
 )
 
+// Defines values for GetTasksParamsLabelMatch.
+const (
+	All GetTasksParamsLabelMatch = "all"
+	Any GetTasksParamsLabelMatch = "any"
+)
+
+// Assignee defines model for Assignee.
+type Assignee struct {
+	Id       int    `json:"id" db:"id"`
+	Username string `json:"username" db:"username"`
+}
+
+// Attachment defines model for Attachment.
+type Attachment struct {
+	// Checksum 本体の SHA-256（16進数）。ダウンロードの ETag にもなる
+	Checksum string `json:"checksum"`
+
+	// ContentType ファイルの内容から判定したメディアタイプ
+	ContentType string    `json:"content_type"`
+	CreatedAt   time.Time `json:"created_at"`
+	Filename    string    `json:"filename"`
+	Id          int       `json:"id"`
+
+	// Size バイト数
+	Size   int64 `json:"size"`
+	TaskId int   `json:"task_id"`
+
+	// UploadedBy アップロードした人。認証なしでアップロードした場合は含まれない
+	UploadedBy *string `json:"uploaded_by,omitempty"`
+}
+
+// AttachmentList defines model for AttachmentList.
+type AttachmentList struct {
+	Attachments []Attachment `json:"attachments"`
+}
+
+// AuditChange 項目の変更前と変更後の値（作成時の old と削除時の new は null）
+type AuditChange struct {
+	New interface{} `json:"new"`
+	Old interface{} `json:"old"`
+}
+
+// AuditEntry タスクまたはラベルに対する1回の変更の記録
+type AuditEntry struct {
+	Action AuditEntryAction `json:"action"`
+
+	// Actor 変更したユーザー（不明な場合は null）
+	Actor *string `json:"actor"`
+
+	// Changes 項目名ごとの変更前後の値
+	Changes    map[string]AuditChange `json:"changes"`
+	CreatedAt  time.Time              `json:"created_at"`
+	EntityId   int                    `json:"entity_id"`
+	EntityType AuditEntryEntityType   `json:"entity_type"`
+	Id         int64                  `json:"id"`
+
+	// RequestId 変更したリクエストの X-Request-ID
+	RequestId *string `json:"request_id"`
+}
+
+// AuditEntryAction defines model for AuditEntry.Action.
+type AuditEntryAction string
+
+// AuditEntryEntityType defines model for AuditEntry.EntityType.
+type AuditEntryEntityType string
+
+// AuditPage defines model for AuditPage.
+type AuditPage struct {
+	Entries  []AuditEntry `json:"entries"`
+	Page     int          `json:"page"`
+	PageSize int          `json:"page_size"`
+
+	// Total 条件に一致する履歴の総数
+	Total int `json:"total"`
+}
+
+// BulkTaskRequest defines model for BulkTaskRequest.
+type BulkTaskRequest struct {
+	// Action delete はタスクをゴミ箱に移動する
+	Action BulkTaskRequestAction `json:"action"`
+	DryRun *bool                 `json:"dry_run,omitempty"`
+
+	// Filter GET /tasks と同じ絞り込みのパラメータ（複数の値はカンマ区切り）。一致するタスクは1000件まで
+	Filter *map[string]string `json:"filter,omitempty"`
+	Ids    *[]int             `json:"ids,omitempty"`
+
+	// LabelIds add_labels, remove_labels で追加・削除するラベル
+	LabelIds *[]int `json:"label_ids,omitempty"`
+
+	// Priority update_priority で設定する優先度
+	Priority *BulkTaskRequestPriority `json:"priority,omitempty"`
+
+	// ShiftDays shift_dates で開始日と終了日をずらす日数（負の値で前にずらす）
+	ShiftDays *int `json:"shift_days,omitempty"`
+
+	// Status update_status で設定する状態
+	Status *BulkTaskRequestStatus `json:"status,omitempty"`
+}
+
+// BulkTaskRequestAction delete はタスクをゴミ箱に移動する
+type BulkTaskRequestAction string
+
+// BulkTaskRequestPriority update_priority で設定する優先度
+type BulkTaskRequestPriority string
+
+// BulkTaskRequestStatus update_status で設定する状態
+type BulkTaskRequestStatus string
+
+// Comment defines model for Comment.
+type Comment struct {
+	// Author 投稿者。認証なしで投稿したコメントでは含まれない
+	Author *string `json:"author,omitempty"`
+
+	// Body 無害化した Markdown の本文
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// EditedAt 最後に本文を編集した日時。編集していない場合は含まれない
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+	Id        int        `json:"id"`
+	TaskId    int        `json:"task_id"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	Version   int        `json:"version"`
+}
+
+// CommentHistory defines model for CommentHistory.
+type CommentHistory struct {
+	// Revisions 編集前の本文（新しい順）
+	Revisions []CommentRevision `json:"revisions"`
+}
+
+// CommentInput defines model for CommentInput.
+type CommentInput struct {
+	// Body Markdown の本文。生の HTML と安全でないリンクはサーバーで取り除く
+	Body string `json:"body"`
+}
+
+// CommentPage defines model for CommentPage.
+type CommentPage struct {
+	Comments []Comment `json:"comments"`
+	Page     int       `json:"page"`
+	PageSize int       `json:"page_size"`
+
+	// Total タスクに付いたコメントの総数
+	Total int `json:"total"`
+}
+
+// CommentRevision defines model for CommentRevision.
+type CommentRevision struct {
+	Body string `json:"body"`
+
+	// CreatedAt この本文を書いた日時
+	CreatedAt time.Time `json:"created_at"`
+	Version   int       `json:"version"`
+}
+
+// Credentials defines model for Credentials.
+type Credentials struct {
+	Password string `json:"password"`
+
+	// Username 英数字と _ . - のみ。大文字は小文字にそろえる
+	Username string `json:"username"`
+}
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	Field    string             `json:"field"`
+	Location FieldErrorLocation `json:"location"`
+	Message  string             `json:"message"`
+}
+
+// FieldErrorLocation defines model for FieldError.Location.
+type FieldErrorLocation string
+
 // Label defines model for Label.
 type Label struct {
-	ID        int       `json:"id" db:"id"`
-	Name      string    `json:"name" db:"name"`
-	Color     string    `json:"color" db:"color"`
+	ID    int    `json:"id" db:"id"`
+	Name  string `json:"name" db:"name"`
+	Color string `json:"color" db:"color"`
+
+	// ProjectId ラベルが属するプロジェクトのID。タスクには同じプロジェクトのラベルだけを付けられる
+	ProjectId int       `json:"project_id" db:"project_id"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	// CreatedBy 作成したユーザー名。認証なしで作成したラベルでは含まれない
+	CreatedBy *string `json:"created_by,omitempty" db:"created_by"`
+
+	// UpdatedBy 最後に更新したユーザー名。認証なしで更新したラベルでは含まれない
+	UpdatedBy *string `json:"updated_by,omitempty" db:"updated_by"`
+	Version   int     `json:"version" db:"version"`
 }
 
 // LabelInput defines model for LabelInput.
@@ -72,18 +382,187 @@ type LabelInput struct {
 	Color string `json:"color" db:"color"`
 }
 
+// MyTasks defines model for MyTasks.
+type MyTasks struct {
+	Completed  []Task `json:"completed"`
+	InProgress []Task `json:"in_progress"`
+	NotStarted []Task `json:"not_started"`
+}
+
+// PermissionDenial 役割が足りずに403になった場合の、不足している権限
+type PermissionDenial struct {
+	// GrantedTo 必要な権限を持つ役割
+	GrantedTo []ProjectRole `json:"granted_to"`
+	ProjectId int           `json:"project_id"`
+
+	// Required 操作に必要な権限
+	Required PermissionDenialRequired `json:"required"`
+
+	// Role リクエストしたユーザーの役割
+	Role ProjectRole `json:"role"`
+}
+
+// PermissionDenialRequired 操作に必要な権限
+type PermissionDenialRequired string
+
+// Problem RFC 7807 形式のエラーレスポンス
+type Problem struct {
+	// Code エラーの種類を表す機械可読なコード
+	Code     ProblemCode   `json:"code"`
+	Detail   *string       `json:"detail,omitempty"`
+	Errors   *[]FieldError `json:"errors,omitempty"`
+	Instance *string       `json:"instance,omitempty"`
+
+	// Permission 役割が足りずに403になった場合の、不足している権限
+	Permission *PermissionDenial `json:"permission,omitempty"`
+	RequestId  *string           `json:"request_id,omitempty"`
+	Status     int               `json:"status"`
+	Title      string            `json:"title"`
+	Type       string            `json:"type"`
+}
+
+// ProblemCode エラーの種類を表す機械可読なコード
+type ProblemCode string
+
+// BadRequest defines model for BadRequest.
+type BadRequest = Problem
+
+// InternalServerError defines model for InternalServerError.
+type InternalServerError = Problem
+
+// NotFound defines model for NotFound.
+type NotFound = Problem
+
+// UnprocessableEntity defines model for UnprocessableEntity.
+type UnprocessableEntity = Problem
+
+// Project タスクとラベルをまとめる単位。ID 1 は既定のプロジェクト
+type Project struct {
+	// ArchivedAt アーカイブした日時。アーカイブしていないプロジェクトでは含まれない
+	ArchivedAt *time.Time `json:"archived_at,omitempty" db:"archived_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+
+	// CreatedBy 作成したユーザー名。認証なしで作成したプロジェクトでは含まれない
+	CreatedBy   *string   `json:"created_by,omitempty" db:"created_by"`
+	Description *string   `json:"description,omitempty" db:"description"`
+	Id          int       `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+
+	// UpdatedBy 最後に更新したユーザー名。認証なしで更新したプロジェクトでは含まれない
+	UpdatedBy *string `json:"updated_by,omitempty" db:"updated_by"`
+
+	// Version 更新のたびに増える版。ETag の元になる
+	Version int `json:"version" db:"version"`
+}
+
+// ProjectInput defines model for ProjectInput.
+type ProjectInput struct {
+	Description *string `json:"description,omitempty"`
+	Name        string  `json:"name"`
+}
+
+// ProjectMember defines model for ProjectMember.
+type ProjectMember struct {
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+
+	// Role プロジェクトでの役割。
+	// viewer はプロジェクトとそのタスク・ラベル・コメントなどを閲覧でき、
+	// editor はさらにタスクとラベルを変更でき、admin はさらにプロジェクトの更新・アーカイブとメンバーの管理ができる
+	Role      ProjectRole `json:"role" db:"role"`
+	UpdatedAt time.Time   `json:"updated_at" db:"updated_at"`
+	UserId    int         `json:"user_id" db:"user_id"`
+	Username  string      `json:"username" db:"username"`
+}
+
+// ProjectMemberInput defines model for ProjectMemberInput.
+type ProjectMemberInput struct {
+	// Role プロジェクトでの役割。
+	// viewer はプロジェクトとそのタスク・ラベル・コメントなどを閲覧でき、
+	// editor はさらにタスクとラベルを変更でき、admin はさらにプロジェクトの更新・アーカイブとメンバーの管理ができる
+	Role ProjectRole `json:"role"`
+}
+
+// ProjectRole プロジェクトでの役割。
+// viewer はプロジェクトとそのタスク・ラベル・コメントなどを閲覧でき、
+// editor はさらにタスクとラベルを変更でき、admin はさらにプロジェクトの更新・アーカイブとメンバーの管理ができる
+type ProjectRole string
+
+// RecurrencePreview defines model for RecurrencePreview.
+type RecurrencePreview struct {
+	Occurrences []TaskOccurrence `json:"occurrences"`
+	Rule        string           `json:"rule"`
+	Timezone    string           `json:"timezone"`
+}
+
+// RefreshRequest defines model for RefreshRequest.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// SearchMatch q で検索した場合のみ含まれる関連度とハイライト
+type SearchMatch struct {
+	// Highlights 一致した部分を <mark> で囲んだHTMLエスケープ済みの抜粋
+	Highlights struct {
+		Description *string `json:"description,omitempty"`
+		Name        string  `json:"name"`
+	} `json:"highlights"`
+
+	// Rank 関連度（大きいほど関連が高い）
+	Rank float64 `json:"rank"`
+}
+
+// SubtaskRollup ゴミ箱にない直下のサブタスクの状態ごとの件数。サブタスクがない場合は含まれない
+type SubtaskRollup struct {
+	Completed int `json:"completed"`
+
+	// CompletionPercentage 完了したサブタスクの割合（切り捨て）
+	CompletionPercentage int `json:"completion_percentage"`
+	InProgress           int `json:"in_progress"`
+	NotStarted           int `json:"not_started"`
+	Total                int `json:"total"`
+}
+
 // Task defines model for Task.
 type Task struct {
-	CreatedAt   *time.Time    `json:"created_at,omitempty"`
+	// Assignees 担当者（ユーザー名の順）。担当者がいない場合は含まれない
+	Assignees    []Assignee `json:"assignees,omitempty" db:"-"`
+	Blocked      *bool      `json:"blocked,omitempty"`
+	BlockedBy    []int      `json:"blocked_by,omitempty" db:"-"`
+	Children     []Task     `json:"children,omitempty" db:"-"` // ツリー表示の場合のみ
+	CommentCount *int       `json:"comment_count,omitempty" db:"-"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+
+	// CreatedBy 作成したユーザー名。認証なしで作成したタスクでは含まれない
+	CreatedBy   *string       `json:"created_by,omitempty"`
+	DeletedAt   *time.Time    `json:"deleted_at,omitempty"`
+	DependsOn   []int         `json:"depends_on,omitempty" db:"-"`
 	Description *string       `json:"description,omitempty"`
 	EndDate     *time.Time    `json:"end_date,omitempty"`
 	Id          *int          `json:"id,omitempty"`
 	Labels      []Label       `json:"labels" db:"-"` // DBには直接対応しない
 	Name        *string       `json:"name,omitempty"`
+	ParentId    *int          `json:"parent_id,omitempty"`
 	Priority    *TaskPriority `json:"priority,omitempty"`
-	StartDate   *time.Time    `json:"start_date,omitempty"`
-	Status      *TaskStatus   `json:"status,omitempty"`
-	UpdatedAt   *time.Time    `json:"updated_at,omitempty"`
+
+	// ProjectId タスクが属するプロジェクトのID。変更には POST /tasks/{id}/move を使う
+	ProjectId *int `json:"project_id,omitempty"`
+
+	// RecurrenceRule 繰り返しの規則（iCalendar の RRULE）。繰り返しの最新のタスクだけが持つ
+	RecurrenceRule     *string      `json:"recurrence_rule,omitempty"`
+	RecurrenceTimezone *string      `json:"recurrence_timezone,omitempty"`
+	Search             *SearchMatch `json:"search,omitempty"`
+
+	// SeriesId 繰り返しの最初のタスクのID
+	SeriesId  *int           `json:"series_id,omitempty"`
+	StartDate *time.Time     `json:"start_date,omitempty"`
+	Status    *TaskStatus    `json:"status,omitempty"`
+	Subtasks  *SubtaskRollup `json:"subtasks,omitempty"`
+	UpdatedAt *time.Time     `json:"updated_at,omitempty"`
+
+	// UpdatedBy 最後に更新したユーザー名。認証なしで更新したタスクでは含まれない
+	UpdatedBy *string `json:"updated_by,omitempty"`
+	Version   *int    `json:"version,omitempty"`
 }
 
 // TaskPriority defines model for Task.Priority.
@@ -94,12 +573,19 @@ type TaskStatus string
 
 // TaskInput defines model for TaskInput.
 type TaskInput struct {
-	Description *string            `json:"description,omitempty"`
-	EndDate     *time.Time         `json:"end_date,omitempty"`
-	Name        *string            `json:"name,omitempty"`
-	Priority    *TaskInputPriority `json:"priority,omitempty"`
-	StartDate   *time.Time         `json:"start_date,omitempty"`
-	Status      *TaskInputStatus   `json:"status,omitempty"`
+	Description *string           `json:"description,omitempty"`
+	EndDate     *time.Time        `json:"end_date,omitempty"`
+	Name        string            `json:"name"`
+	ParentId    *int              `json:"parent_id,omitempty"`
+	Priority    TaskInputPriority `json:"priority"`
+
+	// RecurrenceRule 繰り返しの規則（iCalendar の RRULE）。start_date か end_date が必要
+	RecurrenceRule *string `json:"recurrence_rule,omitempty"`
+
+	// RecurrenceTimezone 繰り返しの日時を計算するタイムゾーン（IANA の名前、省略時は UTC）
+	RecurrenceTimezone *string         `json:"recurrence_timezone,omitempty"`
+	StartDate          *time.Time      `json:"start_date,omitempty"`
+	Status             TaskInputStatus `json:"status"`
 }
 
 // TaskInputPriority defines model for TaskInput.Priority.
@@ -108,60 +594,624 @@ type TaskInputPriority string
 // TaskInputStatus defines model for TaskInput.Status.
 type TaskInputStatus string
 
+// TaskMove defines model for TaskMove.
+type TaskMove struct {
+	// LabelMap 移動元のラベルID（文字列）ごとの、付け替える移動先のラベルID
+	LabelMap *map[string]int `json:"label_map,omitempty"`
+
+	// MissingLabels label_map にも移動先にも同じ名前のラベルがない場合に、同じ名前と色のラベルを移動先に作成する（create）か外す（drop）か
+	MissingLabels *TaskMoveMissingLabels `json:"missing_labels,omitempty"`
+
+	// ProjectId 移動先のプロジェクトのID
+	ProjectId int `json:"project_id"`
+}
+
+// TaskMoveMissingLabels label_map にも移動先にも同じ名前のラベルがない場合に、同じ名前と色のラベルを移動先に作成する（create）か外す（drop）か
+type TaskMoveMissingLabels string
+
+// TaskOccurrence defines model for TaskOccurrence.
+type TaskOccurrence struct {
+	EndDate   *time.Time `json:"end_date,omitempty"`
+	StartDate *time.Time `json:"start_date,omitempty"`
+}
+
+// TaskPatch 指定したフィールドだけを更新する（JSON Merge Patch）
+type TaskPatch struct {
+	Description *string            `json:"description,omitempty"`
+	EndDate     *time.Time         `json:"end_date,omitempty"`
+	Name        *string            `json:"name,omitempty"`
+	ParentId    *int               `json:"parent_id,omitempty"`
+	Priority    *TaskPatchPriority `json:"priority,omitempty"`
+
+	// RecurrenceRule 繰り返しの規則。null で繰り返しをやめる
+	RecurrenceRule     *string          `json:"recurrence_rule,omitempty"`
+	RecurrenceTimezone *string          `json:"recurrence_timezone,omitempty"`
+	StartDate          *time.Time       `json:"start_date,omitempty"`
+	Status             *TaskPatchStatus `json:"status,omitempty"`
+}
+
+// TaskPatchPriority defines model for TaskPatch.Priority.
+type TaskPatchPriority string
+
+// TaskPatchStatus defines model for TaskPatch.Status.
+type TaskPatchStatus string
+
+// TokenPair defines model for TokenPair.
+type TokenPair struct {
+	AccessToken string `json:"access_token"`
+
+	// ExpiresIn アクセストークンの有効期間（秒）
+	ExpiresIn    int                `json:"expires_in"`
+	RefreshToken string             `json:"refresh_token"`
+	TokenType    TokenPairTokenType `json:"token_type"`
+}
+
+// TokenPairTokenType defines model for TokenPair.TokenType.
+type TokenPairTokenType string
+
+// User defines model for User.
+type User struct {
+	CreatedAt time.Time `json:"created_at"`
+	Id        int       `json:"id"`
+	Username  string    `json:"username"`
+}
+
+// GetAuditParams defines parameters for GetAudit.
+type GetAuditParams struct {
+	EntityType    *GetAuditParamsEntityType `form:"entity_type,omitempty" json:"entity_type,omitempty"`
+	EntityId      *int                      `form:"entity_id,omitempty" json:"entity_id,omitempty"`
+	Action        *[]GetAuditParamsAction   `form:"action,omitempty" json:"action,omitempty"`
+	Actor         *string                   `form:"actor,omitempty" json:"actor,omitempty"`
+	CreatedAtFrom *time.Time                `form:"created_at_from,omitempty" json:"created_at_from,omitempty"`
+	CreatedAtTo   *time.Time                `form:"created_at_to,omitempty" json:"created_at_to,omitempty"`
+	Page          *int                      `form:"page,omitempty" json:"page,omitempty"`
+	PageSize      *int                      `form:"page_size,omitempty" json:"page_size,omitempty"`
+	Limit         *int                      `form:"limit,omitempty" json:"limit,omitempty"`
+	IfNoneMatch   *string                   `json:"If-None-Match,omitempty"`
+}
+
+// GetAuditParamsEntityType defines parameters for GetAudit.
+type GetAuditParamsEntityType string
+
+// GetAuditParamsAction defines parameters for GetAudit.
+type GetAuditParamsAction string
+
+// GetLabelsParams defines parameters for GetLabels.
+type GetLabelsParams struct {
+	// ProjectId 指定したプロジェクトのラベルに絞り込む
+	ProjectId   *int    `form:"project_id,omitempty" json:"project_id,omitempty"`
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// DeleteLabelsIdParams defines parameters for DeleteLabelsId.
+type DeleteLabelsIdParams struct {
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// GetLabelsIdParams defines parameters for GetLabelsId.
+type GetLabelsIdParams struct {
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// PutLabelsIdParams defines parameters for PutLabelsId.
+type PutLabelsIdParams struct {
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// GetMeTasksParams defines parameters for GetMeTasks.
+type GetMeTasksParams struct {
+	Sort *string `form:"sort,omitempty" json:"sort,omitempty"`
+}
+
+// GetProjectsParams defines parameters for GetProjects.
+type GetProjectsParams struct {
+	// Archived true の場合はアーカイブしたプロジェクト、false の場合はそれ以外のプロジェクトを返す
+	Archived    *bool   `form:"archived,omitempty" json:"archived,omitempty"`
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// GetProjectsPidParams defines parameters for GetProjectsPid.
+type GetProjectsPidParams struct {
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// PutProjectsPidParams defines parameters for PutProjectsPid.
+type PutProjectsPidParams struct {
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// PostProjectsPidArchiveParams defines parameters for PostProjectsPidArchive.
+type PostProjectsPidArchiveParams struct {
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// GetProjectsPidLabelsParams defines parameters for GetProjectsPidLabels.
+type GetProjectsPidLabelsParams struct {
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// GetProjectsPidMembersParams defines parameters for GetProjectsPidMembers.
+type GetProjectsPidMembersParams struct {
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// GetProjectsPidTasksParams defines parameters for GetProjectsPidTasks.
+type GetProjectsPidTasksParams struct {
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// PostProjectsPidUnarchiveParams defines parameters for PostProjectsPidUnarchive.
+type PostProjectsPidUnarchiveParams struct {
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
 // GetTasksParams defines parameters for GetTasks.
 type GetTasksParams struct {
-	Status      *GetTasksParamsStatus `form:"status,omitempty" json:"status,omitempty"`
-	Name        *string               `form:"name,omitempty" json:"name,omitempty"`
-	Description *string               `form:"description,omitempty" json:"description,omitempty"`
-	Page        *int                  `form:"page,omitempty" json:"page,omitempty"`
-	Sort        *GetTasksParamsSort   `form:"sort,omitempty" json:"sort,omitempty"`
+	Status        *[]GetTasksParamsStatus   `form:"status,omitempty" json:"status,omitempty"`
+	Priority      *[]GetTasksParamsPriority `form:"priority,omitempty" json:"priority,omitempty"`
+	Q             *string                   `form:"q,omitempty" json:"q,omitempty"`
+	Name          *string                   `form:"name,omitempty" json:"name,omitempty"`
+	Description   *string                   `form:"description,omitempty" json:"description,omitempty"`
+	LabelIds      *[]int                    `form:"label_ids,omitempty" json:"label_ids,omitempty"`
+	LabelMatch    *GetTasksParamsLabelMatch `form:"label_match,omitempty" json:"label_match,omitempty"`
+	HasLabels     *bool                     `form:"has_labels,omitempty" json:"has_labels,omitempty"`
+	Assignee      *[]string                 `form:"assignee,omitempty" json:"assignee,omitempty"`
+	Unassigned    *bool                     `form:"unassigned,omitempty" json:"unassigned,omitempty"`
+	StartDateFrom *time.Time                `form:"start_date_from,omitempty" json:"start_date_from,omitempty"`
+	StartDateTo   *time.Time                `form:"start_date_to,omitempty" json:"start_date_to,omitempty"`
+	EndDateFrom   *time.Time                `form:"end_date_from,omitempty" json:"end_date_from,omitempty"`
+	EndDateTo     *time.Time                `form:"end_date_to,omitempty" json:"end_date_to,omitempty"`
+	CreatedAtFrom *time.Time                `form:"created_at_from,omitempty" json:"created_at_from,omitempty"`
+	CreatedAtTo   *time.Time                `form:"created_at_to,omitempty" json:"created_at_to,omitempty"`
+	UpdatedAtFrom *time.Time                `form:"updated_at_from,omitempty" json:"updated_at_from,omitempty"`
+	UpdatedAtTo   *time.Time                `form:"updated_at_to,omitempty" json:"updated_at_to,omitempty"`
+	Overdue       *bool                     `form:"overdue,omitempty" json:"overdue,omitempty"`
+	ParentId      *int                      `form:"parent_id,omitempty" json:"parent_id,omitempty"`
+
+	// ProjectId 指定したプロジェクトのタスクに絞り込む（GET /projects/{pid}/tasks と同じ）
+	ProjectId *int `form:"project_id,omitempty" json:"project_id,omitempty"`
+
+	// IncludeArchived true の場合はアーカイブしたプロジェクトのタスクも含める。project_id を指定した場合は常に含める
+	IncludeArchived *bool   `form:"include_archived,omitempty" json:"include_archived,omitempty"`
+	Page            *int    `form:"page,omitempty" json:"page,omitempty"`
+	PageSize        *int    `form:"page_size,omitempty" json:"page_size,omitempty"`
+	Limit           *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	Cursor          *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Sort            *string `form:"sort,omitempty" json:"sort,omitempty"`
+	IfNoneMatch     *string `json:"If-None-Match,omitempty"`
 }
 
 // GetTasksParamsStatus defines parameters for GetTasks.
 type GetTasksParamsStatus string
 
-// GetTasksParamsSort defines parameters for GetTasks.
-type GetTasksParamsSort string
+// GetTasksParamsPriority defines parameters for GetTasks.
+type GetTasksParamsPriority string
+
+// GetTasksParamsLabelMatch defines parameters for GetTasks.
+type GetTasksParamsLabelMatch string
+
+// DeleteTasksIdParams defines parameters for DeleteTasksId.
+type DeleteTasksIdParams struct {
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// GetTasksIdParams defines parameters for GetTasksId.
+type GetTasksIdParams struct {
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// PatchTasksIdParams defines parameters for PatchTasksId.
+type PatchTasksIdParams struct {
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// PutTasksIdParams defines parameters for PutTasksId.
+type PutTasksIdParams struct {
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// PutTasksIdAssigneesParams defines parameters for PutTasksIdAssignees.
+type PutTasksIdAssigneesParams struct {
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// PutTasksIdAssigneesJSONBody defines parameters for PutTasksIdAssignees.
+type PutTasksIdAssigneesJSONBody struct {
+	UserIds []int `json:"user_ids"`
+}
+
+// GetTasksIdAttachmentsParams defines parameters for GetTasksIdAttachments.
+type GetTasksIdAttachmentsParams struct {
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// GetTasksIdAttachmentsAttachmentIdParams defines parameters for GetTasksIdAttachmentsAttachmentId.
+type GetTasksIdAttachmentsAttachmentIdParams struct {
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// GetTasksIdAttachmentsAttachmentIdContentParams defines parameters for GetTasksIdAttachmentsAttachmentIdContent.
+type GetTasksIdAttachmentsAttachmentIdContentParams struct {
+	// Range 取得するバイトの範囲（例 bytes=0-1023）
+	Range *string `json:"Range,omitempty"`
+
+	// IfRange この ETag が現在の本体と一致する場合だけ Range を適用する
+	IfRange     *string `json:"If-Range,omitempty"`
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// GetTasksIdCommentsParams defines parameters for GetTasksIdComments.
+type GetTasksIdCommentsParams struct {
+	Page        *int    `form:"page,omitempty" json:"page,omitempty"`
+	PageSize    *int    `form:"page_size,omitempty" json:"page_size,omitempty"`
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// DeleteTasksIdCommentsCommentIdParams defines parameters for DeleteTasksIdCommentsCommentId.
+type DeleteTasksIdCommentsCommentIdParams struct {
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// PutTasksIdCommentsCommentIdParams defines parameters for PutTasksIdCommentsCommentId.
+type PutTasksIdCommentsCommentIdParams struct {
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// GetTasksIdCommentsCommentIdHistoryParams defines parameters for GetTasksIdCommentsCommentIdHistory.
+type GetTasksIdCommentsCommentIdHistoryParams struct {
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// PostTasksIdDependenciesParams defines parameters for PostTasksIdDependencies.
+type PostTasksIdDependenciesParams struct {
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// PostTasksIdDependenciesJSONBody defines parameters for PostTasksIdDependencies.
+type PostTasksIdDependenciesJSONBody struct {
+	DependsOnId int `json:"depends_on_id"`
+}
+
+// DeleteTasksIdDependenciesDependsOnIdParams defines parameters for DeleteTasksIdDependenciesDependsOnId.
+type DeleteTasksIdDependenciesDependsOnIdParams struct {
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// GetTasksIdHistoryParams defines parameters for GetTasksIdHistory.
+type GetTasksIdHistoryParams struct {
+	Page        *int    `form:"page,omitempty" json:"page,omitempty"`
+	PageSize    *int    `form:"page_size,omitempty" json:"page_size,omitempty"`
+	Limit       *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// PutTasksIdLabelsParams defines parameters for PutTasksIdLabels.
+type PutTasksIdLabelsParams struct {
+	IfMatch *string `json:"If-Match,omitempty"`
+}
 
 // PutTasksIdLabelsJSONBody defines parameters for PutTasksIdLabels.
 type PutTasksIdLabelsJSONBody struct {
-	LabelIds *[]int `json:"label_ids,omitempty"`
+	LabelIds []int `json:"label_ids"`
+}
+
+// PostTasksIdMoveParams defines parameters for PostTasksIdMove.
+type PostTasksIdMoveParams struct {
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// GetTasksIdRecurrenceParams defines parameters for GetTasksIdRecurrence.
+type GetTasksIdRecurrenceParams struct {
+	Count       *int    `form:"count,omitempty" json:"count,omitempty"`
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// PostTasksIdRecurrenceEndParams defines parameters for PostTasksIdRecurrenceEnd.
+type PostTasksIdRecurrenceEndParams struct {
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// PostTasksIdRecurrenceSkipParams defines parameters for PostTasksIdRecurrenceSkip.
+type PostTasksIdRecurrenceSkipParams struct {
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// PostTasksIdRestoreParams defines parameters for PostTasksIdRestore.
+type PostTasksIdRestoreParams struct {
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// GetTasksIdSubtasksParams defines parameters for GetTasksIdSubtasks.
+type GetTasksIdSubtasksParams struct {
+	Tree        *bool   `form:"tree,omitempty" json:"tree,omitempty"`
+	Page        *int    `form:"page,omitempty" json:"page,omitempty"`
+	PageSize    *int    `form:"page_size,omitempty" json:"page_size,omitempty"`
+	Cursor      *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Sort        *string `form:"sort,omitempty" json:"sort,omitempty"`
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// GetTrashParams defines parameters for GetTrash.
+type GetTrashParams struct {
+	Page        *int    `form:"page,omitempty" json:"page,omitempty"`
+	PageSize    *int    `form:"page_size,omitempty" json:"page_size,omitempty"`
+	Limit       *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	Cursor      *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Sort        *string `form:"sort,omitempty" json:"sort,omitempty"`
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
 }
 
+// PostAuthLoginJSONRequestBody defines body for PostAuthLogin for application/json ContentType.
+type PostAuthLoginJSONRequestBody = Credentials
+
+// PostAuthLogoutJSONRequestBody defines body for PostAuthLogout for application/json ContentType.
+type PostAuthLogoutJSONRequestBody = RefreshRequest
+
+// PostAuthRefreshJSONRequestBody defines body for PostAuthRefresh for application/json ContentType.
+type PostAuthRefreshJSONRequestBody = RefreshRequest
+
+// PostAuthRegisterJSONRequestBody defines body for PostAuthRegister for application/json ContentType.
+type PostAuthRegisterJSONRequestBody = Credentials
+
 // PostLabelsJSONRequestBody defines body for PostLabels for application/json ContentType.
 type PostLabelsJSONRequestBody = LabelInput
 
 // PutLabelsIdJSONRequestBody defines body for PutLabelsId for application/json ContentType.
 type PutLabelsIdJSONRequestBody = LabelInput
 
+// PostProjectsJSONRequestBody defines body for PostProjects for application/json ContentType.
+type PostProjectsJSONRequestBody = ProjectInput
+
+// PutProjectsPidJSONRequestBody defines body for PutProjectsPid for application/json ContentType.
+type PutProjectsPidJSONRequestBody = ProjectInput
+
+// PostProjectsPidLabelsJSONRequestBody defines body for PostProjectsPidLabels for application/json ContentType.
+type PostProjectsPidLabelsJSONRequestBody = LabelInput
+
+// PutProjectsPidMembersUidJSONRequestBody defines body for PutProjectsPidMembersUid for application/json ContentType.
+type PutProjectsPidMembersUidJSONRequestBody = ProjectMemberInput
+
+// PostProjectsPidTasksJSONRequestBody defines body for PostProjectsPidTasks for application/json ContentType.
+type PostProjectsPidTasksJSONRequestBody = TaskInput
+
 // PostTasksJSONRequestBody defines body for PostTasks for application/json ContentType.
 type PostTasksJSONRequestBody = TaskInput
 
+// PostTasksBulkJSONRequestBody defines body for PostTasksBulk for application/json ContentType.
+type PostTasksBulkJSONRequestBody = BulkTaskRequest
+
+// PatchTasksIdApplicationMergePatchPlusJSONRequestBody defines body for PatchTasksId for application/merge-patch+json ContentType.
+type PatchTasksIdApplicationMergePatchPlusJSONRequestBody = TaskPatch
+
+// PatchTasksIdJSONRequestBody defines body for PatchTasksId for application/json ContentType.
+type PatchTasksIdJSONRequestBody = TaskPatch
+
 // PutTasksIdJSONRequestBody defines body for PutTasksId for application/json ContentType.
 type PutTasksIdJSONRequestBody = TaskInput
 
+// PutTasksIdAssigneesJSONRequestBody defines body for PutTasksIdAssignees for application/json ContentType.
+type PutTasksIdAssigneesJSONRequestBody PutTasksIdAssigneesJSONBody
+
+// PostTasksIdCommentsJSONRequestBody defines body for PostTasksIdComments for application/json ContentType.
+type PostTasksIdCommentsJSONRequestBody = CommentInput
+
+// PutTasksIdCommentsCommentIdJSONRequestBody defines body for PutTasksIdCommentsCommentId for application/json ContentType.
+type PutTasksIdCommentsCommentIdJSONRequestBody = CommentInput
+
+// PostTasksIdDependenciesJSONRequestBody defines body for PostTasksIdDependencies for application/json ContentType.
+type PostTasksIdDependenciesJSONRequestBody PostTasksIdDependenciesJSONBody
+
 // PutTasksIdLabelsJSONRequestBody defines body for PutTasksIdLabels for application/json ContentType.
 type PutTasksIdLabelsJSONRequestBody PutTasksIdLabelsJSONBody
 
+// PostTasksIdMoveJSONRequestBody defines body for PostTasksIdMove for application/json ContentType.
+type PostTasksIdMoveJSONRequestBody = TaskMove
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xXUW8bRRD+K9XA4xFfKE/3BlQCSymKRN+qKNr6Js62d7ub3TmQFZ1UO0i0VSVKH1IK",
-	"SJEAgapKIRJ9ACHgx1xsmn+Bds+xz7mNHSdOkPq22tvdmfnmm/nmtqElUyUFCjIQbYNpbWLK3HKF3cHE",
-	"LpSWCjVxdNstmUhtF9RRCBEY0ly0IQ+gpZERxuuM7OcNqVO7gpgRvkM8RQjqd3hceYoLwjZquy9Yil4j",
-	"mYrnNJKPduSdu9gi+4qLrSlURvMEeIpTeQAatzKuMYbodnkqGD6z5jF+i5l7HrPnQC9G09JcEZfC6zCK",
-	"eN0+cPF8JBYw5ycnTN3ibY0bEMFbjTGDGkP6NErujJFnWrPO1LwqzaXm1LEfUWSpRfJj3t6EAG7yOE6s",
-	"tyvy8wqg47uGmKY5AzXEKDNVa59I+tQ+hDEE0BSrWrY1GgMBfChTlaD94LO+GEZaUpxCyMVn+Y3LQh1R",
-	"u8XFhnRhckrsN4vxtZtMsDamKOja+6tNCOAz1MZBC8tL4VJo3ZIKBVMcIri+FC5dhwAUo03nZ2NcCW10",
-	"ybKpYjY5zRgi+AhppTxhu4JRUpgyi++GYdldBKFwF5lSCW+5q427pkxuWUB1Diym/vwwTfALBg+e9B/t",
-	"ubMmS1OmOxBBsfOi2Hle7Lw8/P3+659/KXpP+1/t9v9+5jgjjQeHVWmqQGxlaOgDGXfmwmBmiGXF5JMt",
-	"mHSGeQ395VopweFf3w8ePPEGPNg9KLrPiu4Xo8iL3tPyvDs5pEFjm8d5+bClZh2GG26/BKIZ1znxXt2r",
-	"/sNHR89/8nv1+Mv+/rfOsb3mjaK7X/WuvGczMp2YPjfCxablzLyaHtCYYkyzFAm1gej2NnD7nq1IOG5l",
-	"VrdOUiCoeHxS0PK1AIa99gRts0mU/n/ehnWGDL57Ndg9mB/Q8l7JX2Lm3tQudssd8GO/laHujMEfdvEq",
-	"4Bdt535Dw9GqltdZ96rwneO6Yu1JszFusCwhiJaDOrVOecRITV6IRppbUXIPJGsL1ZNR+s8kJ25i9Uxz",
-	"JIkl3vo6t9D0/il6fxS9X4vu/lxac0zXyyjZ8WyWD0t2lrKMwpgiMeNQJ5XFpeaMwuKidq3qUlrkbLEa",
-	"BTFFtaqBzhapqw0pXChJzkXz1y9++/fVwSTNT5GmS8fmymsnnEKpKTJXpVRN1VztVOb0K50ehim68Ojr",
-	"Gf/XeTzZsuu/6WcZ9xc7cFSY/PJo94ej+z8e/vlN0f266D0seo+L7p53AMnz/wIAAP//IgFxxXkSAAA=",
+	"H4sIAAAAAAAC/+x9e3PT1r7oV9H47D/OOdshCdDuXe7sOUN57HIutLlAT29v4WSErRAdHMmVZWg2zYwl",
+	"E3BIspOmhcAm5dVATAwOj5ZSCOG7bEV28hdf4c5vrSVpLWlJlhPHCTQzHWrH0nr+3s/ziZQ6kFUVSdFz",
+	"iT3nE/2SmJY09PHAcfE0/D8t5VKanNVlVUnsSVjFh5b5m1X80So+gw9G1b44bFd/s8yplUfX7NI9y7hu",
+	"maPwslUw7fFryy8KllG1ivOW+doqLqJ35ixjYeXOmGVU6yMlyxi1zJHl1zOWOWov/moZF/DLxvKLwsr9",
+	"Ofw0mQQGmrCKRcv81SreY998Qt5MJBO5VL80IMLi9cGslNiTyOmarJxODA0NJRNZURMHJJ3s8lDfEVFP",
+	"9Qc3Wrvxc+3qY6v4yh65vHp9FjY6Ml6bmLSMsmXMky2+XSz99cBxy5izJ67aS9OWMW0ZtyzTtIzq28UR",
+	"q2DWJ5bsmbKz0TJs6dLP6LF5y7hg3/7ZnixZxsLu7p1wgG9+gNMrmCeU+oxRv3IPj+c8VRZOJP79REKA",
+	"pTgvwrDmVP3uy5X5cXdYq2BaZgUuqHjTHntply5Z5mXLmFuZvVS78tgyquiAzana2CW7+g92kgWrYMAQ",
+	"xj8scwwO2BgL2cN19MBjyyzhKU8oiWRChqPDQJRIJhRxAE7/UF8HPuSom0kmDvV9qipSyHXYI+P2jZv0",
+	"MTsgRiBjzJ4dscwJy/jJMu6jHTAnvKtrt3vCEeuEFcRY7FAyoUm5rKrkJARFe7VUv3xWSsPnlKrokqLD",
+	"RzGbzcgpEbbQmdXUUxlp4I//k4P9nKcG/4Mm9SX2JP6l00PFTvxrrrMHv4Wn9J3IwtLKkzuw8Sc3MdJZ",
+	"xWmr+MgyX1jmnGUuWMWSZYxZ5l2EdhXLnLWKVy3jCro3dETm6NvFUuABQKpa6RWMaSwBbM+O1G78jPBw",
+	"HJ/q28WRxFAy8bGYPip9nZdyejv3jUjJgmWWER2CLS6/GK89+gmtKKOmzrT3GtJSVlLSkpKSpdwOSelT",
+	"tZQkWMZYbWbEvoxoXcGwq2PLLy8isPVAc3npR/vRNXsYXZJhwv2Zb2BPsLkp4ZDSo6mnNSmXEyyjAu8C",
+	"ol1E5AfAH7Z7UNVOyem0pLRzw7Xvx4HkIoirlR+sXp9EG3AIDwcG54Bivf7NHnlqGWMrz58hasRgp5CV",
+	"tAE5l5NVBXa7/GIcHnPPy53InLInK5ZZALAt3kEUbhKA15jDA/JmX9jdtRud4DyCdwS5hxRd0hQxc0zS",
+	"zkraAU1TtbaCsPkLrBqt3b44vFosAzgXH1jFRVjdp6p+UM0r6bZjlcugxwAyZ8ouRyGrOqKm5T4Z4xf7",
+	"OkM68RW6nO4W/GcaDqFe4BLqRJIne/A2Qx7rRM+gnfSIgxlVTB9X1cOidlpq703eBXEEgx1c6QjZcvGK",
+	"Zd5BFLWCeBMmntct4++WOQrH2aNJKVVJyzDQQVHOtJdqOSxZCOfv1NV/roh5vV/V5L9JbQbKEoLIBcB0",
+	"j8gYmOAjSWUJwddCbebW6vVJJOmMIeLwyDIfo+N/hgVIeLh4H432HLBuchwYXfE7xEQWnKsbg2Fh5xMe",
+	"o2MA84svvujYm9f7JUWHXUvsNgNiAjq7rKampFxOPJWRDii6rA+2VUooPa//fGHVuGJPjFvmBR9es1j/",
+	"Auj0hFkfnsOXnstns6qmS+kjUloWj6PNbS5qeSJIvVxdvXMTKQQepiExkgyNxLJcTj6tSGjZWU3NSpou",
+	"Y4FNTlP3JSu6dFrSYNP5nKRhaZAnoWrS13lZAxz4CkagHj+ZdB5XT/2PlNJhrL26Lqb6B8hZsfOn+qXU",
+	"mVx+gKN3zDxcfv29ZVSFY5/s7dj5wYdvF0vdH64WntauPMY6hVUsWOZ9xPzcg6kKQA6RpGCamNUlkv4d",
+	"JJ2769XJXfrRjSZajmKH5EG7NOuqCojzXrLMn+CGQGSZtYrT3Nk0SdSldK+IDqBP1QbgUyIt6lKHLg9I",
+	"vHf65IwUcgHJ0FvLyX/jbmcSra1Uu/I4kfTmlxX9w92JJGccXcyd6Q0FjSwwGSnde2qQM1cIH1h++dIq",
+	"mCvz4yvlRQS40wDEIQ+74hAIOcYSktMJb4wBi87iqTP0XTg5qKQHfcwdRcPwYTnHgWPR/R19lXVpINcI",
+	"570xE0PulKKmiYOBjdHDc5eXT8v6vn5ROc25/9Xbw/UbVQBkpMDYI+OWUSafl8D4YBdm3y6Wll/P1EqT",
+	"teugtAtqJi3AU0jnJ39TpHMCyKhKPpPBDIE9A0U6h/6Xz2SAxif26FpeGkom1Eya83ffDuGhJBoidH8H",
+	"FF3jgpyjLQBre2AVryO0JQyRJwlX7IUlLLd32zduugdjGdWV8rXVsaeBnYkpPNf5hKQArfqKgAtQvmwa",
+	"f0hLGQl90KScrmrwKZsHKewkB7vFlK5qwa0468DUxePScDsvxmvX/m4Z856u4N0De7Q8CoRAA+8ljWUt",
+	"MdPD7DESUinwGkpywQsJEj8grcyDMxe8Epw7XQtVlJDcEEqayM8OVXcuCyhCIpnIiKekDL5btAbexchp",
+	"ZjHhJFLDCj9ZStQ1+rT0qvB/O4i1oOPQ/saXxyNx9D7pQ0k6kOpAGLNODwwakzu48B7xNEdikBRdk6Um",
+	"qJyHugEql0xkyRzBA4Zfeh2GFvxZV3Uxw5Eafryz/Oo5pXcBlttP7tUeAXrXf53APNA/nu+QnT0605B1",
+	"0ovindrH+cyZ42LuDGUMCqMjftsJ0A4grrTpwzJ/toq36tUnllGpz72yR6/g7SSSLmRj6tOb00U9n3Op",
+	"UW9Wk1UNBOwkoHsvAvwcAoYB9azkfc/1y316L7yS8ygYDy3S2mCvlicr7xPzGT2xp0/M5DxwPaWqGUlU",
+	"iOiiS1oUrQmOzxzHXw8cFzoBaXOIB02OWca1+i83LfPyytKiZbxB0u53iNbfQTTyzdvFkmvUtQuz6CD9",
+	"hl8sNNJw4R22sdDd1dWFIAcsfXDC34gD2QwCPvWspKXzsGBAUDg2fNx7wBRwTBdBM0h6ZqrEEAc05DSL",
+	"MQOyIg/AHXbziMuA+M0h/CQsKwlPO9+DKIRus5dMwB6kd/lJgbl7Aazgb17bl29Thn1sOiUMNJH0FstZ",
+	"YNSCXOgLrMcHnmgV5UdIoEZ4emHeHi7ZL+9TEP6JfLo/kUwckdPpDJz9YfUcF0IdUB7kHAMF5jDl6tVR",
+	"e260Nn3PMsr1X8zllxfhszmFbP0jlnG9Nn0PKRmllae3HYCaQ2JTxX0GM16OCE5gI2Tr+Gf/xuuXn9eG",
+	"abz2ACuRTFCglUzsUwEs4YeTjVgFoTY8SrVPHeDrY9i+waGrl6/Uy29WCsNBKR7/5Lh8njn2yBJxWDUS",
+	"4ZOJU2qaAyv1C3fs6jN77CoeWTgiamfS6jmwi1ZrMw9rVy+1StWS0rL3il8JLSAZpoJnBBfTr+XVG9iE",
+	"fQvg5LoJ3i3vjxyXS/AI4q0rTMppoJ2lmz6As5KW4/IlZ19VJEs/A8n57rRllABkR0pWwSTKdtUeLrqm",
+	"5cbs1aemoftnro7Zhre+CEj+RAahezAI0Jp0VoaXc2G7Q3hNIOrtYgl8nXCPF1ZvX8Q4HkvKIcs4SmZr",
+	"qNB5y4rY1CElm9fD+ShhwOx++cgURB6A2h9ugTj6yfEjhxGfrY7Yw2XKhTCPXNsLiJl6dnrs5LVMzDMm",
+	"EohbHZaU03o/ZleYX7l/aUSl0IIjDoEvhabwj/HFUDJaO2RQSqyoLL+6hgiCjzLGlkTdfTYpivrhMXCA",
+	"Dpw0oKC+nRnfewBkTtVuvMCbw4QwNmGj6E2D7TtP8ogEd9ualAZ1SMzkglvOirncOVVDhJOC2T/tZAD2",
+	"z5wF09ZQ9kRWRp/Urjy2H01DWEKvsEPoEBC5fAMe+dm52tVL6LcF+/GE87liGT9a5jgmoyz67GKXsgtu",
+	"WdclDWb676/2dvw/seNvXR0f9e7oOPnHPzS0hrmrTnpb5x3aQVnKpF3fH3tmffAbF04yKjZ404o2uaSs",
+	"qIPM9nVe0gZdt4Gni3LltwHwDJyOYW/GK6Lm997lbe4wUvo59CODt9sSCcJ5h2cRxWa1oE0HDCYBWYp9",
+	"2LVl8QUpTRLTnymZwVCrT5iMEGpXJpYRrlGDWk50pEX10H4UeOMRQbR6UOK4j1MD37aM7yxzChHN70DO",
+	"NscwjoRsdZ1yj/POqcEoyQ/HP8W7P/bh9d5fqFzmTNOsXNboGIfCEMiVREKwiCJT//KvX3V1fLS346DY",
+	"0Xfy/K6hb+mvH7Jf/zz0b3/gXYwDoBRp/KBJscK1+8MKeYThyCCYanJc0YJoWHFlCxiIJ1jISm/W0dzW",
+	"O5ai6r05ohKubyz/QVEDs0tOUkfBO8EeN1Zlv6TIPEkoEOzyD8uo7O7aRWDS+Ily9lSxP5sb7xIwyJ/W",
+	"RAUwV1c5k74ZXrlvWMa8GytTGzMsYxYvJq5A34NJ4VE1I3FlRoZS8s3D+ITPh4QNVXzrpLR/MvgeQFei",
+	"KeX2nNNk5F7ABhz3q/PsgKiIIc4GTcV2LDGT+awvseerJjZ+smHQmZ8suiFOHJWHfGZOjywvSV8pF9iI",
+	"gzxwnkcP7hP+9OeuPwn267v24gQAkhND5IvRDUBRSk3z3KXu60aVeNfNqZU7ZTAMPbhVu/vYnlhYmX+E",
+	"iOoz7Lak7u6smJHTSDDp7cPRLIBU6K+9RDxyvroBuAmM3n0o1gnEGb1fTffCn8RMRj2HxkipSl9GTulo",
+	"E17ETHASTeqTNElJEa9nTtdEWdF7z8pqxpGXsjhOqFdX1d4MihRKJvJelEPvAIQ5ON6FPB3zgiR8Emrn",
+	"+RZSopKS8CqA1ap5Ha0Hh5b1Skiy5FqVJV2UM1xJBL0Un2pSIiyXDud0WCIjGeQ1uYM+q6Aw5JK3hrTC",
+	"TwgD/qHA4J6ZkKNWynqGL585jq24m/BhILlRPL67hiTGgxCkQx+jVNyyJ+eACXUJ/mIaYM0dv7b8GmSk",
+	"Q/uFbvBt1KbvIotnNSgGBj2uJJCYr4b6I3UZYxzvV88qFxKXGddI11Bm20LqQ9yNNtwTs6LzLdAztqKw",
+	"3rLT2gypPWBddcTfNdlUCdqHCP2NoIEjvHd3rUV6j1jaEWnglMQxV6wF+xzxqAlpcE3wm5O03vWH2znD",
+	"UO+4IlTYbTc8yJCbbvpk/BIf/DFi8qNkfL+IGRk5Dwk2Z2XpnKQJIeE9ZTCwGVWPRxVfeTyq+Iq1xM5b",
+	"xgPLnFq9+tTJsRq3CsYJBZxCKp4CMkVGUEQhn+kxeSEFQ0wPyAr7Is/s4eZVBfhVmQ3pr9ard+qTF1Hc",
+	"L5rCHD2h0BInOowE9mOhmA+0Aq7EdVRK5TUkLPSAB0I6F7x0NeU8kuOQsId3YEG/PQZfPOQQTcNOpu8t",
+	"v7r2drFkT8y6jhPwLzCPjdV/MSHc3Rz1IphSal7RBUjoMC/bj79DtzHh0r3YOu5n7pJ50p+W50FZ7dFP",
+	"K/cnPPfi0aOfHz7Aw1vA57+pSgzURBNRLySZs+ThwVGpT5Ny/aHxIhr+vVdXz+DclmZoKPsyb/pjEohZ",
+	"IYlmX4OTujY7U//5LhuVCdZtjy2ao6tX764WfrJf3keQO4Gg+AH6NyjW9cun+zPy6X6dA1t0psRqsWyX",
+	"LkL6z4l8V9eu1IConUGfIEhmzr7x1DK/t4zb4Loiuqj5BKli07UXJRwhUrs8U386GlhBXO61Rv6kicoZ",
+	"TgSmc0SAJCQF4oJlvLKMB/gnyxhbrVxzA+09jqLmT2UodqLkEd8LXDXMmqRPl3vd+VNgSjiqZjL5LE+q",
+	"9sKNSGz5jZ+XX4wiUvoLUCaP/lVxxIIb8bf86jkE3xTMwJNjDZ3hEda3IKMkP4Pmm5W0lKToIi/klUo0",
+	"uxVcvD3y1J4swV2gwKDaeNky7uOTHxC/IXE5RGTB37p4JmefeS/4gM9mF+FA5Ip0rNTHKHLEFRjPdhd2",
+	"ajwYQSbDYFQIyR/gcYTRYfv19yuFYZRt4kssqbq8wH0MQUTjCIl4oYVkWYmhUCHZ5QKnvIzIUFCJzEmE",
+	"FOAHKPEwQianouDIhFx9BedK5npRll8VDXqHuw4aarFbJfbB8WyS0UeU6pczaU3iaC5eQF7neTk91JnD",
+	"lCT3H7omSX+B0ehkbD+DsB9Nuvtohq3HWjP2c/ciSYJH0xivOyZTsfxJW0eP90Bgzep7RvK2Ek70nRhT",
+	"nzXFzxUaLoh7WP4o5xirdnAkLA6B5jIEe8wpe2nYMmZZvEHJ3e42qrguAQj9KIKFxIUijF+9enf5jRmH",
+	"a60DzxqJIJKSRsGK8QEvTK0kgb5xrajYV85zPoU6jEUNsI/nL165Px+gXrWZwvKLy2AVNKoNwSgkLp7a",
+	"Hh1o2my8aKSrmwasxq5uR/8DP7fQ89kxllZC1K0ATu3XbyzjYizao7laWi9fefEpYKDIjEDwmrxPzEhK",
+	"WgS9tYpVGgTao8LBowf+z1++OHDgfx/+8n99/OX+vV/+5chnyS8OBBS1KzFx3A2PToSMzLW0ePuitarI",
+	"vRFCZE6tlEv16jQVuD1rFW9b5hIS+5+9XSwd2vvpXsSGJsftkXEsznnL3JuTxc7j6plBlbeyHNKEGuEH",
+	"rS+hlzRZynEhyL+JmYJdYm0SBHbYB+dQDRcU9sAQ/jLzmDkFqrRRYp8h4RM0440FbEh+bJLeeN6L9QUs",
+	"JxOOJNHw6BndZWuakNfNqLdEvAdIXmu0/DbPuDi24p0ffNDAVtw021m5NG+XLq5cml95WbHMC/grT61d",
+	"cCoBefnViSSTq7GxTKmVhB+TdqDOKGlz/95Dh79MCphQJ4Ujn316/BP48OWBvUcPf4lMnoh6J4WPv0Q/",
+	"0p+TwqFPjx84+l97DyeFfZ99/unxpPD5p8cPHRZwLqX9hpRBsQqmR04EyxgVHJCA+go44OGEwtBlxD7I",
+	"agj/6Og+eDROUmOb2QlQa1KNCr1klIXPj+8LZzIN17+ZlJcfLUXljZGJwmwER9SznKhsnIo0IGajkr+i",
+	"U598t4dUEkTVvDhBJNDjaFq7NA0345ihIIQIxQ7Wbrwh5JEMUGIHYBO8diX2dO/k0ULk1VdO93pytJv9",
+	"5mUAsyt2j4DUIKAWAF9xHCSGKTb20adyVKBcEvNweWXkKfOKOUUP7uiM13E1K7w8dDij9uxVlLFUSmtq",
+	"Fv+J8h94G9HUbNOyMnvAXBHZR0IbuC+pycKAj7L3c9JT082jVJNoGMY2exxLemS+ho+1U7XnUN2JnxAF",
+	"qqBiCCQe1mH/5Gr/89hnnwpHJO20JKAZieVSVujJuhsYvhtSp4YH2XCE9jB3WAZyVfB1y4qbM7vV2Ti1",
+	"F5+4b5kXcEjNRnHFIAOkFgNcTqAPshWMLc4YrWJ0QVwFj1iPKGu8DO2UlMt5DrcgWnyTlTXQ+hR+XBIE",
+	"R74iwZFeyaYqroIH1Zmufv92sVSfmwpLIA34/AJLQL8Eihx8LImapMXJCqV26J+OGZzZLI8Wf55rVQxG",
+	"q8sQNUgUQsp7Kg/4fQwUTJIShU4Qqlp53w46y//PL44HeD229UB4ZGdGPY2c/nP16y9RRVesEfLhwSmp",
+	"iTwG+NrcJfbrehZXgZKVPhTdTMIBEYcRjqAwXzB5C3t7DlFxPHsS3Tu6dnTBoalZSRGzcmJPYteOrh27",
+	"SEIO2mKnCNUY4NNpSY9bPQFX88A1FMwpOkHSMipurVYmgiI01C4yNsOoklmMMi4WifIRK05+uk/Jdh92",
+	"VG1HXwNgRKGuh9Lgu5B0VIIiwVa8/eo8rn3qJClhKPNV1vBqdzVdSmQoGTmBnGaGj5aPguZvui5t1Y0o",
+	"59W8qHiFE3C5SF5dBIzqGRQOTaQU3trd4iLewl27cosK4/gtzzl9EAE/kJFE8CTCCuYkQjegasz6ObfG",
+	"TXlEauPyq3ur18cpyGMON2ROjxD19mnqADN7PGGzwZLskXUsSVfXtCDeqCQv1RvM1ZW6k00CeLdV/Ae6",
+	"yxfgfAWsv0x778Jm7yVlvXhL6Opinftdza7JnQAZBUr37MnxkKVk5AFZZ5bR5MQ8Q6hHuzrp+tBDJ30V",
+	"mHd2dUUUJ2yuKKFXBYhX/rY0aV++tZ5ypbu6doc97G6pk662OpRM7O7qavwOVY95KJn4IM4rvEK4SEzI",
+	"DwyI2mB4mbEyV+n18UxcrhsNSIkLSHRScdwXy7N61JwOcshh9JibTPAxydluyeXSqdJDrDRF6rZtGFx5",
+	"8je31qlXspTA2NrufXdXd+NXmIqu6wYWIlMm9nx1kgEdpgorCvCgtQNzCguNLHyoeT0WgKgo22YjIMQX",
+	"nRgLSHbz4nnx7u+iYp2ldd3pBl3PPFheig+9hgrs9dizT1AJ8yuWcQNVLXbviWhP9EX5wz7e4DTHBpMY",
+	"C84kxI6Ov7rRjOzDYzCsOYH9hGzHAiRds/l4DfcHkxq/WcZ9/0aTIWBHIGMLwV2biBNG1PeGLEWD5ByX",
+	"SlnGdB1E7usMGpyWc6TmGx8P6tdfrY495aWLLqCwOq7tGBnMJ0yoUuYWakYl1BEL5rBdwYm9H2ND6Cuk",
+	"1pmDXCeUiFw08P3sF7qRndzz3AteWD9TDKeK63i9XSzBSexAT/U6dgg0xpxj4r3uRO6HIRU5xC3C77tb",
+	"NjWyE3GxCYBindj0UXsLsfsiXGlKTFLWNxBfvckROsLpYSz0HFRcuw6LDbjs+X0Ks8jKXT4Q5suhfU+u",
+	"4SdWUQ/H+8sUsPVcPchXjKJMyd9yneezED3llCuk4qcC5p3DTjlLn30nytESvWS/0YROyEcltJx2H3hT",
+	"pE4YTyulE93jm3vaqQByPLjrjhgMGt3fYf1xd9euxq94HWjQG/EWhpubtFBFdSCYdAzzFM9kCFuOyQYZ",
+	"57JVMOkaFBx84LK4w14R2tYzN6pITXzexgvQXiczah5SPmr8httUC17YuTOO9BhstdE6KHMdADQ3wKdH",
+	"MyMU/YqPGdl/AzrsfvR3DBaH0kHy3ZAIhhLA3by2aaiQfbtvt0k6sLs7xu1yGua08HIpLnlov4/l40OE",
+	"2YiUEcKK13SbbbJpEo61zY/awI+igcljTRy3HCkhSMQoXCeIoemxxamT4G7imdDyeotoz+azs3agB4pB",
+	"wg0d6CLha8aW944CbwXWHIVw+AYxi8YRFWFU/Ajx1m4MiIVZA2jm3HYTWZStHuvGrPJNe3QGpE43h4Gv",
+	"fjPJZ1QRiYIRL2qCfouJdkAZoT8i68NN9K+bmF1dfvEIdOycqumCk4lbcuT9BYHXYwHrsRxwwJUSGyjY",
+	"zIhVZ2IytFucbfnFfct4tnr7YojGDK9Feug3UjhwakI2gM53webbHEAbYzhB298Xw5xiE/0rNNw7ppp1",
+	"m52CEG9OOVHPVTrMiAedPc4qGoCnP0t5gVtFjLOWgoFCYth3EdJB8MXsVa72HOix7I9EcXQ6brRCSGuV",
+	"zTUN0dfdTAnN99w81DI05ZD92HacsOzykApJnjfDyxULWmwozNoIIZepMtZmj4QLmkHAi66h13aJt63u",
+	"jUB2Cq+POiqHsUUEXi7hpW1RrDshSuh1oL1H3sLWiwi43bZftMN+wZNUmjBkZDfIkrE+4N1YY0bzdL49",
+	"+BJZ/fM9tGy02VPul66v0D5yumV5k0znfTPQcCgKbanx+cOJ3oB7Lmw4teHKmnH1pga5HriG+hvcS8p+",
+	"bNZ/KDvi6GXI/iCR/STVenfXR3TMwQnFGxW1a2cLPZVrv76CRGC6j7dpOkFAlK2F6VPiM4pU2AoOpC4l",
+	"JNsrqUw+LfU6OhwqSThZQSl6pGUJThdHYUawqbCK1FQtzOXXV1BdIhLnFBInRNF54hVspd+sLXQ3Jvhs",
+	"m5Zb79zjEhvffXCpTiC8KEp6DgvH2Q5q2RbCW2UYoXMGeUaStrHGSBK9HfLSQsH1nYqR4Uczh8TL+Ijt",
+	"ACrJHmVV51RZjWUi75HTR8jgW4g6U/ttxqyMd7JNprc2mWYK2W8CpQ7Hr87z+UCAmr97OraRV902ZiSG",
+	"GRWyxKEQXloA8gTdCElCx7FuQTz8nGzuPQlfa6+JIXAJkKl1FSmdFx13462WgjgTAhAD4kctcwQXX2oD",
+	"rCe5Q+ZbY3FcB2a4Dy0wjTL4iMLaMn1YsmG2SboLyuZYKB1uFoRyx2rhWMuYigcesG1ThTCq4AKg16ll",
+	"GlqObBUiUXGMXGAV9S2VKyBGBxvxAnu8dBZoTG24MTgofd2ptAA5fqjGaPE7JKneQX9/g9j1tGV853Tl",
+	"HSX2t5hWQMr+Z5pYRj2hNJBSQ+KNNk9GVaRv9N5UXsvhdrcNq1etvaW8c7frahYb1VcipnD8drHEgyQc",
+	"x4UDxbaF540VnqlSR5tq4/DXQSHl+YTGnb29HTiVDOn6ug3CTig6sBGs36tzvFbzibu9bTtKe+0oVHhi",
+	"hB0lr2xRZ9n9YAMcn/enEV587m7tnff+gIpUK73adgNtLTcQdTGk0EODEPc1h/ry2ES4KTNWJLqvPh4M",
+	"XrzoSLO/+QrlUbO3slae21w5olbeWpsmNFkkz3cc9oV5e7iE+vW14yDomuLhRxG3tG6TW/dqZ88/rF37",
+	"O+qQUK5dvUT6GhZM3GjQOQaiY6O6J6iFofkYPhRvg5H9zs3lxUV0bHPkeXPKLs2SeI6CAQX5Zh6uzN8k",
+	"hUvYuvgnFJwQ4clA0z4eQDcJ1KSMdFZUUriCSBX3BsRmfqS8PUcjhsSWf+2vPedWX47RhjfsBKv0SeE2",
+	"hqQE++MJ/AGOA8Djnru18LoI6H9N1WF0L7Cl66DnaGo5VC15KPoaA12gEQ9atFNkdUFwasXrqX7BVycm",
+	"JnLhEeR0CKGJLrXfJC65cwlEIXG3UjBFZRB3vGUJr3NI0BUXFZNeYEuMUMlpYwbbtoshTYmo3aPz4+dR",
+	"JERlkKp0j7+JmUxIvdaQK8blm+sX7vhuOmRV/WLOaRjAASkmmSOklaKvhBdy8jWGMR8EIS5MXwc/xQdD",
+	"5oCELidGqlBMwHQ6RobCZVQF+CYBk2T2zDHnF+yg6OXxBJ4MZ34hd5xXyAbTTd7x6tVRe260Nn0PBTVy",
+	"Sss2uRCv1HqrqstGrNAeWd8KdbUF66v/Yi6/vNi6E3T6HLTq/CLWZ4+sZ30tOT1iqkAratEBtr7AcfQi",
+	"7ZH1LbIl54hDdFt6jl5Hs1adY/Qi7ZH1LbIl5xjIyvTQx5xaNf5uwX+3ajPzTnvcKo+ghydmNrc79ayk",
+	"pfNSs3ybrixG98gObZ8dZy1eG5S1F4pvXPMsXOfjVWPj5ou3sO5ZizJ2WZ+TE5oNWhhdcY7RxLwqqvaL",
+	"FyT222m5wtugPwa86Tze7QrqG1lB3R89gxNLHiJL0I/I0/kbeIgptx6ELgTAQYAlg2Rcv3Bn5b6TxBIK",
+	"FHikJhVJjhzvFUmAQsDDpdXbjyyjIpxIdJxIoFKIrifWKK9eH8fPnVAcSwsgqIDtT7D22oUJ+7tnbgkI",
+	"sLT88+IUtrT88+LUYfWcVTA8U9Q/L055pqh/XpxyTVG4mCleGuoXWJu+h1YyVpuZx1VQfZUqatcuwdqK",
+	"r8gijYrTXxnyKWozFfvxEmvRcO0eqGfh12FICh3GUT3IEiqhQewmkYYSr0lfh3NOSUe0ShJzRKx6FJ5y",
+	"GRgHd1rRJQ3G+e+O//hXdzvfOs9+i+/lWxj9W080/tYZ4ltPUPnW47Xfyul/+9dkiwf8t3//A59Dbxk3",
+	"u4+ZPbyDsNiha44z1FF3UWFgAMAKeuA1sjtAPBJ0y3XfQr1ZBXDgx+lCtVX8+DHa9DCSx68TmM6v3f//",
+	"e2zs0ETBBcoLHiJH+HrVcQuLsNLqwhqrceKv4S0Y5wQEjc2V69x2vb9fVTvDnOa4U/2pfOZMePF2bPMl",
+	"zhGhT87okkYbrH2KTwVrB6QtlTnVDZZd0sLgARK+nqN1/GoV53Ch+VXjAUr/dH0l9uyT2pVpTssvEsBH",
+	"/IQ4ebT2vIQ9MNSTpCFs/ZfJ2s0ZNnM0rQ32anlUJV7gqJ/sC3gmY45MbE7V776kvTdoRHw+c1wywBi8",
+	"o+lBQ0QF5gWCF/8G3ClDRnGN7PF8sPbC0sqTO154IpGjQijFxwBAG0MtYGiYYwP7Qfh7QIZ2OCeww7ML",
+	"JBN92FHPlQc0KZfP+GonsbNKCGnjxr2Gt0wMNs7M5VMpSUojJZWsketaBSEklqDCa70Y0S86YM5318OP",
+	"UGS7VRLfnHPySd52vPM9GUPACSMT7eMfW4wdvCjURh9ick0zhfMN8mNoVY8qcVjBLaExSXYEqgsrD57V",
+	"f36MQwZX7pTrsy+dkhBebj0JLOYWS1wQcLdNb2mdpJ8gSr+HDuEVFK5yA2Z982NtzMDdVj07ItKXA10l",
+	"iUpP5vYZ6kKbfeTyp9BKduDD6c2qGTk1iMUze+l7xBmM6JbEbxdLqpbtFxXkgjNOKMsvCvVfp+B3zmGS",
+	"N1JiLiWmJfwK2YI5BdURwKs1ZU/edx+F49HklI5DCFhf4Fxt5AkEwHOJOk5WQmSdVxu3xfV5ky2u9O0e",
+	"9HbOFIf2MeCKRCAHTAQsXQRQoDW1VzaAbAWxJKoy+VYC5zYVCyPMejv7tQ0B/JRkTzgdG6sPlx0gVcFG",
+	"/qWjB/cJf9r10Yds5yjs57himT8hZlSxiiNeSD2pY+UK6dQcScGzCCYFxyQIgfsC7u7u2S5IyEj9wUu3",
+	"KCT0gBcHpKTgGjopqzIZwKiQYH5PG/J1v4+cLaTPfYiyAWe0VdnSxlhJevAMQ0lmnAEAmQ4EVH9c+5jt",
+	"7aoXRovosvIOEr23xdcarCqjps5I70EZeppL43BNp6JZSD6xJ7MNl5dfg8hef121jPHaxA3w8SBxtj5j",
+	"1K/gmM5by69e1S5MrN4ert+oWgWDTkkqC0H6s0BTNac0V1rKSkpaUlKylNshKX2qhrxOY7WZEdQD0+lT",
+	"VjCc0AMmdWN56Uf70TVkdCUSEr1rwXOhCVSGZ7CMGUMBGb/1lOB63pghQK9AnhDfq443DmQhfEq1mfn6",
+	"j4XayKhvYCqwEfRfr+AtKCHUZmnLG2QDIKP2hD1yyUm0v/52sdSz9/i+T5ICrUMCRzBNbAR0TpuX6P37",
+	"IuWbkl2+TXZ/p2SXLiFJmUycsNxcvJy4jagd4cXemlMCNEp1QsrZuuUVHwewCiai4tXV4XG7NM0E8TId",
+	"jK/yMoc8arPXPYHNqJPLmnyd3a81Zj+ZyCvy13npEH4XEROf/dSdgm8Y3QKUiLpFpiTve0yV3hMqY1SD",
+	"lxekOLoupvoHYJqI/jjUkMHKrbhghvnGKl6iSlLMuoE+XidavukYIj52d+1unFd4KL2XWu3vyFLkbfuw",
+	"nNuumNam6vIBSI8dfTKQz+hyVtT0Toi67kiLuoh6LvXJSN8hOXLIouJ5Hch8nqEITL2Q4/gSBb5cRp7m",
+	"BYFC2B0D4jdOwCbYhleeDwMzplzNu7t3+coyQx7wJTBUmXfR5MjdbCzQ2wR/+8Vhu/obKZDl5FBCNh/5",
+	"7JmUnKnA2uXfn1EV9mHw7zg+mJW8LtGOR4Xei5jJqOekdC/gIae88wVqTx+we1qZH18pL3KrNuOwACKD",
+	"QHRykXjXwUw3QpTVly/djOiV8jXUBj+GR729xChSpuGAW5RYA7fEZASckhURxVQGgg0ZWQW9tzY5pXsD",
+	"SCGXDBIccg2yoTxqKzeZjTFFjziYUcX0cVU9LGqnJfzeB3Fkj1w+m1U1XUofkdKyCHjZ0tIF1JmbU/g6",
+	"omSOzvPel95G/myflGGUazMPsTnKcRZf5xWtYfylFNJ6H9tj5OCMyGx+ndTh/ahRuWHMO1Z/5PcZSLak",
+	"PLsty24WOvC1Nq97ZDx63UnBA1d35M7s0m1XioO7Q8kw+BejKhz7ZG/Hzg8+JKLtUVFBmT5l4VBfh/Ol",
+	"AmUizVEsbdb/AZZ/J+WgEijvUf/hlV2ceLtYOvZffwVJFPGLCSyJ9uw/CKEVxYuW+QjlIKEwTVnJyIoU",
+	"DAOnRHCBlnH3y7msmkMWATYLiCtINqQ2ZNh3nuj4uBCdE1KcRCABAa71hQv2jadvF0vLS6PCqUFdyv2l",
+	"q6O7a+cuKokRI7q3RgQHTaZzoRxbgYDbWH1iyZ4pexBplOmUDUa3IUBnTtHB0GErc4C00eI2jiCrKV3S",
+	"O3K6JokDLGFuLPZzarbC6ayHIu/s+rBdi3WoAxOdgcHLvejEe8Ukdnd/GCS8zjGM+WgvPgl79upGS1tW",
+	"sWCZ91HyJNG3A4wlpQ7EtzwyLaQ2zMK4z1lSu8huK3OM25Q2vFXESHJXPXBk23Jke+LoPByMbQ2tzTys",
+	"Xb0EgtMRUTuTVs8pRKir/3ALmPEnx48cRuWNzDJC2CeIWkzjiId+Xc8mBfg3lxQGRDmjqwLV13se1oLj",
+	"z08k/gXSr32eSeP+8huIBKEkwZj2wtrlK/XyG/i8Jutg2wjJBsVEkPVvSvtnMndUuX3sg/Sg8T10Q24F",
+	"XyLDcvHZh3LxzvPkU0NDHjts/dfy6o2L9pN7tUc/45An2pwHOeIeJlbrd1+uzI9DzBh6y611Em31c5DR",
+	"AerNMuV4B7R1EjLekTyMLZDbwMqfrjExmw9ner5ISadvKq544j3DYEClVh3F4ix5wBizZ0cscwLF+EXV",
+	"pcY9TT1sGXM4Fs7kYggmGFu8J3Ea7ZiDVKT+O9QxZG0fu7t2xQgZ/D2j21bgxF3t4MQOrPwOOPE7HhDE",
+	"4bbxmHhnv5zTVW0wqrNsGMp/Ql59vzG/vZquc6bbym67lV1UtIdi0qG+EjptIbxiB52/KeBXcr2qglMk",
+	"mDoUJN7fnLKXhlEFZtwYi676gFMdnNJdY/bSfH3qMWvBXti9cycqgX7XLf/hewvnSHjP+xMholTd/fSe",
+	"349sATZihrmhxitkombYdzc7fCYszJeBBkfTe/8jfdub3M1FPIyTLUni3gLCBheOIolk53kGQ3yGgwid",
+	"nqY6+HPuM2XTVA0WzbeV+3dMuffBraPc++A2KA772TrP/WReCBQ1CdTNIlKF6bjHiQIONgCSccFUzYxw",
+	"YLVb7t6ukbtBNXK3TLBWPi1v+9g2p1YFsRY20DlIN5M2pgyGGf4OO31VNjt1z2u8s3G5e94cWzZ573eS",
+	"Rvy+JOxVcK1sJ3JwhFjt6T5M/Cy+AfWs1B78DzFnMEWpUPsjWhIy0b9TqGh/AfwHpukWU6MLqFkFky3L",
+	"u+DVqsMODlxFumBQf6cFqYXwkm5eATv3OKkWX1l3TFynl3RTdpqr0Z2yBuRcTlZOkx5SAi7c7m7EKfPr",
+	"zlHBt1m78cZNlD6heImZBYMRPQsGa3Uq8+JnURcNKBm3ZBlL9uIVyxivP79uGZPRZpoj6robkm5M6QW0",
+	"sC1CMrmA9TutvMCUSH5vSi+gzoPc+r5umTofdfUq1oRqfqF1YoxqVDUYc0pIqXlFF1C9+CXLmHNNruwL",
+	"c/5uac3ENB711r9JWiHaJF8n+4DVyN4dvcg71R5NOitL57b1o7boRz5MWn5ZgijvUOXIQ95OSUnHcslA",
+	"1rNvFl9hJRRqTJ5fuTSPUgcWIGzDvOwvSGWapDEGJaWguusjbmpzFNP2oOyAssWLvbaBObP1CHEvsu16",
+	"JBtuHeWeexSm5c7I2XBUiyiq5qDODSiGbFRiVFKDImeujZSDZE6HYSz7O9FMyy8us6MGqgzs3BnfAeoh",
+	"6THY+O8cS13Zxq10t42cW1Qu9iF2t33j5upPNywDwgc4+I3quUewUKojM2vJMMNKpmP1un75eW14lFSI",
+	"d6IaoqqtY6BiK2GbpldlHrFhMCUYY/TrviCHEGMBifan1hKN+/hUfudI7539NsZvfCQy1804Rd1BEHud",
+	"jgjhKmxYO1RzSkDNRn3dRb1+pMYbq/jK64lYfOU57iA75zGt1p5QdE2S/hLsHUq1B1q5NL/yEqUQFgxq",
+	"rxXCoh9N2o8qdFFBIdUvZ9KapKCyQcP3HLPpBZQftOjl76BFQK1aeulGObBcfz/J0JBnT8c+5hzvJmnY",
+	"cKqb3+S0TT7ZVjX3ZMC6KuRUTRfojJJYDSc3uUOjqkif9SFAi+zV+M60UmzcEjEZZ4aT3MZCvt4ZpJ+g",
+	"003ZR+Ds13ftRVIhwmuvFkK+HGKzbeppKaPzcyLGyKOJuf7wWBziRJmkOYA9YTpxjST1M2a3tQgbK1oF",
+	"n+pvx8Zs949uVf9op/noXGg3L+NWbfpe7To4W+mgseYbJ3fg+EtoRBxombzOvsbeyG7P5JaOuN00+V1t",
+	"mhxSumO7V/L6NUNO32T0GlgtwZ4E/OqUJGqStjev9yf2fHVy6OTQ/x8A0LCx/nI5AQA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/auth"
+	"github.com/yuchi1128/task-management-system/backend/internal/config"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+// 設定された管理者を既定のプロジェクトの admin にする
+// 誰でも登録できるため、登録しただけでは admin にならず、最初の管理者はここで設定する
+func bootstrapAdmin(ctx context.Context, s store.Store, cfg config.AuthConfig) error {
+	if cfg.AdminUsername == "" {
+		return nil
+	}
+	// 登録時と同じく小文字にそろえる
+	username := strings.ToLower(strings.TrimSpace(cfg.AdminUsername))
+	user, err := s.GetUserByUsername(ctx, username)
+	if errors.Is(err, store.ErrNotFound) {
+		user, err = createAdmin(ctx, s, username, cfg)
+	}
+	if err != nil {
+		return fmt.Errorf("bootstrap admin %q: %w", username, err)
+	}
+
+	roles, err := s.ProjectRoles(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("bootstrap admin %q: %w", username, err)
+	}
+	if roles[store.DefaultProjectID] == api.ProjectRoleAdmin {
+		return nil
+	}
+	if _, err := s.SetProjectMember(ctx, store.DefaultProjectID, user.ID, api.ProjectRoleAdmin); err != nil {
+		return fmt.Errorf("bootstrap admin %q: %w", username, err)
+	}
+	log.Printf("Granted admin on the default project to %s", username)
+	return nil
+}
+
+// 管理者のユーザーを auth.admin_password で作成
+func createAdmin(ctx context.Context, s store.Store, username string, cfg config.AuthConfig) (store.UserEntity, error) {
+	if cfg.AdminPassword == "" {
+		return store.UserEntity{}, errors.New("user does not exist; set auth.admin_password to create it")
+	}
+	if err := auth.ValidatePassword(cfg.AdminPassword); err != nil {
+		return store.UserEntity{}, fmt.Errorf("auth.admin_password: %w", err)
+	}
+	hash, err := auth.HashPassword(cfg.AdminPassword, cfg.BcryptCost)
+	if err != nil {
+		return store.UserEntity{}, err
+	}
+	log.Printf("Creating admin user %s", username)
+	return s.CreateUser(ctx, username, hash)
+}
@@ -1,50 +1,202 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
+	"errors"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/gorilla/mux"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 	"github.com/rs/cors"
+	"github.com/yuchi1128/task-management-system/backend/internal/auth"
+	"github.com/yuchi1128/task-management-system/backend/internal/blob"
+	"github.com/yuchi1128/task-management-system/backend/internal/config"
 	"github.com/yuchi1128/task-management-system/backend/internal/handlers"
+	"github.com/yuchi1128/task-management-system/backend/internal/migrate"
+	"github.com/yuchi1128/task-management-system/backend/internal/requestid"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+	"github.com/yuchi1128/task-management-system/backend/internal/trash"
 )
 
 func main() {
-	// PostgreSQLに接続
-	db, err := sqlx.Connect("postgres", "user=user password=password dbname=taskdb host=db port=5432 sslmode=disable")
+	// サブコマンド
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(os.Args[2:])
+		return
+	}
+
+	cfg, args, err := config.Load(os.Args[0], os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	// サブコマンドの打ち間違いなどを黙って無視しない
+	if len(args) > 0 {
+		log.Fatalf("unexpected arguments %q (subcommands: migrate)", args)
+	}
+	setupLogger(cfg)
+
+	dataStore, err := openStore(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := bootstrapAdmin(context.Background(), dataStore, cfg.Auth); err != nil {
+		log.Fatal(err)
+	}
+	blobStore, err := openBlobStore(cfg.Attachments)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	issuer, err := newIssuer(cfg.Auth)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	// ルーターを設定
 	router := mux.NewRouter()
-	taskHandler := handlers.NewTaskHandler(db)
-	router.HandleFunc("/tasks", taskHandler.GetTasks).Methods("GET")
-	router.HandleFunc("/tasks", taskHandler.CreateTask).Methods("POST")
-	router.HandleFunc("/tasks/{id:[0-9]+}", taskHandler.GetTask).Methods("GET")
-	router.HandleFunc("/tasks/{id:[0-9]+}", taskHandler.UpdateTask).Methods("PUT")
-	router.HandleFunc("/tasks/{id:[0-9]+}", taskHandler.DeleteTask).Methods("DELETE")
-
-	// ラベルハンドラーを初期化し、ルーターに追加
-	labelHandler := handlers.NewLabelHandler(db)
-	router.HandleFunc("/labels", labelHandler.GetLabels).Methods("GET")
-	router.HandleFunc("/labels", labelHandler.CreateLabel).Methods("POST")
-	router.HandleFunc("/labels/{id:[0-9]+}", labelHandler.GetLabel).Methods("GET")
-	router.HandleFunc("/labels/{id:[0-9]+}", labelHandler.UpdateLabel).Methods("PUT")
-	router.HandleFunc("/labels/{id:[0-9]+}", labelHandler.DeleteLabel).Methods("DELETE")
-	router.HandleFunc("/tasks/{id:[0-9]+}/labels", labelHandler.UpdateTaskLabels).Methods("PUT")
+	if err := handlers.Routes(router, handlers.Deps{
+		Store: dataStore,
+		Blobs: blobStore,
+		Attachments: handlers.AttachmentOptions{
+			MaxSize:      cfg.Attachments.MaxSize,
+			AllowedTypes: cfg.Attachments.AllowedTypes,
+		},
+		Issuer:       issuer,
+		AuthRequired: cfg.Auth.Required,
+		BcryptCost:   cfg.Auth.BcryptCost,
+	}); err != nil {
+		log.Fatal(err)
+	}
 
 	// CORSミドルウェアを適用
 	corsHandler := cors.New(cors.Options{
-		AllowedOrigins:   []string{"http://localhost:3000"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Content-Type", "Accept", "Origin", "Authorization"},
+		AllowedOrigins:   cfg.CORS.AllowedOrigins,
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Content-Type", "Accept", "Origin", "Authorization", "If-Match", "If-None-Match", "Range", "If-Range", requestid.Header},
+		ExposedHeaders:   []string{"ETag", "Content-Disposition", "Content-Range", "Accept-Ranges", requestid.Header},
 		AllowCredentials: true,
 	}).Handler(router)
 
+	server := &http.Server{
+		Addr:         cfg.ListenAddr,
+		Handler:      requestid.Middleware(corsHandler),
+		ReadTimeout:  cfg.HTTP.ReadTimeout,
+		WriteTimeout: cfg.HTTP.WriteTimeout,
+		IdleTimeout:  cfg.HTTP.IdleTimeout,
+	}
+
 	// サーバーを起動（CORSハンドラを使用）
-	log.Println("Server starting on :8080")
-	log.Fatal(http.ListenAndServe(":8080", corsHandler))
+	go func() {
+		log.Printf("Server starting on %s", cfg.ListenAddr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err)
+		}
+	}()
+
+	// シグナルを受けたらグレースフルシャットダウン
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// ゴミ箱の保持期間を過ぎたタスクを定期的に完全削除
+	if cfg.Trash.Retention > 0 {
+		go trash.NewPurger(dataStore, cfg.Trash.Retention, cfg.Trash.PurgeInterval).Run(ctx)
+	}
+	// 参照されなくなった添付ファイルの本体を定期的に削除
+	go blob.NewSweeper(blobStore, dataStore, handlers.AttachmentKeyPrefix, cfg.Attachments.OrphanGrace, cfg.Attachments.SweepInterval).Run(ctx)
+
+	<-ctx.Done()
+
+	log.Println("Shutting down server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Error shutting down server: %v", err)
+	}
+}
+
+// ログレベルを設定
+func setupLogger(cfg *config.Config) {
+	level, _ := cfg.SlogLevel()
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+}
+
+// データストアを初期化
+func openStore(cfg *config.Config) (store.Store, error) {
+	opts := store.Options{
+		DeletePolicy:        store.DeletePolicy(cfg.Subtasks.DeletePolicy),
+		EnforceDependencies: cfg.Dependencies.Enforce,
+	}
+	if cfg.Store == "memory" {
+		log.Println("Using in-memory store")
+		return store.NewMemoryStore(opts), nil
+	}
+
+	db, err := connectDB(cfg.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	// 起動時に未適用のマイグレーションを適用
+	migrator, err := migrate.New(db)
+	if err != nil {
+		return nil, err
+	}
+	if err := migrator.Up(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return store.NewPostgresStore(db, opts), nil
+}
+
+// 添付ファイルの本体の保存先を初期化
+func openBlobStore(cfg config.AttachmentsConfig) (blob.Store, error) {
+	if cfg.Backend == "s3" {
+		log.Printf("Storing attachments in S3 bucket %s", cfg.S3.Bucket)
+		return blob.NewS3(blob.S3Config{
+			Endpoint:        cfg.S3.Endpoint,
+			Region:          cfg.S3.Region,
+			Bucket:          cfg.S3.Bucket,
+			AccessKeyID:     cfg.S3.AccessKeyID,
+			SecretAccessKey: cfg.S3.SecretAccessKey,
+			PathStyle:       cfg.S3.PathStyle,
+		}, nil)
+	}
+	log.Printf("Storing attachments in %s", cfg.Dir)
+	return blob.NewFS(cfg.Dir)
+}
+
+// トークンの発行者を初期化（鍵が設定されていなければ起動のたびに生成する）
+func newIssuer(cfg config.AuthConfig) (*auth.Issuer, error) {
+	secret := []byte(cfg.Secret)
+	if len(secret) == 0 {
+		log.Println("Warning: auth.secret is not set; generating a random secret (tokens will not survive a restart)")
+		secret = make([]byte, auth.MinSecretLength)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+	}
+	if !cfg.Required {
+		log.Println("Warning: auth.required is false; requests without a token are accepted")
+	}
+	return auth.NewIssuer(secret, cfg.AccessTTL, cfg.RefreshTTL), nil
+}
+
+// PostgreSQLに接続し、コネクションプールを設定
+func connectDB(cfg config.DBConfig) (*sqlx.DB, error) {
+	db, err := sqlx.Connect("postgres", cfg.DSN())
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	return db, nil
 }
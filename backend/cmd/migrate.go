@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/yuchi1128/task-management-system/backend/internal/config"
+	"github.com/yuchi1128/task-management-system/backend/internal/migrate"
+)
+
+const migrateUsage = "usage: migrate [flags] up|down|status|to N"
+
+// migrate サブコマンドを実行
+func runMigrate(args []string) {
+	cfg, args, err := config.Load("migrate", args)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(args) == 0 {
+		log.Fatal(migrateUsage)
+	}
+
+	db, err := connectDB(cfg.DB)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	migrator, err := migrate.New(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		err = migrator.Up(ctx)
+	case "down":
+		err = migrator.Down(ctx)
+	case "to":
+		if len(args) != 2 {
+			log.Fatal(migrateUsage)
+		}
+		version, convErr := strconv.Atoi(args[1])
+		if convErr != nil {
+			log.Fatalf("Invalid migration version: %v", convErr)
+		}
+		err = migrator.To(ctx, version)
+	case "status":
+		err = printMigrationStatus(ctx, migrator)
+	default:
+		log.Fatal(migrateUsage)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// マイグレーションの適用状況を表形式で出力
+func printMigrationStatus(ctx context.Context, migrator *migrate.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, s := range statuses {
+		appliedAt := "pending"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+	}
+	return w.Flush()
+}
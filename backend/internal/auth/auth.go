@@ -0,0 +1,26 @@
+// Package auth はユーザーの認証（パスワードのハッシュ化と署名付きトークン）を提供します
+//
+// パスワードは bcrypt でハッシュ化して保存し、ログインしたユーザーには HS256 で署名した
+// 短命のアクセストークンと長命のリフレッシュトークン（JWT）を発行します。
+package auth
+
+import "context"
+
+// User は認証したユーザーです
+type User struct {
+	ID       int
+	Username string
+}
+
+type contextKey struct{}
+
+// NewContext は認証したユーザーを保持したコンテキストを返します
+func NewContext(ctx context.Context, user User) context.Context {
+	return context.WithValue(ctx, contextKey{}, user)
+}
+
+// FromContext はコンテキストから認証したユーザーを取り出します（認証していない場合は false）
+func FromContext(ctx context.Context) (User, bool) {
+	user, ok := ctx.Value(contextKey{}).(User)
+	return user, ok
+}
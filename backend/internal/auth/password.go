@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"fmt"
+	"sync"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// パスワードの長さの制限（bcrypt は72バイトまでしか使わないため、それより長いパスワードは受け付けない）
+const (
+	MinPasswordLength = 8
+	MaxPasswordBytes  = 72
+)
+
+// dummyHashes は存在しないユーザーでのログインでも照合の時間をそろえるための、コストごとのハッシュです
+// 照合の時間はコストで決まるため、実際のハッシュと同じコストで必要になったときに作成します
+var (
+	dummyMu     sync.Mutex
+	dummyHashes = make(map[int][]byte)
+)
+
+// dummyHash は cost のコストで作成したダミーのハッシュを返します
+func dummyHash(cost int) []byte {
+	dummyMu.Lock()
+	defer dummyMu.Unlock()
+	if hash, ok := dummyHashes[cost]; ok {
+		return hash
+	}
+	hash, _ := bcrypt.GenerateFromPassword([]byte("dummy password"), cost)
+	dummyHashes[cost] = hash
+	return hash
+}
+
+// ValidatePassword はパスワードが長さの制限を満たすかを確認します
+func ValidatePassword(password string) error {
+	if utf8.RuneCountInString(password) < MinPasswordLength {
+		return fmt.Errorf("password must be at least %d characters", MinPasswordLength)
+	}
+	if len(password) > MaxPasswordBytes {
+		return fmt.Errorf("password must be at most %d bytes", MaxPasswordBytes)
+	}
+	return nil
+}
+
+// HashPassword はパスワードを bcrypt でハッシュ化します
+func HashPassword(password string, cost int) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword はパスワードがハッシュと一致するかを確認します
+// hash が空の場合（ユーザーが存在しない場合）も、HashPassword に渡すのと同じ cost のハッシュを照合して
+// 同じだけ時間をかけてから false を返します
+func CheckPassword(hash, password string, cost int) bool {
+	if hash == "" {
+		bcrypt.CompareHashAndPassword(dummyHash(cost), []byte(password))
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
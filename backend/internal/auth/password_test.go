@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestValidatePassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		wantErr  bool
+	}{
+		{"too short", "1234567", true},
+		{"minimum length", "12345678", false},
+		{"multibyte characters count as one", "パスワードパスワ", false},
+		{"maximum bytes", strings.Repeat("a", MaxPasswordBytes), false},
+		{"too many bytes", strings.Repeat("a", MaxPasswordBytes+1), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidatePassword(tt.password); (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePassword(%q) error = %v, wantErr %v", tt.password, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckPassword(t *testing.T) {
+	hash, err := HashPassword("correct horse", bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+	if !CheckPassword(hash, "correct horse", bcrypt.MinCost) {
+		t.Error("CheckPassword with the right password = false, want true")
+	}
+	if CheckPassword(hash, "wrong horse", bcrypt.MinCost) {
+		t.Error("CheckPassword with a wrong password = true, want false")
+	}
+	if CheckPassword("", "correct horse", bcrypt.MinCost) {
+		t.Error("CheckPassword without a hash = true, want false")
+	}
+}
+
+func TestDummyHashUsesConfiguredCost(t *testing.T) {
+	// 存在しないユーザーの照合も、実際のハッシュと同じコストで時間をかける
+	for _, cost := range []int{bcrypt.MinCost, bcrypt.MinCost + 1} {
+		got, err := bcrypt.Cost(dummyHash(cost))
+		if err != nil {
+			t.Fatalf("bcrypt.Cost: %v", err)
+		}
+		if got != cost {
+			t.Errorf("dummy hash cost = %d, want %d", got, cost)
+		}
+	}
+}
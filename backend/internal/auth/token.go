@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// トークンの種類
+const (
+	AccessToken  = "access"
+	RefreshToken = "refresh"
+)
+
+// MinSecretLength はトークンの署名に使う鍵の最小の長さ（バイト）です
+const MinSecretLength = 32
+
+var (
+	// ErrInvalidToken はトークンの形式、署名、種類のいずれかが正しくない場合に返されます
+	ErrInvalidToken = errors.New("auth: invalid token")
+	// ErrTokenExpired はトークンの有効期限が切れている場合に返されます
+	ErrTokenExpired = errors.New("auth: token expired")
+)
+
+// tokenHeader は HS256 で署名した JWT のヘッダーです（検証では完全に一致するものだけを受け付けます）
+var tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Claims はトークンに含める情報です
+type Claims struct {
+	// Subject はユーザーIDです
+	Subject  string `json:"sub"`
+	Username string `json:"name"`
+	// Type は AccessToken か RefreshToken です
+	Type      string `json:"typ"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+	// ID はトークンごとに一意な値です（リフレッシュトークンの失効の管理に使います）
+	ID string `json:"jti"`
+}
+
+// User はトークンのユーザーを返します
+func (c Claims) User() User {
+	id, _ := strconv.Atoi(c.Subject)
+	return User{ID: id, Username: c.Username}
+}
+
+// Expires はトークンの有効期限を返します
+func (c Claims) Expires() time.Time {
+	return time.Unix(c.ExpiresAt, 0)
+}
+
+// Issuer はトークンの発行と検証を行います
+type Issuer struct {
+	secret     []byte
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	now        func() time.Time
+}
+
+// NewIssuer は secret で署名し、それぞれの有効期間のトークンを発行する Issuer を返します
+func NewIssuer(secret []byte, accessTTL, refreshTTL time.Duration) *Issuer {
+	return &Issuer{secret: secret, accessTTL: accessTTL, refreshTTL: refreshTTL, now: time.Now}
+}
+
+// AccessTTL はアクセストークンの有効期間です
+func (i *Issuer) AccessTTL() time.Duration {
+	return i.accessTTL
+}
+
+// Sign はユーザーに typ の種類のトークンを発行します
+func (i *Issuer) Sign(user User, typ string) (string, Claims, error) {
+	ttl := i.accessTTL
+	if typ == RefreshToken {
+		ttl = i.refreshTTL
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", Claims{}, err
+	}
+	now := i.now()
+	claims := Claims{
+		Subject:   strconv.Itoa(user.ID),
+		Username:  user.Username,
+		Type:      typ,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+		ID:        hex.EncodeToString(id),
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", Claims{}, err
+	}
+	signed := tokenHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + i.signature(signed), claims, nil
+}
+
+// Verify はトークンの署名と有効期限を検証し、typ の種類のトークンであればその情報を返します
+func (i *Issuer) Verify(token, typ string) (Claims, error) {
+	header, rest, ok := strings.Cut(token, ".")
+	if !ok || header != tokenHeader {
+		return Claims{}, ErrInvalidToken
+	}
+	payload, signature, ok := strings.Cut(rest, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(i.signature(header+"."+payload))) {
+		return Claims{}, ErrInvalidToken
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+	var claims Claims
+	if err := json.Unmarshal(data, &claims); err != nil || claims.Type != typ || claims.ID == "" {
+		return Claims{}, ErrInvalidToken
+	}
+	if user := claims.User(); user.ID < 1 || user.Username == "" {
+		return Claims{}, ErrInvalidToken
+	}
+	if !i.now().Before(claims.Expires()) {
+		return Claims{}, ErrTokenExpired
+	}
+	return claims, nil
+}
+
+func (i *Issuer) signature(signed string) string {
+	h := hmac.New(sha256.New, i.secret)
+	h.Write([]byte(signed))
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestIssuer(now *time.Time) *Issuer {
+	i := NewIssuer([]byte(strings.Repeat("k", MinSecretLength)), 15*time.Minute, 24*time.Hour)
+	i.now = func() time.Time { return *now }
+	return i
+}
+
+func TestIssuerSignVerify(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	i := newTestIssuer(&now)
+	user := User{ID: 7, Username: "alice"}
+
+	access, claims, err := i.Sign(user, AccessToken)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if !claims.Expires().Equal(now.Add(15 * time.Minute)) {
+		t.Errorf("access token expires at %v, want 15 minutes later", claims.Expires())
+	}
+	got, err := i.Verify(access, AccessToken)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if got.User() != user || got.ID != claims.ID {
+		t.Errorf("claims = %+v, want %+v", got, claims)
+	}
+
+	refresh, refreshClaims, err := i.Sign(user, RefreshToken)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if !refreshClaims.Expires().Equal(now.Add(24 * time.Hour)) {
+		t.Errorf("refresh token expires at %v, want 24 hours later", refreshClaims.Expires())
+	}
+	// トークンごとにIDは異なる
+	if refreshClaims.ID == claims.ID {
+		t.Errorf("access and refresh tokens share ID %q", claims.ID)
+	}
+	// 種類の異なるトークンは受け付けない
+	if _, err := i.Verify(refresh, AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify(refresh token as access) error = %v, want ErrInvalidToken", err)
+	}
+
+	now = now.Add(15 * time.Minute)
+	if _, err := i.Verify(access, AccessToken); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("Verify(expired) error = %v, want ErrTokenExpired", err)
+	}
+	if _, err := i.Verify(refresh, RefreshToken); err != nil {
+		t.Errorf("Verify(refresh token) error = %v", err)
+	}
+}
+
+func TestIssuerVerifyRejectsTamperedTokens(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	i := newTestIssuer(&now)
+	token, _, err := i.Sign(User{ID: 7, Username: "alice"}, AccessToken)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	parts := strings.Split(token, ".")
+	other := NewIssuer([]byte(strings.Repeat("x", MinSecretLength)), time.Minute, time.Minute)
+	otherToken, _, err := other.Sign(User{ID: 7, Username: "alice"}, AccessToken)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"missing signature", parts[0] + "." + parts[1]},
+		{"different payload", parts[0] + "." + strings.Split(otherToken, ".")[1] + "." + parts[2]},
+		{"different secret", otherToken},
+		{"alg none", "eyJhbGciOiJub25lIiwidHlwIjoiSldUIn0." + parts[1] + "."},
+		{"modified signature", parts[0] + "." + parts[1] + "." + parts[2] + "x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := i.Verify(tt.token, AccessToken); !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("Verify error = %v, want ErrInvalidToken", err)
+			}
+		})
+	}
+}
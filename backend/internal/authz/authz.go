@@ -0,0 +1,188 @@
+// Package authz はプロジェクトの役割にもとづく認可（権限の判定）を提供します
+//
+// 役割ごとに持つ権限は policy として1か所で定義し、ハンドラーは操作に必要な権限を
+// Authorizer に問い合わせます。認証していないリクエスト（auth.required=false の場合）は判定しません。
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/auth"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+// Permission はプロジェクトに対する操作の権限です
+type Permission string
+
+const (
+	// ReadProject はプロジェクトとそのタスク、ラベル、コメント、添付ファイル、変更履歴を閲覧する権限です
+	ReadProject Permission = "project:read"
+	// WriteTasks はタスクとそのコメント、添付ファイルなどを追加、変更、削除する権限です
+	WriteTasks Permission = "tasks:write"
+	// WriteLabels はラベルを追加、変更、削除する権限です
+	WriteLabels Permission = "labels:write"
+	// ManageProject はプロジェクトの変更、アーカイブ、メンバーの管理をする権限です
+	ManageProject Permission = "project:manage"
+)
+
+// 役割ごとの権限
+var policy = map[api.ProjectRole][]Permission{
+	api.ProjectRoleViewer: {ReadProject},
+	api.ProjectRoleEditor: {ReadProject, WriteTasks, WriteLabels},
+	api.ProjectRoleAdmin:  {ReadProject, WriteTasks, WriteLabels, ManageProject},
+}
+
+// 役割を権限の少ない順に並べたもの（RolesWith の順序）
+var roles = []api.ProjectRole{api.ProjectRoleViewer, api.ProjectRoleEditor, api.ProjectRoleAdmin}
+
+// Allows は役割が権限を持つかを判定します
+func Allows(role api.ProjectRole, perm Permission) bool {
+	for _, p := range policy[role] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// RolesWith は権限を持つ役割を権限の少ない順に返します
+func RolesWith(perm Permission) []api.ProjectRole {
+	var granted []api.ProjectRole
+	for _, role := range roles {
+		if Allows(role, perm) {
+			granted = append(granted, role)
+		}
+	}
+	return granted
+}
+
+// DeniedError はプロジェクトのメンバーが、役割に含まれない権限を必要とする操作をした場合のエラーです
+// errors.Is で store.ErrForbidden として判定できます
+type DeniedError struct {
+	Permission Permission
+	ProjectID  int
+	// Role はプロジェクトでのユーザーの役割です
+	Role api.ProjectRole
+}
+
+func (e *DeniedError) Error() string {
+	return fmt.Sprintf("requires %s permission on project %d; your role is %s", e.Permission, e.ProjectID, e.Role)
+}
+
+func (e *DeniedError) Unwrap() error {
+	return store.ErrForbidden
+}
+
+// Store は判定に必要なストアの操作です
+type Store interface {
+	store.MemberStore
+	GetLabel(ctx context.Context, id int) (api.Label, error)
+}
+
+// Authorizer はリクエストのユーザーがプロジェクトでの権限を持つかを判定します
+type Authorizer struct {
+	store Store
+}
+
+// New はストアのメンバーと役割をもとに判定する Authorizer を作成します
+func New(s Store) *Authorizer {
+	return &Authorizer{store: s}
+}
+
+// Project はユーザーがプロジェクトで権限を持つことを確認します
+// メンバーでない場合は、プロジェクトの存在を明かさないよう store.ErrNotFound を返します
+// メンバーの役割に権限が含まれない場合は *DeniedError を返します
+func (a *Authorizer) Project(ctx context.Context, projectID int, perm Permission) error {
+	user, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	roles, err := a.store.ProjectRoles(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	role, member := roles[projectID]
+	if !member {
+		return store.ErrNotFound
+	}
+	if !Allows(role, perm) {
+		return &DeniedError{Permission: perm, ProjectID: projectID, Role: role}
+	}
+	return nil
+}
+
+// Task はユーザーがタスク（ゴミ箱のものを含む）のプロジェクトで権限を持つことを確認します
+func (a *Authorizer) Task(ctx context.Context, taskID int, perm Permission) error {
+	if _, ok := auth.FromContext(ctx); !ok {
+		return nil
+	}
+	projectID, err := a.store.TaskProjectID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	return a.Project(ctx, projectID, perm)
+}
+
+// Label はユーザーがラベルのプロジェクトで権限を持つことを確認します
+func (a *Authorizer) Label(ctx context.Context, labelID int, perm Permission) error {
+	if _, ok := auth.FromContext(ctx); !ok {
+		return nil
+	}
+	l, err := a.store.GetLabel(ctx, labelID)
+	if err != nil {
+		return err
+	}
+	return a.Project(ctx, l.ProjectId, perm)
+}
+
+// Scope はタスクや変更履歴の一覧を、ユーザーが権限を持つプロジェクトのものに絞り込む条件を返します
+// 認証していない場合は絞り込まないため nil を返します
+func (a *Authorizer) Scope(ctx context.Context, perm Permission) *store.MemberScope {
+	user, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return &store.MemberScope{UserID: user.ID, Roles: RolesWith(perm)}
+}
+
+// Projects はユーザーがプロジェクトで権限を持つかを判定する関数を返します（ラベルやプロジェクトの一覧の絞り込みに使う）
+// 認証していない場合はすべてのプロジェクトで true を返します
+func (a *Authorizer) Projects(ctx context.Context, perm Permission) (func(projectID int) bool, error) {
+	user, ok := auth.FromContext(ctx)
+	if !ok {
+		return func(int) bool { return true }, nil
+	}
+	roles, err := a.store.ProjectRoles(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return func(projectID int) bool {
+		role, ok := roles[projectID]
+		return ok && Allows(role, perm)
+	}, nil
+}
+
+// NewTask はユーザーがタスクを作成するプロジェクトで tasks:write を持つことを確認します
+// projectID が 0 の場合はストアと同じく、親タスクのプロジェクト、親がなければ既定のプロジェクトで判定します
+// 親タスクが存在しない場合は既定のプロジェクトで判定し、親の誤りはストアの検証に任せます
+func (a *Authorizer) NewTask(ctx context.Context, projectID int, parentID *int) error {
+	if _, ok := auth.FromContext(ctx); !ok {
+		return nil
+	}
+	if projectID == 0 {
+		projectID = store.DefaultProjectID
+		if parentID != nil {
+			id, err := a.store.TaskProjectID(ctx, *parentID)
+			if err != nil && !errors.Is(err, store.ErrNotFound) {
+				return err
+			}
+			if err == nil {
+				projectID = id
+			}
+		}
+	}
+	return a.Project(ctx, projectID, WriteTasks)
+}
@@ -0,0 +1,124 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/auth"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+func TestRolesWith(t *testing.T) {
+	tests := []struct {
+		perm Permission
+		want string
+	}{
+		{ReadProject, "[viewer editor admin]"},
+		{WriteTasks, "[editor admin]"},
+		{WriteLabels, "[editor admin]"},
+		{ManageProject, "[admin]"},
+		{Permission("unknown"), "[]"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprint(RolesWith(tt.perm)); got != tt.want {
+			t.Errorf("RolesWith(%s) = %s, want %s", tt.perm, got, tt.want)
+		}
+	}
+}
+
+func TestAuthorizer(t *testing.T) {
+	ctx := context.Background()
+	s := store.NewMemoryStore(store.Options{})
+	admin, err := s.CreateUser(ctx, "alice", "hash")
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	viewer, err := s.CreateUser(ctx, "bob", "hash")
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	outsider, err := s.CreateUser(ctx, "carol", "hash")
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	for id, role := range map[int]api.ProjectRole{admin.ID: api.ProjectRoleAdmin, viewer.ID: api.ProjectRoleViewer} {
+		if _, err := s.SetProjectMember(ctx, store.DefaultProjectID, id, role); err != nil {
+			t.Fatalf("SetProjectMember: %v", err)
+		}
+	}
+	task, err := s.CreateTask(ctx, 0, api.TaskInput{Name: "task", Priority: api.TaskInputPriorityMiddle, Status: api.TaskInputStatusNotStarted})
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	a := New(s)
+	as := func(u store.UserEntity) context.Context {
+		return auth.NewContext(ctx, auth.User{ID: u.ID, Username: u.Username})
+	}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		perm Permission
+		// want が nil の場合は許可する
+		want *DeniedError
+	}{
+		{"admin manages", as(admin), ManageProject, nil},
+		{"viewer reads", as(viewer), ReadProject, nil},
+		{"viewer writes", as(viewer), WriteTasks, &DeniedError{Permission: WriteTasks, ProjectID: store.DefaultProjectID, Role: api.ProjectRoleViewer}},
+		// 認証していないリクエストは判定しない
+		{"unauthenticated", ctx, ManageProject, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, err := range []error{
+				a.Project(tt.ctx, store.DefaultProjectID, tt.perm),
+				a.Task(tt.ctx, task, tt.perm),
+			} {
+				if tt.want == nil {
+					if err != nil {
+						t.Errorf("error = %v, want nil", err)
+					}
+					continue
+				}
+				var denied *DeniedError
+				if !errors.As(err, &denied) || !errors.Is(err, store.ErrForbidden) || denied.Error() != tt.want.Error() {
+					t.Errorf("error = %v, want %v", err, tt.want)
+				}
+			}
+		})
+	}
+
+	// メンバーでないプロジェクトは、存在しない場合と同じく404にする
+	for _, projectID := range []int{store.DefaultProjectID, 999} {
+		if err := a.Project(as(outsider), projectID, ReadProject); !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("Project(%d) as outsider error = %v, want ErrNotFound", projectID, err)
+		}
+	}
+	if err := a.Task(as(outsider), task, ReadProject); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Task as outsider error = %v, want ErrNotFound", err)
+	}
+	if err := a.Task(as(admin), 999, ReadProject); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Task(missing) error = %v, want ErrNotFound", err)
+	}
+	// 親のないタスクは既定のプロジェクトに作成するので、そこで判定する
+	if err := a.NewTask(as(viewer), 0, nil); !errors.Is(err, store.ErrForbidden) {
+		t.Errorf("NewTask as viewer error = %v, want ErrForbidden", err)
+	}
+
+	visible, err := a.Projects(as(outsider), ReadProject)
+	if err != nil {
+		t.Fatalf("Projects: %v", err)
+	}
+	if visible(store.DefaultProjectID) {
+		t.Error("outsider can see the default project")
+	}
+	if scope := a.Scope(as(viewer), WriteTasks); scope == nil || scope.UserID != viewer.ID || fmt.Sprint(scope.Roles) != "[editor admin]" {
+		t.Errorf("Scope = %+v, want editor and admin roles of bob", scope)
+	}
+	if scope := a.Scope(ctx, ReadProject); scope != nil {
+		t.Errorf("Scope without a user = %+v, want nil", scope)
+	}
+}
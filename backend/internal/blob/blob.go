@@ -0,0 +1,50 @@
+// Package blob は添付ファイルの本体を保存するストレージを抽象化します
+//
+// メタデータはデータベースに保存し、本体だけをキーで識別してこのパッケージの Store に保存します。
+// ローカルのファイルシステムに保存する FS と、S3 互換のオブジェクトストレージに保存する S3 があります
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ErrNotFound は指定したキーの本体が存在しない場合に返されます
+var ErrNotFound = errors.New("blob: not found")
+
+// Store はキーで識別するバイナリの保存先です
+// キーは "/" で区切った相対パスの形で、".." や空の要素を含めることはできません
+type Store interface {
+	// Put は r から size バイトを読み込んでキーに保存します（同じキーがあれば置き換えます）
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Open は本体を読み出します。http.ServeContent で範囲指定に応えられるよう Seek できます
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+	// Delete は本体を削除します。存在しない場合もエラーにしません
+	Delete(ctx context.Context, key string) error
+	// List は prefix で始まるキーの本体を順に fn に渡します。fn がエラーを返すと中断します
+	List(ctx context.Context, prefix string, fn func(Info) error) error
+}
+
+// Info は保存されている本体の情報です
+type Info struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// ValidKey はキーが Store で使える形かどうかを確認します
+func ValidKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("blob: invalid key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("blob: invalid key %q", key)
+		}
+	}
+	return nil
+}
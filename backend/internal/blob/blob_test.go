@@ -0,0 +1,25 @@
+package blob
+
+import "testing"
+
+func TestValidKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"tasks/1/report.pdf", true},
+		{"a", true},
+		{"", false},
+		{"/tasks/1", false},
+		{"tasks//1", false},
+		{"tasks/1/", false},
+		{"tasks/../secret", false},
+		{"./tasks", false},
+		{`tasks\1`, false},
+	}
+	for _, tt := range tests {
+		if err := ValidKey(tt.key); (err == nil) != tt.want {
+			t.Errorf("ValidKey(%q) = %v, want valid %v", tt.key, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func newTestFS(t *testing.T) *FS {
+	t.Helper()
+	s, err := NewFS(filepath.Join(t.TempDir(), "blobs"))
+	if err != nil {
+		t.Fatalf("NewFS: %v", err)
+	}
+	return s
+}
+
+func TestFSPutOpenDelete(t *testing.T) {
+	ctx := context.Background()
+	s := newTestFS(t)
+	content := "hello, attachment"
+	if err := s.Put(ctx, "tasks/1/a.txt", strings.NewReader(content), int64(len(content)), "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	// 同じキーは置き換える
+	content = "replaced"
+	if err := s.Put(ctx, "tasks/1/a.txt", strings.NewReader(content), int64(len(content)), "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	f, err := s.Open(ctx, "tasks/1/a.txt")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if _, err := f.Seek(2, io.SeekStart); err != nil {
+		t.Fatalf("Seek: %v", err)
+	}
+	data, err := io.ReadAll(f)
+	f.Close()
+	if err != nil || string(data) != content[2:] {
+		t.Errorf("read = %q, %v, want %q", data, err, content[2:])
+	}
+
+	if err := s.Delete(ctx, "tasks/1/a.txt"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Open(ctx, "tasks/1/a.txt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Open after Delete = %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(ctx, "tasks/1/a.txt"); err != nil {
+		t.Errorf("Delete of a missing key: %v", err)
+	}
+}
+
+func TestFSPutErrors(t *testing.T) {
+	ctx := context.Background()
+	s := newTestFS(t)
+	// 大きさが合わない場合は保存しない
+	if err := s.Put(ctx, "short", strings.NewReader("abc"), 5, ""); err == nil {
+		t.Error("Put with a short body succeeded")
+	}
+	if _, err := s.Open(ctx, "short"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Open after a failed Put = %v, want ErrNotFound", err)
+	}
+	if err := s.Put(ctx, "../escape", strings.NewReader("x"), 1, ""); err == nil {
+		t.Error("Put with a key outside the root succeeded")
+	}
+	entries, err := os.ReadDir(s.root)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("root contains %d entries after failed Puts, want none", len(entries))
+	}
+}
+
+func TestFSList(t *testing.T) {
+	ctx := context.Background()
+	s := newTestFS(t)
+	for _, key := range []string{"tasks/1/a", "tasks/2/b", "other/c"} {
+		if err := s.Put(ctx, key, strings.NewReader(key), int64(len(key)), ""); err != nil {
+			t.Fatalf("Put(%q): %v", key, err)
+		}
+	}
+	// 書き込み中の一時ファイルは含めない
+	if err := os.WriteFile(filepath.Join(s.root, "tasks", tempPrefix+"x"), []byte("x"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	var keys []string
+	err := s.List(ctx, "tasks/", func(info Info) error {
+		if info.Size != int64(len(info.Key)) {
+			t.Errorf("%s: size = %d, want %d", info.Key, info.Size, len(info.Key))
+		}
+		keys = append(keys, info.Key)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	sort.Strings(keys)
+	if strings.Join(keys, ",") != "tasks/1/a,tasks/2/b" {
+		t.Errorf("keys = %v, want tasks/1/a and tasks/2/b", keys)
+	}
+
+	stop := errors.New("stop")
+	if err := s.List(ctx, "", func(Info) error { return stop }); !errors.Is(err, stop) {
+		t.Errorf("List = %v, want the error returned by fn", err)
+	}
+}
@@ -0,0 +1,289 @@
+package blob
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testAccessKeyID     = "AKIDEXAMPLE"
+	testSecretAccessKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+	testRegion          = "ap-northeast-1"
+	testBucket          = "attachments"
+)
+
+// fakeS3 は署名を検証する S3 のパス形式の API の偽物です
+// 署名は S3 の実装とは別に、リクエストとして届いた値から計算し直して比べます
+type fakeS3 struct {
+	secret  string
+	mu      sync.Mutex
+	objects map[string]fakeObject
+}
+
+type fakeObject struct {
+	data        []byte
+	contentType string
+}
+
+// newFakeS3 は fakeS3 のサーバーと、それに接続する S3 を返します
+// secret は S3 に設定するシークレットで、サーバーは testSecretAccessKey で署名を検証します
+func newFakeS3(t *testing.T, secret string) (*fakeS3, *S3) {
+	t.Helper()
+	fake := &fakeS3{secret: testSecretAccessKey, objects: make(map[string]fakeObject)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	s, err := NewS3(S3Config{
+		Endpoint:        server.URL,
+		Region:          testRegion,
+		Bucket:          testBucket,
+		AccessKeyID:     testAccessKeyID,
+		SecretAccessKey: secret,
+		PathStyle:       true,
+	}, server.Client())
+	if err != nil {
+		t.Fatalf("NewS3: %v", err)
+	}
+	return fake, s
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if code, message := f.verify(r); code != "" {
+		writeS3Error(w, http.StatusForbidden, code, message)
+		return
+	}
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+testBucket+"/")
+	if !ok || key == "" {
+		writeS3Error(w, http.StatusBadRequest, "InvalidRequest", "unexpected path "+r.URL.Path)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil || int64(len(data)) != r.ContentLength {
+			writeS3Error(w, http.StatusBadRequest, "IncompleteBody", "body does not match Content-Length")
+			return
+		}
+		f.objects[key] = fakeObject{data: data, contentType: r.Header.Get("Content-Type")}
+	case http.MethodHead, http.MethodGet:
+		obj, ok := f.objects[key]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+			return
+		}
+		w.Header().Set("Content-Type", obj.contentType)
+		data, status := obj.data, http.StatusOK
+		if rng := r.Header.Get("Range"); rng != "" {
+			start, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"))
+			if err != nil || start >= len(data) {
+				writeS3Error(w, http.StatusRequestedRangeNotSatisfiable, "InvalidRange", rng)
+				return
+			}
+			data, status = data[start:], http.StatusPartialContent
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.WriteHeader(status)
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+	case http.MethodDelete:
+		// S3 と同じく、存在しないキーの削除も成功にする
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeS3Error(w, http.StatusMethodNotAllowed, "MethodNotAllowed", r.Method)
+	}
+}
+
+// verify は Signature Version 4 の署名を検証し、失敗した場合は S3 のエラーコードを返します
+func (f *fakeS3) verify(r *http.Request) (code, message string) {
+	auth := r.Header.Get("Authorization")
+	rest, ok := strings.CutPrefix(auth, "AWS4-HMAC-SHA256 ")
+	if !ok {
+		return "AccessDenied", "missing AWS4-HMAC-SHA256 authorization"
+	}
+	fields := make(map[string]string)
+	for _, part := range strings.Split(rest, ", ") {
+		name, value, _ := strings.Cut(part, "=")
+		fields[name] = value
+	}
+	amzDate := r.Header.Get("X-Amz-Date")
+	date, err := time.Parse("20060102T150405Z", amzDate)
+	if err != nil {
+		return "AccessDenied", "invalid x-amz-date " + amzDate
+	}
+	scope := date.Format("20060102") + "/" + testRegion + "/s3/aws4_request"
+	if fields["Credential"] != testAccessKeyID+"/"+scope {
+		return "AuthorizationHeaderMalformed", "unexpected credential " + fields["Credential"]
+	}
+
+	// 本体のハッシュは、空のボディ以外では UNSIGNED-PAYLOAD だけを受け付ける
+	payloadHash := r.Header.Get("X-Amz-Content-Sha256")
+	switch {
+	case payloadHash == "UNSIGNED-PAYLOAD" && r.Method == http.MethodPut:
+	case payloadHash == hex.EncodeToString(sha256Sum(nil)) && r.ContentLength <= 0:
+	default:
+		return "XAmzContentSHA256Mismatch", "unexpected x-amz-content-sha256 " + payloadHash
+	}
+
+	signed := strings.Split(fields["SignedHeaders"], ";")
+	for _, required := range []string{"host", "x-amz-content-sha256", "x-amz-date"} {
+		if !contains(signed, required) {
+			return "AccessDenied", required + " is not signed"
+		}
+	}
+	if r.Header.Get("Content-Type") != "" && !contains(signed, "content-type") {
+		return "AccessDenied", "content-type is not signed"
+	}
+	if r.Header.Get("Range") != "" && !contains(signed, "range") {
+		return "AccessDenied", "range is not signed"
+	}
+	var headers strings.Builder
+	for _, name := range signed {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		headers.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	path, query, _ := strings.Cut(r.RequestURI, "?")
+	params := strings.Split(query, "&")
+	sort.Strings(params)
+	canonicalRequest := strings.Join([]string{
+		r.Method, path, strings.Trim(strings.Join(params, "&"), "&"),
+		headers.String(), fields["SignedHeaders"], payloadHash,
+	}, "\n")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(sha256Sum([]byte(canonicalRequest))),
+	}, "\n")
+
+	key := []byte("AWS4" + f.secret)
+	for _, part := range []string{date.Format("20060102"), testRegion, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	if want := hex.EncodeToString(hmacSHA256(key, stringToSign)); fields["Signature"] != want {
+		return "SignatureDoesNotMatch", "The request signature we calculated does not match the signature you provided."
+	}
+	return "", ""
+}
+
+// object は保存されているオブジェクトを返します
+func (f *fakeS3) object(key string) (fakeObject, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	obj, ok := f.objects[key]
+	return obj, ok
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, message)
+}
+
+func sha256Sum(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestS3PutOpenDelete(t *testing.T) {
+	ctx := context.Background()
+	fake, s := newFakeS3(t, testSecretAccessKey)
+	// エスケープが必要な文字を含むキーでも署名が一致する
+	key := "tasks/1/報告書 (final)+v2.txt"
+	content := "hello, attachment"
+
+	if err := s.Put(ctx, key, strings.NewReader(content), int64(len(content)), "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if got, _ := fake.object(key); string(got.data) != content || got.contentType != "text/plain" {
+		t.Fatalf("stored object = %q (%s), want %q (text/plain)", got.data, got.contentType, content)
+	}
+
+	obj, err := s.Open(ctx, key)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer obj.Close()
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(data) != content {
+		t.Errorf("read %q, want %q", data, content)
+	}
+	// 範囲を指定した GET で途中から読み出す
+	if _, err := obj.Seek(7, io.SeekStart); err != nil {
+		t.Fatalf("Seek: %v", err)
+	}
+	if data, err = io.ReadAll(obj); err != nil || string(data) != content[7:] {
+		t.Errorf("read after Seek = %q, %v, want %q", data, err, content[7:])
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, ok := fake.object(key); ok {
+		t.Errorf("object %q still exists after Delete", key)
+	}
+	// 存在しないキーの削除はエラーにしない
+	if err := s.Delete(ctx, key); err != nil {
+		t.Errorf("Delete of a missing key: %v", err)
+	}
+}
+
+func TestS3EmptyObject(t *testing.T) {
+	ctx := context.Background()
+	_, s := newFakeS3(t, testSecretAccessKey)
+	if err := s.Put(ctx, "empty", strings.NewReader(""), 0, ""); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	obj, err := s.Open(ctx, "empty")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer obj.Close()
+	if data, err := io.ReadAll(obj); err != nil || len(data) != 0 {
+		t.Errorf("ReadAll = %q, %v, want empty", data, err)
+	}
+}
+
+func TestS3NotFound(t *testing.T) {
+	_, s := newFakeS3(t, testSecretAccessKey)
+	if _, err := s.Open(context.Background(), "tasks/1/missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Open of a missing key = %v, want ErrNotFound", err)
+	}
+}
+
+func TestS3SignatureMismatch(t *testing.T) {
+	_, s := newFakeS3(t, "wrong-secret")
+	err := s.Put(context.Background(), "key", strings.NewReader("x"), 1, "")
+	if err == nil || !strings.Contains(err.Error(), "SignatureDoesNotMatch") {
+		t.Errorf("Put with a wrong secret = %v, want SignatureDoesNotMatch", err)
+	}
+}
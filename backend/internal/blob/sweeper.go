@@ -0,0 +1,89 @@
+package blob
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// sweepBatch は参照を一度に確認する本体の数です
+const sweepBatch = 500
+
+// Referencer は本体がデータベースから参照されているかを確認できるストアです
+type Referencer interface {
+	// UnreferencedBlobs は keys のうち、どのメタデータからも参照されていないキーを返します
+	UnreferencedBlobs(ctx context.Context, keys []string) ([]string, error)
+}
+
+// Sweeper はメタデータから参照されなくなった本体（孤立した本体）を定期的に削除します
+// タスクの完全削除ではメタデータだけが消えるため、本体はこの Sweeper が後から削除します
+type Sweeper struct {
+	blobs    Store
+	refs     Referencer
+	prefix   string
+	grace    time.Duration
+	interval time.Duration
+}
+
+// NewSweeper は prefix で始まるキーの孤立した本体を interval ごとに削除する Sweeper を返します
+// アップロード中の本体を削除しないよう、保存してから grace が経っていない本体は残します
+func NewSweeper(blobs Store, refs Referencer, prefix string, grace, interval time.Duration) *Sweeper {
+	return &Sweeper{blobs: blobs, refs: refs, prefix: prefix, grace: grace, interval: interval}
+}
+
+// Run は ctx がキャンセルされるまで削除を繰り返します（起動直後にも1回実行します）
+func (s *Sweeper) Run(ctx context.Context) {
+	log.Printf("Sweeping orphaned blobs older than %s every %s", s.grace, s.interval)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.SweepOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SweepOnce は孤立した本体を1回だけ削除します
+func (s *Sweeper) SweepOnce(ctx context.Context) {
+	cutoff := time.Now().Add(-s.grace)
+	var keys []string
+	deleted := 0
+	flush := func() error {
+		orphans, err := s.refs.UnreferencedBlobs(ctx, keys)
+		keys = keys[:0]
+		if err != nil {
+			return err
+		}
+		for _, key := range orphans {
+			if err := s.blobs.Delete(ctx, key); err != nil {
+				return err
+			}
+			deleted++
+		}
+		return nil
+	}
+
+	err := s.blobs.List(ctx, s.prefix, func(info Info) error {
+		if info.ModTime.After(cutoff) {
+			return nil
+		}
+		keys = append(keys, info.Key)
+		if len(keys) < sweepBatch {
+			return nil
+		}
+		return flush()
+	})
+	if err == nil && len(keys) > 0 {
+		err = flush()
+	}
+	if err != nil && ctx.Err() == nil {
+		log.Printf("Error sweeping orphaned blobs: %v", err)
+	}
+	if deleted > 0 {
+		log.Printf("Deleted %d orphaned blobs", deleted)
+	}
+}
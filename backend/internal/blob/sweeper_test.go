@@ -0,0 +1,69 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeReferencer は referenced に含まれるキーだけを参照されているものとして扱います
+type fakeReferencer struct {
+	referenced map[string]bool
+	err        error
+}
+
+func (r fakeReferencer) UnreferencedBlobs(ctx context.Context, keys []string) ([]string, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	var orphans []string
+	for _, key := range keys {
+		if !r.referenced[key] {
+			orphans = append(orphans, key)
+		}
+	}
+	return orphans, nil
+}
+
+func TestSweepOnce(t *testing.T) {
+	ctx := context.Background()
+	s := newTestFS(t)
+	old := time.Now().Add(-2 * time.Hour)
+	for _, key := range []string{"tasks/1/kept", "tasks/1/orphan", "tasks/2/recent", "other/orphan"} {
+		if err := s.Put(ctx, key, strings.NewReader("x"), 1, ""); err != nil {
+			t.Fatalf("Put(%q): %v", key, err)
+		}
+		if key != "tasks/2/recent" {
+			if err := os.Chtimes(filepath.Join(s.root, filepath.FromSlash(key)), old, old); err != nil {
+				t.Fatalf("Chtimes: %v", err)
+			}
+		}
+	}
+
+	refs := fakeReferencer{referenced: map[string]bool{"tasks/1/kept": true}}
+	NewSweeper(s, refs, "tasks/", time.Hour, time.Hour).SweepOnce(ctx)
+
+	// 参照されていない古い本体だけを削除し、保存したばかりの本体と prefix の外は残す
+	want := map[string]bool{"tasks/1/kept": true, "tasks/1/orphan": false, "tasks/2/recent": true, "other/orphan": true}
+	for key, exists := range want {
+		f, err := s.Open(ctx, key)
+		if err == nil {
+			f.Close()
+		}
+		if (err == nil) != exists {
+			t.Errorf("%s: Open = %v, want exists %v", key, err, exists)
+		}
+	}
+
+	// 参照を確認できない場合は何も削除しない
+	NewSweeper(s, fakeReferencer{err: errors.New("connection refused")}, "", 0, time.Hour).SweepOnce(ctx)
+	if f, err := s.Open(ctx, "other/orphan"); err != nil {
+		t.Errorf("Open after a failed sweep: %v", err)
+	} else {
+		f.Close()
+	}
+}
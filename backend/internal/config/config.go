@@ -0,0 +1,539 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config はサーバーの設定です
+// 優先順位は 既定値 < YAMLファイル < 環境変数 < コマンドラインフラグ です
+type Config struct {
+	ListenAddr   string             `yaml:"listen_addr"`
+	Store        string             `yaml:"store"`
+	LogLevel     string             `yaml:"log_level"`
+	CORS         CORSConfig         `yaml:"cors"`
+	HTTP         HTTPConfig         `yaml:"http"`
+	DB           DBConfig           `yaml:"db"`
+	Trash        TrashConfig        `yaml:"trash"`
+	Subtasks     SubtasksConfig     `yaml:"subtasks"`
+	Dependencies DependenciesConfig `yaml:"dependencies"`
+	Attachments  AttachmentsConfig  `yaml:"attachments"`
+	Auth         AuthConfig         `yaml:"auth"`
+}
+
+type CORSConfig struct {
+	AllowedOrigins []string `yaml:"allowed_origins"`
+}
+
+type HTTPConfig struct {
+	ReadTimeout     time.Duration `yaml:"read_timeout"`
+	WriteTimeout    time.Duration `yaml:"write_timeout"`
+	IdleTimeout     time.Duration `yaml:"idle_timeout"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+// TrashConfig はゴミ箱の保持期間の設定です
+// Retention を0にすると完全削除を行いません
+type TrashConfig struct {
+	Retention     time.Duration `yaml:"retention"`
+	PurgeInterval time.Duration `yaml:"purge_interval"`
+}
+
+// SubtasksConfig はサブタスクの設定です
+// DeletePolicy はサブタスクを持つタスクを削除したときの扱いで、orphan, cascade, restrict のいずれかです
+type SubtasksConfig struct {
+	DeletePolicy string `yaml:"delete_policy"`
+}
+
+// DependenciesConfig はタスクの依存関係の設定です
+// Enforce を true にすると、完了していない依存先があるタスクを InProgress にできなくなります
+type DependenciesConfig struct {
+	Enforce bool `yaml:"enforce"`
+}
+
+// AttachmentsConfig は添付ファイルの設定です
+// Backend は本体の保存先で、fs（ローカルのディレクトリ）か s3（S3 互換のストレージ）のいずれかです
+// AllowedTypes は "image/*" のようにサブタイプを * にした指定もできます（空の場合はすべて許可）
+type AttachmentsConfig struct {
+	Backend       string        `yaml:"backend"`
+	Dir           string        `yaml:"dir"`
+	MaxSize       int64         `yaml:"max_size"`
+	AllowedTypes  []string      `yaml:"allowed_types"`
+	OrphanGrace   time.Duration `yaml:"orphan_grace"`
+	SweepInterval time.Duration `yaml:"sweep_interval"`
+	S3            S3Config      `yaml:"s3"`
+}
+
+// S3Config は添付ファイルの本体を保存する S3 互換のストレージの設定です
+type S3Config struct {
+	Endpoint        string `yaml:"endpoint"`
+	Region          string `yaml:"region"`
+	Bucket          string `yaml:"bucket"`
+	AccessKeyID     string `yaml:"access_key_id"`
+	SecretAccessKey string `yaml:"secret_access_key"`
+	PathStyle       bool   `yaml:"path_style"`
+}
+
+// AuthConfig はユーザー認証の設定です
+// Required を false にすると、トークンなしのリクエストも匿名のユーザーとして受け付けます
+// Secret はトークンの署名に使う32バイト以上の鍵です。空の場合は起動のたびに生成するため、再起動すると全員がログインし直しになります
+// AdminUsername のユーザーは起動時に既定のプロジェクトの admin になります。ユーザーがいなければ AdminPassword で作成します
+type AuthConfig struct {
+	Required      bool          `yaml:"required"`
+	Secret        string        `yaml:"secret"`
+	AccessTTL     time.Duration `yaml:"access_ttl"`
+	RefreshTTL    time.Duration `yaml:"refresh_ttl"`
+	BcryptCost    int           `yaml:"bcrypt_cost"`
+	AdminUsername string        `yaml:"admin_username"`
+	AdminPassword string        `yaml:"admin_password"`
+}
+
+type DBConfig struct {
+	Host            string        `yaml:"host"`
+	Port            int           `yaml:"port"`
+	User            string        `yaml:"user"`
+	Password        string        `yaml:"password"`
+	Name            string        `yaml:"name"`
+	SSLMode         string        `yaml:"sslmode"`
+	ConnectTimeout  time.Duration `yaml:"connect_timeout"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+}
+
+// Default は docker-compose の構成に合わせた既定値を返します
+func Default() Config {
+	return Config{
+		ListenAddr: ":8080",
+		Store:      "postgres",
+		LogLevel:   "info",
+		CORS: CORSConfig{
+			AllowedOrigins: []string{"http://localhost:3000"},
+		},
+		HTTP: HTTPConfig{
+			ReadTimeout:     15 * time.Second,
+			WriteTimeout:    30 * time.Second,
+			IdleTimeout:     60 * time.Second,
+			ShutdownTimeout: 10 * time.Second,
+		},
+		DB: DBConfig{
+			Host:            "db",
+			Port:            5432,
+			User:            "user",
+			Password:        "password",
+			Name:            "taskdb",
+			SSLMode:         "disable",
+			ConnectTimeout:  5 * time.Second,
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+		},
+		Trash: TrashConfig{
+			Retention:     30 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+		},
+		Subtasks: SubtasksConfig{
+			DeletePolicy: "orphan",
+		},
+		Attachments: AttachmentsConfig{
+			Backend:       "fs",
+			Dir:           "data/attachments",
+			MaxSize:       25 << 20,
+			OrphanGrace:   time.Hour,
+			SweepInterval: time.Hour,
+			S3: S3Config{
+				Region: "us-east-1",
+			},
+		},
+		Auth: AuthConfig{
+			Required:   true,
+			AccessTTL:  15 * time.Minute,
+			RefreshTTL: 30 * 24 * time.Hour,
+			BcryptCost: 12,
+		},
+	}
+}
+
+// setting は環境変数・フラグと設定項目の対応です
+type setting struct {
+	env   string
+	flag  string
+	usage string
+	set   setter
+}
+
+// setter は文字列の値を設定項目に反映します
+// isBool の設定は値のないフラグ（-flag）を -flag=true として受け付けます
+type setter struct {
+	apply  func(c *Config, v string) error
+	isBool bool
+}
+
+// flagValue はフラグの値を文字列のまま保持するflag.Valueです
+// 値の変換は環境変数と同じく setter で行います
+type flagValue struct {
+	value  string
+	isBool bool
+}
+
+func (f *flagValue) String() string     { return f.value }
+func (f *flagValue) Set(v string) error { f.value = v; return nil }
+func (f *flagValue) IsBoolFlag() bool   { return f.isBool }
+
+func settings() []setting {
+	return []setting{
+		{"LISTEN_ADDR", "listen", "待ち受けアドレス", setString(func(c *Config) *string { return &c.ListenAddr })},
+		{"STORE", "store", "データストアの種類 (postgres または memory)", setString(func(c *Config) *string { return &c.Store })},
+		{"LOG_LEVEL", "log-level", "ログレベル (debug, info, warn, error)", setString(func(c *Config) *string { return &c.LogLevel })},
+		{"CORS_ALLOWED_ORIGINS", "cors-origins", "CORSで許可するオリジン (カンマ区切り)", setList(func(c *Config) *[]string { return &c.CORS.AllowedOrigins })},
+		{"HTTP_READ_TIMEOUT", "http-read-timeout", "リクエスト読み込みのタイムアウト", setDuration(func(c *Config) *time.Duration { return &c.HTTP.ReadTimeout })},
+		{"HTTP_WRITE_TIMEOUT", "http-write-timeout", "レスポンス書き込みのタイムアウト", setDuration(func(c *Config) *time.Duration { return &c.HTTP.WriteTimeout })},
+		{"HTTP_IDLE_TIMEOUT", "http-idle-timeout", "keep-alive接続のアイドルタイムアウト", setDuration(func(c *Config) *time.Duration { return &c.HTTP.IdleTimeout })},
+		{"HTTP_SHUTDOWN_TIMEOUT", "http-shutdown-timeout", "グレースフルシャットダウンの待ち時間", setDuration(func(c *Config) *time.Duration { return &c.HTTP.ShutdownTimeout })},
+		{"POSTGRES_HOST", "db-host", "PostgreSQLのホスト", setString(func(c *Config) *string { return &c.DB.Host })},
+		{"POSTGRES_PORT", "db-port", "PostgreSQLのポート", setInt(func(c *Config) *int { return &c.DB.Port })},
+		{"POSTGRES_USER", "db-user", "PostgreSQLのユーザー", setString(func(c *Config) *string { return &c.DB.User })},
+		{"POSTGRES_PASSWORD", "db-password", "PostgreSQLのパスワード", setString(func(c *Config) *string { return &c.DB.Password })},
+		{"POSTGRES_DB", "db-name", "PostgreSQLのデータベース名", setString(func(c *Config) *string { return &c.DB.Name })},
+		{"POSTGRES_SSLMODE", "db-sslmode", "PostgreSQLのsslmode", setString(func(c *Config) *string { return &c.DB.SSLMode })},
+		{"DB_CONNECT_TIMEOUT", "db-connect-timeout", "データベース接続のタイムアウト", setDuration(func(c *Config) *time.Duration { return &c.DB.ConnectTimeout })},
+		{"DB_MAX_OPEN_CONNS", "db-max-open-conns", "コネクションプールの最大接続数 (0は無制限)", setInt(func(c *Config) *int { return &c.DB.MaxOpenConns })},
+		{"DB_MAX_IDLE_CONNS", "db-max-idle-conns", "コネクションプールの最大アイドル接続数", setInt(func(c *Config) *int { return &c.DB.MaxIdleConns })},
+		{"DB_CONN_MAX_LIFETIME", "db-conn-max-lifetime", "接続を再利用できる最大時間", setDuration(func(c *Config) *time.Duration { return &c.DB.ConnMaxLifetime })},
+		{"DB_CONN_MAX_IDLE_TIME", "db-conn-max-idle-time", "接続をアイドルのまま保持する最大時間", setDuration(func(c *Config) *time.Duration { return &c.DB.ConnMaxIdleTime })},
+		{"TRASH_RETENTION", "trash-retention", "ゴミ箱のタスクを完全に削除するまでの期間 (0は削除しない)", setDuration(func(c *Config) *time.Duration { return &c.Trash.Retention })},
+		{"TRASH_PURGE_INTERVAL", "trash-purge-interval", "ゴミ箱の完全削除を実行する間隔", setDuration(func(c *Config) *time.Duration { return &c.Trash.PurgeInterval })},
+		{"SUBTASK_DELETE_POLICY", "subtask-delete-policy", "サブタスクを持つタスクを削除したときの扱い (orphan, cascade, restrict)", setString(func(c *Config) *string { return &c.Subtasks.DeletePolicy })},
+		{"DEPENDENCY_ENFORCE", "dependency-enforce", "完了していない依存先があるタスクを開始できないようにする (true または false)", setBool(func(c *Config) *bool { return &c.Dependencies.Enforce })},
+		{"ATTACHMENT_BACKEND", "attachment-backend", "添付ファイルの保存先 (fs または s3)", setString(func(c *Config) *string { return &c.Attachments.Backend })},
+		{"ATTACHMENT_DIR", "attachment-dir", "添付ファイルを保存するディレクトリ (backend が fs の場合)", setString(func(c *Config) *string { return &c.Attachments.Dir })},
+		{"ATTACHMENT_MAX_SIZE", "attachment-max-size", "添付ファイルの最大サイズ (バイト)", setInt64(func(c *Config) *int64 { return &c.Attachments.MaxSize })},
+		{"ATTACHMENT_ALLOWED_TYPES", "attachment-allowed-types", "添付できるファイルの種類 (カンマ区切り、image/* のような指定も可)", setList(func(c *Config) *[]string { return &c.Attachments.AllowedTypes })},
+		{"ATTACHMENT_ORPHAN_GRACE", "attachment-orphan-grace", "参照されなくなった添付ファイルの本体を削除するまでの猶予", setDuration(func(c *Config) *time.Duration { return &c.Attachments.OrphanGrace })},
+		{"ATTACHMENT_SWEEP_INTERVAL", "attachment-sweep-interval", "参照されなくなった添付ファイルの本体を削除する間隔", setDuration(func(c *Config) *time.Duration { return &c.Attachments.SweepInterval })},
+		{"S3_ENDPOINT", "s3-endpoint", "S3 互換ストレージのエンドポイント URL", setString(func(c *Config) *string { return &c.Attachments.S3.Endpoint })},
+		{"S3_REGION", "s3-region", "S3 のリージョン", setString(func(c *Config) *string { return &c.Attachments.S3.Region })},
+		{"S3_BUCKET", "s3-bucket", "添付ファイルを保存する S3 のバケット", setString(func(c *Config) *string { return &c.Attachments.S3.Bucket })},
+		{"S3_ACCESS_KEY_ID", "s3-access-key-id", "S3 のアクセスキーID", setString(func(c *Config) *string { return &c.Attachments.S3.AccessKeyID })},
+		{"S3_SECRET_ACCESS_KEY", "s3-secret-access-key", "S3 のシークレットアクセスキー", setString(func(c *Config) *string { return &c.Attachments.S3.SecretAccessKey })},
+		{"AUTH_REQUIRED", "auth-required", "トークンのないリクエストを拒否する (true または false)", setBool(func(c *Config) *bool { return &c.Auth.Required })},
+		{"AUTH_SECRET", "auth-secret", "トークンの署名に使う32バイト以上の鍵 (空の場合は起動時に生成)", setString(func(c *Config) *string { return &c.Auth.Secret })},
+		{"AUTH_ACCESS_TTL", "auth-access-ttl", "アクセストークンの有効期間", setDuration(func(c *Config) *time.Duration { return &c.Auth.AccessTTL })},
+		{"AUTH_REFRESH_TTL", "auth-refresh-ttl", "リフレッシュトークンの有効期間", setDuration(func(c *Config) *time.Duration { return &c.Auth.RefreshTTL })},
+		{"AUTH_BCRYPT_COST", "auth-bcrypt-cost", "パスワードのハッシュ化の bcrypt のコスト (4〜31)", setInt(func(c *Config) *int { return &c.Auth.BcryptCost })},
+		{"AUTH_ADMIN_USERNAME", "auth-admin-username", "起動時に既定のプロジェクトの admin にするユーザー名", setString(func(c *Config) *string { return &c.Auth.AdminUsername })},
+		{"AUTH_ADMIN_PASSWORD", "auth-admin-password", "admin のユーザーがいない場合に作成するときのパスワード", setString(func(c *Config) *string { return &c.Auth.AdminPassword })},
+		{"S3_PATH_STYLE", "s3-path-style", "S3 にパス形式の URL でアクセスする (MinIO などでは true)", setBool(func(c *Config) *bool { return &c.Attachments.S3.PathStyle })},
+	}
+}
+
+// Load は既定値・YAMLファイル・環境変数・フラグの順に設定を読み込み、検証して返します
+// YAMLファイルは -config フラグまたは CONFIG_FILE 環境変数で指定します
+// フラグ以外の残りの引数も返します
+func Load(name string, args []string) (*Config, []string, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "YAML設定ファイルのパス")
+	all := settings()
+	for _, s := range all {
+		fs.Var(&flagValue{isBool: s.set.isBool}, s.flag, fmt.Sprintf("%s (環境変数 %s)", s.usage, s.env))
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	cfg := Default()
+
+	if *configFile != "" {
+		if err := cfg.loadFile(*configFile); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, s := range all {
+		if v, ok := os.LookupEnv(s.env); ok && v != "" {
+			if err := s.set.apply(&cfg, v); err != nil {
+				return nil, nil, fmt.Errorf("env %s: %w", s.env, err)
+			}
+		}
+	}
+
+	var flagErr error
+	fs.Visit(func(f *flag.Flag) {
+		for _, s := range all {
+			if s.flag == f.Name {
+				if err := s.set.apply(&cfg, f.Value.String()); err != nil {
+					flagErr = errors.Join(flagErr, fmt.Errorf("flag -%s: %w", s.flag, err))
+				}
+			}
+		}
+	})
+	if flagErr != nil {
+		return nil, nil, flagErr
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return &cfg, fs.Args(), nil
+}
+
+// YAMLファイルの内容で上書き
+func (c *Config) loadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open config file: %w", err)
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate は設定値を検証し、問題を全てまとめたエラーを返します
+func (c *Config) Validate() error {
+	var errs []error
+	invalid := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if c.ListenAddr == "" {
+		invalid("listen_addr must not be empty")
+	}
+	if c.Store != "postgres" && c.Store != "memory" {
+		invalid("store must be postgres or memory, got %q", c.Store)
+	}
+	if _, err := c.SlogLevel(); err != nil {
+		invalid("log_level: %v", err)
+	}
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		if u, err := url.Parse(origin); err != nil || u.Scheme == "" || u.Host == "" {
+			invalid("cors.allowed_origins: invalid origin %q", origin)
+		}
+	}
+
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"http.read_timeout", c.HTTP.ReadTimeout},
+		{"http.write_timeout", c.HTTP.WriteTimeout},
+		{"http.idle_timeout", c.HTTP.IdleTimeout},
+		{"http.shutdown_timeout", c.HTTP.ShutdownTimeout},
+		{"db.connect_timeout", c.DB.ConnectTimeout},
+		{"db.conn_max_lifetime", c.DB.ConnMaxLifetime},
+		{"db.conn_max_idle_time", c.DB.ConnMaxIdleTime},
+		{"trash.retention", c.Trash.Retention},
+		{"attachments.orphan_grace", c.Attachments.OrphanGrace},
+	} {
+		if d.value < 0 {
+			invalid("%s must not be negative", d.name)
+		}
+	}
+
+	if c.Store == "postgres" {
+		if c.DB.Host == "" {
+			invalid("db.host must not be empty")
+		}
+		if c.DB.Port < 1 || c.DB.Port > 65535 {
+			invalid("db.port must be between 1 and 65535, got %d", c.DB.Port)
+		}
+		if c.DB.User == "" {
+			invalid("db.user must not be empty")
+		}
+		if c.DB.Name == "" {
+			invalid("db.name must not be empty")
+		}
+	}
+	if c.DB.MaxOpenConns < 0 {
+		invalid("db.max_open_conns must not be negative")
+	}
+	if c.DB.MaxIdleConns < 0 {
+		invalid("db.max_idle_conns must not be negative")
+	}
+	if c.DB.MaxOpenConns > 0 && c.DB.MaxIdleConns > c.DB.MaxOpenConns {
+		invalid("db.max_idle_conns (%d) must not exceed db.max_open_conns (%d)", c.DB.MaxIdleConns, c.DB.MaxOpenConns)
+	}
+
+	if c.Trash.Retention > 0 && c.Trash.PurgeInterval <= 0 {
+		invalid("trash.purge_interval must be positive when trash.retention is set")
+	}
+	switch c.Subtasks.DeletePolicy {
+	case "orphan", "cascade", "restrict":
+	default:
+		invalid("subtasks.delete_policy must be orphan, cascade or restrict, got %q", c.Subtasks.DeletePolicy)
+	}
+
+	switch c.Attachments.Backend {
+	case "fs":
+		if c.Attachments.Dir == "" {
+			invalid("attachments.dir must not be empty when attachments.backend is fs")
+		}
+	case "s3":
+		if u, err := url.Parse(c.Attachments.S3.Endpoint); err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			invalid("attachments.s3.endpoint: invalid URL %q", c.Attachments.S3.Endpoint)
+		}
+		if c.Attachments.S3.Region == "" {
+			invalid("attachments.s3.region must not be empty")
+		}
+		if c.Attachments.S3.Bucket == "" {
+			invalid("attachments.s3.bucket must not be empty")
+		}
+	default:
+		invalid("attachments.backend must be fs or s3, got %q", c.Attachments.Backend)
+	}
+	if c.Attachments.MaxSize <= 0 {
+		invalid("attachments.max_size must be positive")
+	}
+	for _, t := range c.Attachments.AllowedTypes {
+		if mainType, subType, ok := strings.Cut(t, "/"); !ok || mainType == "" || mainType == "*" || subType == "" {
+			invalid("attachments.allowed_types: invalid media type %q", t)
+		}
+	}
+	if c.Attachments.SweepInterval <= 0 {
+		invalid("attachments.sweep_interval must be positive")
+	}
+
+	if c.Auth.Secret != "" && len(c.Auth.Secret) < 32 {
+		invalid("auth.secret must be at least 32 bytes")
+	}
+	if c.Auth.AccessTTL <= 0 {
+		invalid("auth.access_ttl must be positive")
+	}
+	if c.Auth.RefreshTTL < c.Auth.AccessTTL {
+		invalid("auth.refresh_ttl must not be shorter than auth.access_ttl")
+	}
+	if c.Auth.BcryptCost < 4 || c.Auth.BcryptCost > 31 {
+		invalid("auth.bcrypt_cost must be between 4 and 31, got %d", c.Auth.BcryptCost)
+	}
+	if c.Auth.AdminPassword != "" && c.Auth.AdminUsername == "" {
+		invalid("auth.admin_password requires auth.admin_username")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// SlogLevel はログレベルの文字列をslog.Levelに変換します
+func (c *Config) SlogLevel() (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(c.LogLevel))
+	return level, err
+}
+
+// DSN はlib/pqに渡す接続文字列を返します
+func (db DBConfig) DSN() string {
+	params := []struct{ key, value string }{
+		{"host", db.Host},
+		{"port", strconv.Itoa(db.Port)},
+		{"user", db.User},
+		{"password", db.Password},
+		{"dbname", db.Name},
+		{"sslmode", db.SSLMode},
+	}
+	if db.ConnectTimeout > 0 {
+		params = append(params, struct{ key, value string }{"connect_timeout", strconv.Itoa(int(db.ConnectTimeout.Seconds()))})
+	}
+
+	var parts []string
+	for _, p := range params {
+		if p.value == "" {
+			continue
+		}
+		parts = append(parts, p.key+"="+quoteDSNValue(p.value))
+	}
+	return strings.Join(parts, " ")
+}
+
+// 空白や引用符を含む値をlib/pqの形式でクォートする
+func quoteDSNValue(v string) string {
+	if !strings.ContainsAny(v, ` '\`) {
+		return v
+	}
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `'`, `\'`)
+	return "'" + v + "'"
+}
+
+func setString(field func(c *Config) *string) setter {
+	return setter{apply: func(c *Config, v string) error {
+		*field(c) = v
+		return nil
+	}}
+}
+
+func setInt(field func(c *Config) *int) setter {
+	return setter{apply: func(c *Config, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return err
+		}
+		*field(c) = n
+		return nil
+	}}
+}
+
+func setInt64(field func(c *Config) *int64) setter {
+	return setter{apply: func(c *Config, v string) error {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return err
+		}
+		*field(c) = n
+		return nil
+	}}
+}
+
+func setDuration(field func(c *Config) *time.Duration) setter {
+	return setter{apply: func(c *Config, v string) error {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		*field(c) = d
+		return nil
+	}}
+}
+
+func setList(field func(c *Config) *[]string) setter {
+	return setter{apply: func(c *Config, v string) error {
+		var list []string
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		*field(c) = list
+		return nil
+	}}
+}
+
+func setBool(field func(c *Config) *bool) setter {
+	return setter{apply: func(c *Config, v string) error {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		*field(c) = b
+		return nil
+	}, isBool: true}
+}
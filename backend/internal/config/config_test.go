@@ -0,0 +1,144 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clearEnv は実行環境の設定がテストに影響しないよう、設定の環境変数を空にします（空の値は無視される）
+func clearEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("CONFIG_FILE", "")
+	for _, s := range settings() {
+		t.Setenv(s.env, "")
+	}
+}
+
+func TestLoadDefaults(t *testing.T) {
+	clearEnv(t)
+	cfg, args, err := Load("test", []string{"migrate", "up"})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.ListenAddr != ":8080" || cfg.Store != "postgres" || cfg.DB.Host != "db" {
+		t.Errorf("defaults = %+v", cfg)
+	}
+	if strings.Join(args, " ") != "migrate up" {
+		t.Errorf("remaining args = %v, want [migrate up]", args)
+	}
+}
+
+// 既定値・YAMLファイル・環境変数・フラグの順に後のものが優先される
+func TestLoadPrecedence(t *testing.T) {
+	clearEnv(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	yaml := "listen_addr: \":9000\"\nlog_level: debug\ndb:\n  host: file-host\n  port: 6543\n"
+	if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("POSTGRES_HOST", "env-host")
+	t.Setenv("HTTP_READ_TIMEOUT", "3s")
+
+	cfg, _, err := Load("test", []string{"-db-host", "flag-host", "-cors-origins", "http://a.example, http://b.example"})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"file only", cfg.ListenAddr, ":9000"},
+		{"file only", cfg.LogLevel, "debug"},
+		{"file over default", cfg.DB.Port, 6543},
+		{"flag over env and file", cfg.DB.Host, "flag-host"},
+		{"env over default", cfg.HTTP.ReadTimeout, 3 * time.Second},
+		{"list flag", strings.Join(cfg.CORS.AllowedOrigins, ","), "http://a.example,http://b.example"},
+		{"default", cfg.DB.Name, "taskdb"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+// 真偽値の設定は値のないフラグを true として受け付け、後続の引数を値として取り込まない
+func TestBoolFlag(t *testing.T) {
+	var enabled bool
+	s := setBool(func(c *Config) *bool { return &enabled })
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	v := &flagValue{isBool: s.isBool}
+	fs.Var(v, "enabled", "")
+	if err := fs.Parse([]string{"-enabled", "rest"}); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if err := s.apply(&Config{}, v.String()); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if !enabled {
+		t.Error("bare -enabled did not set the value to true")
+	}
+	if strings.Join(fs.Args(), " ") != "rest" {
+		t.Errorf("remaining args = %v, want [rest]", fs.Args())
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		args []string
+		file string
+		want []string
+	}{
+		{name: "invalid env value", env: map[string]string{"POSTGRES_PORT": "abc"}, want: []string{"env POSTGRES_PORT"}},
+		{name: "invalid flag value", args: []string{"-http-read-timeout", "soon"}, want: []string{"flag -http-read-timeout"}},
+		{name: "unknown file field", file: "unknown: 1\n", want: []string{"field unknown not found"}},
+		{name: "admin password without username", env: map[string]string{"AUTH_ADMIN_PASSWORD": "password123"}, want: []string{"auth.admin_password requires auth.admin_username"}},
+		{
+			// 検証の誤りはまとめて返す
+			name: "multiple invalid values",
+			args: []string{"-store", "sqlite", "-db-max-open-conns", "2", "-db-max-idle-conns", "3"},
+			want: []string{"store must be postgres or memory", "db.max_idle_conns (3) must not exceed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if tt.file != "" {
+				path := filepath.Join(t.TempDir(), "config.yaml")
+				if err := os.WriteFile(path, []byte(tt.file), 0o600); err != nil {
+					t.Fatal(err)
+				}
+				tt.args = append([]string{"-config", path}, tt.args...)
+			}
+			_, _, err := Load("test", tt.args)
+			if err == nil {
+				t.Fatal("Load succeeded, want an error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDSN(t *testing.T) {
+	db := Default().DB
+	db.Password = `p@ss word's`
+	want := `host=db port=5432 user=user password='p@ss word\'s' dbname=taskdb sslmode=disable connect_timeout=5`
+	if got := db.DSN(); got != want {
+		t.Errorf("DSN() = %q, want %q", got, want)
+	}
+}
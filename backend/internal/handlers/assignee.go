@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/auth"
+	"github.com/yuchi1128/task-management-system/backend/internal/authz"
+	"github.com/yuchi1128/task-management-system/backend/internal/problem"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+type AssigneeHandler struct {
+	store store.AssigneeStore
+	authz *authz.Authorizer
+}
+
+func NewAssigneeHandler(assigneeStore store.AssigneeStore, authorizer *authz.Authorizer) *AssigneeHandler {
+	return &AssigneeHandler{store: assigneeStore, authz: authorizer}
+}
+
+// UpdateTaskAssignees はタスクの担当者を置き換えます
+func (h *AssigneeHandler) UpdateTaskAssignees(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling UpdateTaskAssignees request")
+	pathParts := r.URL.Path[len("/tasks/"):]
+	taskID, err := strconv.Atoi(pathParts[:len(pathParts)-len("/assignees")])
+	if err != nil {
+		log.Printf("Invalid task ID: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid task ID"))
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), taskID, authz.WriteTasks); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to update task assignees")
+		return
+	}
+
+	var input api.PutTasksIdAssigneesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		log.Printf("Error decoding request body: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid request body"))
+		return
+	}
+
+	// If-Match はタスクのETagと比較する
+	var task api.Task
+	err = ifMatch(r, func(version int) (err error) {
+		task, err = h.store.SetTaskAssignees(r.Context(), taskID, input.UserIds, version)
+		return err
+	})
+	if err != nil {
+		log.Printf("Error updating task assignees: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to update task assignees")
+		return
+	}
+
+	log.Printf("Task %d now has %d assignees", taskID, len(task.Assignees))
+	writeVersioned(w, *task.Version, task)
+}
+
+// GetMyTasks はログインしているユーザーが担当するタスクを状態ごとに返します（ゴミ箱のタスクは含まない）
+func (h *TaskHandler) GetMyTasks(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling GetMyTasks request")
+	user, ok := auth.FromContext(r.Context())
+	if !ok {
+		writeUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	sort := r.URL.Query().Get("sort")
+	var response api.MyTasks
+	groups := []struct {
+		status string
+		tasks  *[]api.Task
+	}{
+		{string(api.TaskStatusNotStarted), &response.NotStarted},
+		{string(api.TaskStatusInProgress), &response.InProgress},
+		{string(api.TaskStatusCompleted), &response.Completed},
+	}
+	for _, group := range groups {
+		filter := store.TaskFilter{Assignees: []string{user.Username}, Statuses: []string{group.status}}
+		tasks, err := h.allTasks(r.Context(), filter, sort)
+		if err != nil {
+			log.Printf("Error fetching tasks: %v", err)
+			writeStoreError(w, r, err, "Task", "Failed to fetch tasks")
+			return
+		}
+		if tasks == nil {
+			tasks = []api.Task{}
+		}
+		*group.tasks = tasks
+	}
+
+	log.Printf("Fetched %d, %d, %d tasks assigned to %s", len(response.NotStarted), len(response.InProgress), len(response.Completed), user.Username)
+	writeJSONWithETag(w, r, "", response)
+}
+
+// 条件に一致するタスクをカーソルでたどってすべて取得する
+func (h *TaskHandler) allTasks(ctx context.Context, filter store.TaskFilter, sort string) ([]api.Task, error) {
+	// 閲覧できるプロジェクトのタスクに絞り込む
+	filter.Member = h.authz.Scope(ctx, authz.ReadProject)
+	query := store.TaskQuery{Filter: filter, Sort: sort, Limit: store.MaxPageSize}
+	var tasks []api.Task
+	for {
+		page, err := h.store.ListTasks(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, page.Tasks...)
+		if page.NextCursor == "" {
+			return tasks, nil
+		}
+		query.Cursor = page.NextCursor
+	}
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+func TestAssigneeHandler(t *testing.T) {
+	s := newAuthTestServer(t)
+	alicePair, alice := s.register(t, "alice")
+	bobPair, bob := s.register(t, "bob")
+	s.join(t, alice.Id, api.ProjectRoleAdmin)
+	admin := s.as(alicePair.AccessToken)
+	admin.do(t, http.MethodPut, fmt.Sprintf("/projects/%d/members/%d", store.DefaultProjectID, bob.Id),
+		map[string]string{"role": "editor"}).expect(t, http.StatusOK)
+
+	shared := admin.createTask(t, "shared", nil)
+	bobs := admin.createTask(t, "bob's", map[string]interface{}{"status": "InProgress"})
+	nobody := admin.createTask(t, "nobody's", nil)
+
+	var task api.Task
+	resp := admin.do(t, http.MethodPut, fmt.Sprintf("/tasks/%d/assignees", shared), map[string][]int{"user_ids": {bob.Id, alice.Id}}).
+		expect(t, http.StatusOK)
+	resp.decode(t, &task)
+	if len(task.Assignees) != 2 || task.Assignees[0].Username != "alice" || task.Assignees[1].Username != "bob" {
+		t.Fatalf("assignees = %+v, want alice and bob", task.Assignees)
+	}
+	if got := resp.Header.Get("ETag"); got != versionETag(*task.Version) {
+		t.Errorf("ETag = %q, want %q", got, versionETag(*task.Version))
+	}
+	admin.do(t, http.MethodPut, fmt.Sprintf("/tasks/%d/assignees", bobs), map[string][]int{"user_ids": {bob.Id}}).expect(t, http.StatusOK)
+
+	var mine api.MyTasks
+	s.as(bobPair.AccessToken).do(t, http.MethodGet, "/me/tasks", nil).expect(t, http.StatusOK).decode(t, &mine)
+	if len(mine.NotStarted) != 1 || *mine.NotStarted[0].Id != shared ||
+		len(mine.InProgress) != 1 || *mine.InProgress[0].Id != bobs || len(mine.Completed) != 0 {
+		t.Errorf("bob's tasks = %+v", mine)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []int
+	}{
+		{"assigned to me", "assignee=me", []int{shared}},
+		{"assigned to any of", "assignee=BOB&assignee=carol", []int{shared, bobs}},
+		{"unassigned", "unassigned=true", []int{nobody}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var list struct {
+				Tasks []api.Task `json:"tasks"`
+			}
+			admin.do(t, http.MethodGet, "/tasks?sort=id&"+tt.query, nil).expect(t, http.StatusOK).decode(t, &list)
+			var got []int
+			for _, task := range list.Tasks {
+				got = append(got, *task.Id)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("tasks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	p := admin.do(t, http.MethodPut, fmt.Sprintf("/tasks/%d/assignees", shared), map[string][]int{"user_ids": {999}}).
+		expect(t, http.StatusUnprocessableEntity).problem(t)
+	if p.Code != "invalid_reference" || len(p.Errors) == 0 || p.Errors[0].Field != "user_ids" {
+		t.Errorf("problem = %+v, want invalid_reference on user_ids", p)
+	}
+	admin.do(t, http.MethodPut, fmt.Sprintf("/tasks/%d/assignees", shared), map[string][]int{"user_ids": {}}, "If-Match", `"1"`).
+		expect(t, http.StatusPreconditionFailed).problem(t)
+	admin.do(t, http.MethodPut, "/tasks/999/assignees", map[string][]int{"user_ids": {}}).expect(t, http.StatusNotFound).problem(t)
+
+	// 認証していない場合、自分のタスクは取得できない
+	open := newTestServer(t)
+	open.do(t, http.MethodGet, "/me/tasks", nil).expect(t, http.StatusUnauthorized).problem(t)
+}
@@ -0,0 +1,463 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/authz"
+	"github.com/yuchi1128/task-management-system/backend/internal/blob"
+	"github.com/yuchi1128/task-management-system/backend/internal/problem"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+// AttachmentKeyPrefix は添付ファイルの本体のキーの接頭辞です（blob.Sweeper の対象になります）
+const AttachmentKeyPrefix = "attachments/"
+
+// アップロードで使う値
+const (
+	// multipartOverhead はファイル以外の multipart の部分（境界やヘッダー）に許す大きさです
+	multipartOverhead = 1 << 20
+	// maxFilenameLength はファイル名の長さの上限（文字数）です
+	maxFilenameLength = 255
+	// sniffLength は内容からメディアタイプを判定するために読む大きさです
+	sniffLength = 512
+)
+
+// inlineTypes はブラウザでそのまま表示してよいメディアタイプです（それ以外はダウンロードさせる）
+var inlineTypes = map[string]bool{
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+	"text/plain":      true,
+}
+
+// AttachmentOptions はアップロードできるファイルの制限です
+type AttachmentOptions struct {
+	// MaxSize はファイルの最大サイズ（バイト）です
+	MaxSize int64
+	// AllowedTypes はアップロードできるメディアタイプです。"image/*" の形も使えます（空の場合はすべて許可）
+	AllowedTypes []string
+}
+
+type AttachmentHandler struct {
+	store store.AttachmentStore
+	authz *authz.Authorizer
+	blobs blob.Store
+	opts  AttachmentOptions
+}
+
+func NewAttachmentHandler(attachmentStore store.AttachmentStore, authorizer *authz.Authorizer, blobs blob.Store, opts AttachmentOptions) *AttachmentHandler {
+	return &AttachmentHandler{store: attachmentStore, authz: authorizer, blobs: blobs, opts: opts}
+}
+
+// GetAttachments はタスクの添付ファイルを古い順に取得します
+func (h *AttachmentHandler) GetAttachments(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling GetAttachments request")
+	taskID, _, ok := attachmentPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), taskID, authz.ReadProject); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to fetch attachments")
+		return
+	}
+
+	attachments, err := h.store.ListAttachments(r.Context(), taskID)
+	if err != nil {
+		log.Printf("Error fetching attachments: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to fetch attachments")
+		return
+	}
+
+	log.Printf("Fetched %d attachments", len(attachments))
+	writeJSONWithETag(w, r, "", api.AttachmentList{Attachments: attachments})
+}
+
+// UploadAttachment は multipart/form-data の file の部分をタスクに添付します
+// 本体を一時ファイルに書き出してサイズとメディアタイプを確認してから、blob ストレージに保存します
+func (h *AttachmentHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling UploadAttachment request")
+	taskID, _, ok := attachmentPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), taskID, authz.WriteTasks); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to create attachment")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.opts.MaxSize+multipartOverhead)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		log.Printf("Error reading multipart body: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Request body must be multipart/form-data"))
+		return
+	}
+	part, err := filePart(reader)
+	if err != nil {
+		log.Printf("Error reading multipart body: %v", err)
+		h.writeUploadError(w, r, err)
+		return
+	}
+	if part == nil {
+		problem.Write(w, r, problem.Validation([]problem.FieldError{{Field: "file", Location: "body", Message: "file is required"}}))
+		return
+	}
+	defer part.Close()
+
+	upload, err := h.spool(part)
+	if err != nil {
+		log.Printf("Error reading uploaded file: %v", err)
+		h.writeUploadError(w, r, err)
+		return
+	}
+	defer upload.remove()
+
+	contentType, err := upload.contentType(part.Header.Get("Content-Type"))
+	if err != nil {
+		log.Printf("Error detecting content type: %v", err)
+		problem.Write(w, r, problem.New(http.StatusInternalServerError, problem.CodeInternal, "Failed to store attachment"))
+		return
+	}
+	if !allowedType(contentType, h.opts.AllowedTypes) {
+		log.Printf("Rejected attachment with content type %s", contentType)
+		p := problem.New(http.StatusUnsupportedMediaType, problem.CodeUnsupportedMediaType, "Files of type "+contentType+" are not allowed")
+		p.Errors = []problem.FieldError{{Field: "file", Location: "body", Message: "allowed types: " + strings.Join(h.opts.AllowedTypes, ", ")}}
+		problem.Write(w, r, p)
+		return
+	}
+
+	key, err := attachmentKey(taskID)
+	if err != nil {
+		log.Printf("Error generating attachment key: %v", err)
+		problem.Write(w, r, problem.New(http.StatusInternalServerError, problem.CodeInternal, "Failed to store attachment"))
+		return
+	}
+	if _, err := upload.file.Seek(0, io.SeekStart); err != nil {
+		log.Printf("Error rewinding uploaded file: %v", err)
+		problem.Write(w, r, problem.New(http.StatusInternalServerError, problem.CodeInternal, "Failed to store attachment"))
+		return
+	}
+	if err := h.blobs.Put(r.Context(), key, upload.file, upload.size, contentType); err != nil {
+		log.Printf("Error storing attachment content: %v", err)
+		problem.Write(w, r, problem.New(http.StatusInternalServerError, problem.CodeInternal, "Failed to store attachment"))
+		return
+	}
+
+	attachment, err := h.store.CreateAttachment(r.Context(), store.AttachmentEntity{
+		TaskID:      taskID,
+		Filename:    cleanFilename(part.FileName()),
+		ContentType: contentType,
+		Size:        upload.size,
+		Checksum:    upload.checksum,
+		BlobKey:     key,
+	})
+	if err != nil {
+		log.Printf("Error creating attachment: %v", err)
+		// 保存した本体は参照されないため削除する（失敗しても blob.Sweeper が後で削除する）
+		if err := h.blobs.Delete(r.Context(), key); err != nil {
+			log.Printf("Error deleting attachment content: %v", err)
+		}
+		writeStoreError(w, r, err, "Task", "Failed to create attachment")
+		return
+	}
+
+	log.Printf("Attachment created successfully with ID: %d (%d bytes)", attachment.Id, attachment.Size)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(attachment)
+}
+
+// GetAttachment は添付ファイルのメタデータを取得します
+func (h *AttachmentHandler) GetAttachment(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling GetAttachment request")
+	taskID, attachmentID, ok := attachmentPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), taskID, authz.ReadProject); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to fetch attachment")
+		return
+	}
+
+	a, err := h.store.GetAttachment(r.Context(), taskID, attachmentID)
+	if err != nil {
+		log.Printf("Error fetching attachment: %v", err)
+		writeStoreError(w, r, err, "Attachment", "Failed to fetch attachment")
+		return
+	}
+	writeJSONWithETag(w, r, "", a.ToAPIAttachment())
+}
+
+// DownloadAttachment は添付ファイルの本体を返します
+// Range と If-Range による部分的な取得、If-None-Match による条件付きの取得に対応します
+func (h *AttachmentHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling DownloadAttachment request")
+	taskID, attachmentID, ok := attachmentPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), taskID, authz.ReadProject); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to fetch attachment")
+		return
+	}
+
+	a, err := h.store.GetAttachment(r.Context(), taskID, attachmentID)
+	if err != nil {
+		log.Printf("Error fetching attachment: %v", err)
+		writeStoreError(w, r, err, "Attachment", "Failed to fetch attachment")
+		return
+	}
+	content, err := h.blobs.Open(r.Context(), a.BlobKey)
+	if err != nil {
+		log.Printf("Error opening attachment content: %v", err)
+		if errors.Is(err, blob.ErrNotFound) {
+			problem.Write(w, r, problem.New(http.StatusNotFound, problem.CodeNotFound, "Attachment content not found"))
+			return
+		}
+		problem.Write(w, r, problem.New(http.StatusInternalServerError, problem.CodeInternal, "Failed to fetch attachment"))
+		return
+	}
+	defer content.Close()
+
+	disposition := "attachment"
+	if mediaType, _, _ := mime.ParseMediaType(a.ContentType); inlineTypes[mediaType] {
+		disposition = "inline"
+	}
+	header := w.Header()
+	header.Set("Content-Type", a.ContentType)
+	header.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": a.Filename}))
+	header.Set("ETag", `"`+a.Checksum+`"`)
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Set("Content-Security-Policy", "sandbox; default-src 'none'")
+	http.ServeContent(w, r, "", a.CreatedAt, content)
+}
+
+// DeleteAttachment は添付ファイルをメタデータと本体ごと削除します
+func (h *AttachmentHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling DeleteAttachment request")
+	taskID, attachmentID, ok := attachmentPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), taskID, authz.WriteTasks); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to delete attachment")
+		return
+	}
+
+	a, err := h.store.DeleteAttachment(r.Context(), taskID, attachmentID)
+	if err != nil {
+		log.Printf("Error deleting attachment: %v", err)
+		writeStoreError(w, r, err, "Attachment", "Failed to delete attachment")
+		return
+	}
+	// 本体の削除に失敗しても、参照されなくなった本体は blob.Sweeper が後で削除する
+	if err := h.blobs.Delete(r.Context(), a.BlobKey); err != nil {
+		log.Printf("Error deleting attachment content: %v", err)
+	}
+
+	log.Println("Attachment deleted successfully")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// errFileTooLarge はファイルが最大サイズを超えたことを表します
+var errFileTooLarge = errors.New("file is too large")
+
+// アップロードの読み込みエラーを Problem にして書き込む
+func (h *AttachmentHandler) writeUploadError(w http.ResponseWriter, r *http.Request, err error) {
+	var maxErr *http.MaxBytesError
+	if errors.Is(err, errFileTooLarge) || errors.As(err, &maxErr) {
+		p := problem.New(http.StatusRequestEntityTooLarge, problem.CodePayloadTooLarge,
+			fmt.Sprintf("File must be at most %d bytes", h.opts.MaxSize))
+		p.Errors = []problem.FieldError{{Field: "file", Location: "body", Message: "file is too large"}}
+		problem.Write(w, r, p)
+		return
+	}
+	problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid multipart body"))
+}
+
+// filePart は multipart の中から file という名前のファイルの部分を探します（なければ nil）
+func filePart(reader *multipart.Reader) (*multipart.Part, error) {
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == "file" && part.FileName() != "" {
+			return part, nil
+		}
+		// ファイル以外の部分は読み捨てる
+		if _, err := io.Copy(io.Discard, part); err != nil {
+			return nil, err
+		}
+		part.Close()
+	}
+}
+
+// upload は一時ファイルに書き出したアップロード中のファイルです
+type upload struct {
+	file     *os.File
+	size     int64
+	checksum string
+}
+
+// spool はファイルの部分を一時ファイルに書き出し、サイズと SHA-256 を求めます
+func (h *AttachmentHandler) spool(part *multipart.Part) (*upload, error) {
+	f, err := os.CreateTemp("", "attachment-*")
+	if err != nil {
+		return nil, err
+	}
+	u := &upload{file: f}
+	hash := sha256.New()
+	// 最大サイズを1バイトでも超えたら打ち切る
+	n, err := io.Copy(io.MultiWriter(f, hash), io.LimitReader(part, h.opts.MaxSize+1))
+	if err == nil && n > h.opts.MaxSize {
+		err = errFileTooLarge
+	}
+	if err != nil {
+		u.remove()
+		return nil, err
+	}
+	u.size = n
+	u.checksum = hex.EncodeToString(hash.Sum(nil))
+	return u, nil
+}
+
+func (u *upload) remove() {
+	u.file.Close()
+	os.Remove(u.file.Name())
+}
+
+// contentType はファイルの内容からメディアタイプを判定します
+// 内容から判定できない（汎用的な型になる）場合だけ、クライアントが指定した型を使います
+func (u *upload) contentType(declared string) (string, error) {
+	head := make([]byte, sniffLength)
+	n, err := u.file.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	detected := http.DetectContentType(head[:n])
+	mediaType, _, _ := mime.ParseMediaType(detected)
+	if mediaType != "application/octet-stream" && mediaType != "text/plain" {
+		return detected, nil
+	}
+	declaredType, params, err := mime.ParseMediaType(declared)
+	if err != nil || declaredType == "application/octet-stream" || !strings.Contains(declaredType, "/") {
+		return detected, nil
+	}
+	// 宣言された型でも、テキストとして判定したものにバイナリの型を付けさせない（逆も同じ）
+	if (mediaType == "text/plain") != isTextType(declaredType) {
+		return detected, nil
+	}
+	return mime.FormatMediaType(declaredType, params), nil
+}
+
+// isTextType は内容がテキストのメディアタイプかどうかを判定します
+func isTextType(mediaType string) bool {
+	switch mediaType {
+	case "application/json", "application/xml", "application/yaml", "application/x-yaml":
+		return true
+	}
+	return strings.HasPrefix(mediaType, "text/") || strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml")
+}
+
+// allowedType はメディアタイプがアップロードできる型に含まれるかを判定します
+func allowedType(contentType string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, pattern := range allowed {
+		pattern = strings.ToLower(pattern)
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+			if strings.HasPrefix(mediaType, prefix+"/") {
+				return true
+			}
+		} else if mediaType == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanFilename はファイル名から制御文字を取り除き、長すぎる場合は切り詰めます
+func cleanFilename(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, strings.ToValidUTF8(name, ""))
+	name = strings.TrimSpace(name)
+	if utf8.RuneCountInString(name) > maxFilenameLength {
+		name = string([]rune(name)[:maxFilenameLength])
+	}
+	if name == "" {
+		return "file"
+	}
+	return name
+}
+
+// attachmentKey は本体を保存するキー attachments/{タスクID}/{ランダムな値} を作成します
+func attachmentKey(taskID int) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return AttachmentKeyPrefix + strconv.Itoa(taskID) + "/" + hex.EncodeToString(b), nil
+}
+
+// attachmentPath は /tasks/{id}/attachments[/{attachment_id}[/content]] からタスクと添付ファイルのIDを取り出します
+// 添付ファイルのIDを含まないパスでは0を返します。IDが不正な場合はエラーを書き込んで false を返します
+func attachmentPath(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	pathParts := strings.Split(r.URL.Path[len("/tasks/"):], "/")
+	taskID, err := strconv.Atoi(pathParts[0])
+	if err != nil {
+		log.Printf("Invalid task ID: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid task ID"))
+		return 0, 0, false
+	}
+	if len(pathParts) < 3 {
+		return taskID, 0, true
+	}
+	attachmentID, err := strconv.Atoi(pathParts[2])
+	if err != nil {
+		log.Printf("Invalid attachment ID: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid attachment ID"))
+		return 0, 0, false
+	}
+	return taskID, attachmentID, true
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// upload は file という名前のファイルを multipart/form-data で送信します
+func (s *testServer) upload(t *testing.T, path, filename, contentType string, content []byte) testResponse {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, filename))
+	header.Set("Content-Type", contentType)
+	part, err := w.CreatePart(header)
+	if err != nil {
+		t.Fatalf("CreatePart: %v", err)
+	}
+	part.Write(content)
+	w.Close()
+	return s.do(t, http.MethodPost, path, body.String(), "Content-Type", w.FormDataContentType())
+}
+
+func TestAttachmentHandler(t *testing.T) {
+	s := newTestServer(t)
+	taskID := s.createTask(t, "write report", nil)
+	path := fmt.Sprintf("/tasks/%d/attachments", taskID)
+	content := []byte("quarterly numbers\n")
+
+	var a api.Attachment
+	s.upload(t, path, "報告書.txt", "text/plain", content).expect(t, http.StatusCreated).decode(t, &a)
+	sum := sha256.Sum256(content)
+	if a.Filename != "報告書.txt" || a.Size != int64(len(content)) || a.Checksum != hex.EncodeToString(sum[:]) ||
+		!strings.HasPrefix(a.ContentType, "text/plain") {
+		t.Fatalf("attachment = %+v", a)
+	}
+
+	contentPath := fmt.Sprintf("%s/%d/content", path, a.Id)
+	resp := s.do(t, http.MethodGet, contentPath, nil).expect(t, http.StatusOK)
+	if !bytes.Equal(resp.Body, content) || resp.Header.Get("X-Content-Type-Options") != "nosniff" ||
+		!strings.HasPrefix(resp.Header.Get("Content-Disposition"), "inline") {
+		t.Errorf("download = %q with headers %v", resp.Body, resp.Header)
+	}
+	resp = s.do(t, http.MethodGet, contentPath, nil, "Range", "bytes=10-").expect(t, http.StatusPartialContent)
+	if string(resp.Body) != string(content[10:]) {
+		t.Errorf("partial download = %q, want %q", resp.Body, content[10:])
+	}
+	s.do(t, http.MethodGet, contentPath, nil, "If-None-Match", `"`+a.Checksum+`"`).expect(t, http.StatusNotModified)
+
+	var list struct {
+		Attachments []api.Attachment `json:"attachments"`
+	}
+	s.do(t, http.MethodGet, path, nil).expect(t, http.StatusOK).decode(t, &list)
+	if len(list.Attachments) != 1 || list.Attachments[0].Id != a.Id {
+		t.Errorf("attachments = %+v", list.Attachments)
+	}
+
+	s.do(t, http.MethodDelete, fmt.Sprintf("%s/%d", path, a.Id), nil).expect(t, http.StatusNoContent)
+	s.do(t, http.MethodGet, contentPath, nil).expect(t, http.StatusNotFound).problem(t)
+}
+
+func TestAttachmentHandlerRejectsInvalidUploads(t *testing.T) {
+	s := newTestServer(t)
+	path := fmt.Sprintf("/tasks/%d/attachments", s.createTask(t, "task", nil))
+
+	p := s.upload(t, path, "big.bin", "application/octet-stream", make([]byte, 1<<20+1)).
+		expect(t, http.StatusRequestEntityTooLarge).problem(t)
+	if p.Code != "payload_too_large" {
+		t.Errorf("code = %q, want payload_too_large", p.Code)
+	}
+	s.do(t, http.MethodPost, path, `{"file":"x"}`).expect(t, http.StatusBadRequest)
+	s.upload(t, "/tasks/999/attachments", "a.txt", "text/plain", []byte("x")).expect(t, http.StatusNotFound).problem(t)
+}
+
+func TestUploadContentType(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		declared string
+		want     string
+	}{
+		{"detected image", "\x89PNG\r\n\x1a\n0000", "text/plain", "image/png"},
+		{"declared binary type", "\x00\x01\x02", "application/zip", "application/zip"},
+		{"declared text type", "a,b\n1,2\n", "text/csv", "text/csv"},
+		// テキストにバイナリの型を付けさせない
+		{"text declared as binary", "hello", "application/zip", "text/plain; charset=utf-8"},
+		{"html declared as text", "<html><body>x</body></html>", "text/plain", "text/html; charset=utf-8"},
+		{"invalid declared type", "\x00\x01", "zip", "application/octet-stream"},
+	}
+	h := &AttachmentHandler{opts: AttachmentOptions{MaxSize: 1 << 10}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body bytes.Buffer
+			w := multipart.NewWriter(&body)
+			part, _ := w.CreateFormFile("file", "f")
+			part.Write([]byte(tt.content))
+			w.Close()
+			filePart, err := multipart.NewReader(&body, w.Boundary()).NextPart()
+			if err != nil {
+				t.Fatalf("NextPart: %v", err)
+			}
+			u, err := h.spool(filePart)
+			if err != nil {
+				t.Fatalf("spool: %v", err)
+			}
+			defer u.remove()
+			if got, err := u.contentType(tt.declared); err != nil || got != tt.want {
+				t.Errorf("contentType(%q) = %q, %v, want %q", tt.declared, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllowedType(t *testing.T) {
+	allowed := []string{"image/*", "application/PDF"}
+	tests := []struct {
+		contentType string
+		want        bool
+	}{
+		{"image/png", true},
+		{"application/pdf", true},
+		{"text/plain; charset=utf-8", false},
+		{"imagex/png", false},
+		{"not a type", false},
+	}
+	for _, tt := range tests {
+		if got := allowedType(tt.contentType, allowed); got != tt.want {
+			t.Errorf("allowedType(%q) = %v, want %v", tt.contentType, got, tt.want)
+		}
+	}
+	if !allowedType("application/x-anything", nil) {
+		t.Error("allowedType with no restriction = false, want true")
+	}
+}
+
+func TestCleanFilename(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"report.pdf", "report.pdf"},
+		{" a\x00b\nc.txt ", "abc.txt"},
+		{"bad\xffutf8", "badutf8"},
+		{"\x01\x02", "file"},
+		{strings.Repeat("あ", maxFilenameLength+10), strings.Repeat("あ", maxFilenameLength)},
+	}
+	for _, tt := range tests {
+		if got := cleanFilename(tt.name); got != tt.want {
+			t.Errorf("cleanFilename(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/authz"
+	"github.com/yuchi1128/task-management-system/backend/internal/problem"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+type AuditHandler struct {
+	store store.AuditStore
+	authz *authz.Authorizer
+}
+
+func NewAuditHandler(auditStore store.AuditStore, authorizer *authz.Authorizer) *AuditHandler {
+	return &AuditHandler{store: auditStore, authz: authorizer}
+}
+
+// GetAudit はタスクとラベルの変更履歴を新しい順に取得します
+func (h *AuditHandler) GetAudit(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling GetAudit request")
+	query := r.URL.Query()
+	auditQuery, fieldErrors := parseAuditQuery(query)
+	if len(fieldErrors) > 0 {
+		problem.Write(w, r, problem.Validation(fieldErrors))
+		return
+	}
+
+	// 閲覧できるプロジェクトの変更履歴に絞り込む
+	auditQuery.Member = h.authz.Scope(r.Context(), authz.ReadProject)
+
+	result, err := h.store.ListAudit(r.Context(), auditQuery)
+	if err != nil {
+		log.Printf("Error fetching audit log: %v", err)
+		writeStoreError(w, r, err, "Audit log", "Failed to fetch audit log")
+		return
+	}
+	writeAuditPage(w, r, auditQuery, result)
+}
+
+// GetTaskHistory は指定したIDのタスクの変更履歴を新しい順に取得します
+func (h *AuditHandler) GetTaskHistory(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling GetTaskHistory request")
+	pathParts := r.URL.Path[len("/tasks/"):]
+	id, err := strconv.Atoi(pathParts[:len(pathParts)-len("/history")])
+	if err != nil {
+		log.Printf("Invalid task ID: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid task ID"))
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), id, authz.ReadProject); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to fetch task history")
+		return
+	}
+
+	var auditQuery store.AuditQuery
+	auditQuery.Page, auditQuery.Limit = parsePaging(r.URL.Query())
+	result, err := h.store.TaskHistory(r.Context(), id, auditQuery)
+	if err != nil {
+		log.Printf("Error fetching task history: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to fetch task history")
+		return
+	}
+	writeAuditPage(w, r, auditQuery, result)
+}
+
+// 変更履歴のページをレスポンスとして書き込む
+func writeAuditPage(w http.ResponseWriter, r *http.Request, query store.AuditQuery, result store.AuditPage) {
+	log.Printf("Fetched %d of %d audit entries", len(result.Entries), result.Total)
+	response := api.AuditPage{
+		Entries:  result.Entries,
+		Total:    result.Total,
+		Page:     query.Page,
+		PageSize: query.PageSize(),
+	}
+	if response.Entries == nil {
+		response.Entries = []api.AuditEntry{}
+	}
+	writeJSONWithETag(w, r, "", response)
+}
+
+// parseAuditQuery はクエリパラメータから変更履歴の取得条件を作成します
+func parseAuditQuery(query url.Values) (store.AuditQuery, []problem.FieldError) {
+	p := filterParser{query: query}
+	auditQuery := store.AuditQuery{
+		EntityType: query.Get("entity_type"),
+		Actions:    p.list("action"),
+		Actor:      query.Get("actor"),
+		CreatedAt:  p.timeRange("created_at"),
+	}
+	auditQuery.Page, auditQuery.Limit = parsePaging(query)
+	switch api.AuditEntryEntityType(auditQuery.EntityType) {
+	case "", api.AuditEntryEntityTypeTask, api.AuditEntryEntityTypeLabel, api.AuditEntryEntityTypeProject:
+	default:
+		p.fail("entity_type", "must be one of task, label, project")
+	}
+	if raw := query.Get("entity_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil || id < 1 {
+			p.fail("entity_id", "must be a positive integer")
+		}
+		auditQuery.EntityID = id
+	}
+	for _, action := range auditQuery.Actions {
+		switch api.AuditEntryAction(action) {
+		case api.Create, api.Update, api.Delete, api.Restore, api.Purge:
+		default:
+			p.fail("action", "must be a comma-separated list of create, update, delete, restore, purge")
+			return auditQuery, p.errors
+		}
+	}
+	return auditQuery, p.errors
+}
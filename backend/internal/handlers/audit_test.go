@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+func TestParseAuditQueryErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"entity_type=task&entity_id=3&action=create,update", "[]"},
+		{"entity_type=comment", "[entity_type]"},
+		{"entity_id=0", "[entity_id]"},
+		{"action=create,rename", "[action]"},
+		{"created_at_from=yesterday", "[created_at_from]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery: %v", err)
+			}
+			_, fieldErrors := parseAuditQuery(query)
+			fields := []string{}
+			for _, e := range fieldErrors {
+				fields = append(fields, e.Field)
+			}
+			if got := fmt.Sprint(fields); got != tt.want {
+				t.Errorf("fields = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditHandler(t *testing.T) {
+	s := newTestServer(t)
+	id := s.createTask(t, "write report", nil)
+	path := fmt.Sprintf("/tasks/%d", id)
+	s.do(t, http.MethodPatch, path, map[string]interface{}{"status": "Completed"}, "X-Request-ID", "req-history").expect(t, http.StatusOK)
+
+	var history api.AuditPage
+	s.do(t, http.MethodGet, path+"/history", nil).expect(t, http.StatusOK).decode(t, &history)
+	if history.Total != 2 || history.Entries[0].Action != api.Update || history.Entries[1].Action != api.Create {
+		t.Fatalf("history = %+v, want update and create", history)
+	}
+	update := history.Entries[0]
+	if change := update.Changes["status"]; change.Old != "NotStarted" || change.New != "Completed" {
+		t.Errorf("status change = %+v", change)
+	}
+	if update.RequestId == nil || *update.RequestId != "req-history" {
+		t.Errorf("request id = %v, want req-history", update.RequestId)
+	}
+
+	var audit api.AuditPage
+	s.do(t, http.MethodGet, "/audit?entity_type=task&action=update", nil).expect(t, http.StatusOK).decode(t, &audit)
+	if audit.Total != 1 || audit.Entries[0].EntityId != id {
+		t.Errorf("GET /audit = %+v, want the status update", audit)
+	}
+	s.do(t, http.MethodGet, "/audit?entity_type=comment", nil).expect(t, http.StatusBadRequest).problem(t)
+	s.do(t, http.MethodGet, "/tasks/999/history", nil).expect(t, http.StatusNotFound).problem(t)
+}
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/auth"
+	"github.com/yuchi1128/task-management-system/backend/internal/problem"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+type AuthHandler struct {
+	store      store.UserStore
+	issuer     *auth.Issuer
+	bcryptCost int
+}
+
+func NewAuthHandler(userStore store.UserStore, issuer *auth.Issuer, bcryptCost int) *AuthHandler {
+	return &AuthHandler{store: userStore, issuer: issuer, bcryptCost: bcryptCost}
+}
+
+// Register はユーザーを登録します（ログインはしないので、続けて Login を呼ぶ）
+func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling Register request")
+	var input api.Credentials
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	if err := auth.ValidatePassword(input.Password); err != nil {
+		problem.Write(w, r, problem.Validation([]problem.FieldError{{Field: "password", Location: "body", Message: err.Error()}}))
+		return
+	}
+
+	hash, err := auth.HashPassword(input.Password, h.bcryptCost)
+	if err != nil {
+		log.Printf("Error hashing password: %v", err)
+		problem.Write(w, r, problem.New(http.StatusInternalServerError, problem.CodeInternal, "Failed to register user"))
+		return
+	}
+	user, err := h.store.CreateUser(r.Context(), normalizeUsername(input.Username), hash)
+	if err != nil {
+		log.Printf("Error creating user: %v", err)
+		writeStoreError(w, r, err, "Username", "Failed to register user")
+		return
+	}
+
+	log.Printf("User registered successfully with ID: %d", user.ID)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(user.ToAPIUser())
+}
+
+// Login はユーザー名とパスワードを確認し、アクセストークンとリフレッシュトークンを発行します
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling Login request")
+	var input api.Credentials
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	user, err := h.store.GetUserByUsername(r.Context(), normalizeUsername(input.Username))
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		log.Printf("Error fetching user: %v", err)
+		writeStoreError(w, r, err, "User", "Failed to log in")
+		return
+	}
+	// ユーザーが存在しない場合も照合を行い、ユーザー名の有無が応答時間からわからないようにする
+	if !auth.CheckPassword(user.PasswordHash, input.Password, h.bcryptCost) {
+		log.Printf("Login failed for %q", input.Username)
+		writeUnauthorized(w, r, "Invalid username or password")
+		return
+	}
+
+	pair, refresh, err := h.issue(user)
+	if err == nil {
+		err = h.store.CreateRefreshToken(r.Context(), refresh)
+	}
+	if err != nil {
+		log.Printf("Error issuing tokens: %v", err)
+		writeStoreError(w, r, err, "User", "Failed to log in")
+		return
+	}
+
+	log.Printf("User %d logged in", user.ID)
+	writeTokenPair(w, pair)
+}
+
+// Refresh はリフレッシュトークンを新しいアクセストークンとリフレッシュトークンに交換します
+// 使ったリフレッシュトークンは失効し、再び使われた場合はそのユーザーのリフレッシュトークンをすべて失効させます
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling Refresh request")
+	var input api.RefreshRequest
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	claims, err := h.issuer.Verify(input.RefreshToken, auth.RefreshToken)
+	if err != nil {
+		log.Printf("Invalid refresh token: %v", err)
+		writeUnauthorized(w, r, "Invalid or expired refresh token")
+		return
+	}
+
+	pair, next, err := h.issue(store.UserEntity{ID: claims.User().ID, Username: claims.Username})
+	if err != nil {
+		log.Printf("Error issuing tokens: %v", err)
+		problem.Write(w, r, problem.New(http.StatusInternalServerError, problem.CodeInternal, "Failed to refresh tokens"))
+		return
+	}
+	user, err := h.store.RotateRefreshToken(r.Context(), claims.ID, next)
+	if errors.Is(err, store.ErrNotFound) || errors.Is(err, store.ErrTokenRevoked) {
+		log.Printf("Refresh token rejected for user %s: %v", claims.Subject, err)
+		writeUnauthorized(w, r, "Invalid or expired refresh token")
+		return
+	}
+	if err != nil {
+		log.Printf("Error rotating refresh token: %v", err)
+		writeStoreError(w, r, err, "User", "Failed to refresh tokens")
+		return
+	}
+
+	log.Printf("Tokens refreshed for user %d", user.ID)
+	writeTokenPair(w, pair)
+}
+
+// Logout はリフレッシュトークンを失効させます（アクセストークンは有効期限まで使えます）
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling Logout request")
+	var input api.RefreshRequest
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	// 不正なトークンでもログアウトは成功したことにする
+	if claims, err := h.issuer.Verify(input.RefreshToken, auth.RefreshToken); err == nil || errors.Is(err, auth.ErrTokenExpired) {
+		if err := h.store.RevokeRefreshToken(r.Context(), claims.ID); err != nil {
+			log.Printf("Error revoking refresh token: %v", err)
+			writeStoreError(w, r, err, "User", "Failed to log out")
+			return
+		}
+	}
+
+	log.Println("Logged out successfully")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Me はログインしているユーザーを返します
+func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling Me request")
+	current, ok := auth.FromContext(r.Context())
+	if !ok {
+		writeUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	user, err := h.store.GetUser(r.Context(), current.ID)
+	if errors.Is(err, store.ErrNotFound) {
+		writeUnauthorized(w, r, "User no longer exists")
+		return
+	}
+	if err != nil {
+		log.Printf("Error fetching user: %v", err)
+		writeStoreError(w, r, err, "User", "Failed to fetch user")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(user.ToAPIUser())
+}
+
+// アクセストークンとリフレッシュトークンを発行する（リフレッシュトークンの記録も返す）
+func (h *AuthHandler) issue(user store.UserEntity) (api.TokenPair, store.RefreshTokenEntity, error) {
+	subject := auth.User{ID: user.ID, Username: user.Username}
+	access, _, err := h.issuer.Sign(subject, auth.AccessToken)
+	if err != nil {
+		return api.TokenPair{}, store.RefreshTokenEntity{}, err
+	}
+	refresh, claims, err := h.issuer.Sign(subject, auth.RefreshToken)
+	if err != nil {
+		return api.TokenPair{}, store.RefreshTokenEntity{}, err
+	}
+
+	pair := api.TokenPair{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		TokenType:    api.Bearer,
+		ExpiresIn:    int(h.issuer.AccessTTL().Seconds()),
+	}
+	record := store.RefreshTokenEntity{ID: claims.ID, UserID: user.ID, ExpiresAt: claims.Expires()}
+	return pair, record, nil
+}
+
+// ユーザー名は大文字と小文字を区別しない
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+// リクエストボディを読み込む（失敗した場合はエラーを書き込んで false を返す）
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		log.Printf("Error decoding request body: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid request body"))
+		return false
+	}
+	return true
+}
+
+func writeUnauthorized(w http.ResponseWriter, r *http.Request, detail string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+	problem.Write(w, r, problem.New(http.StatusUnauthorized, problem.CodeUnauthorized, detail))
+}
+
+func writeTokenPair(w http.ResponseWriter, pair api.TokenPair) {
+	// トークンはキャッシュさせない
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(pair)
+}
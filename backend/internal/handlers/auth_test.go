@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// register はユーザーを登録してログインし、発行されたトークンとユーザーを返します
+func (s *testServer) register(t *testing.T, username string) (api.TokenPair, api.User) {
+	t.Helper()
+	credentials := map[string]string{"username": username, "password": "password123"}
+	var user api.User
+	s.do(t, http.MethodPost, "/auth/register", credentials).expect(t, http.StatusCreated).decode(t, &user)
+	var pair api.TokenPair
+	s.do(t, http.MethodPost, "/auth/login", credentials).expect(t, http.StatusOK).decode(t, &pair)
+	return pair, user
+}
+
+// as は token で認証してリクエストを送るテスト用サーバーを返します
+func (s *testServer) as(token string) *testServer {
+	copied := *s
+	copied.token = token
+	return &copied
+}
+
+func TestAuthHandler(t *testing.T) {
+	s := newAuthTestServer(t)
+	s.do(t, http.MethodGet, "/tasks", nil).expect(t, http.StatusUnauthorized).problem(t)
+
+	pair, user := s.register(t, "Alice")
+	// ユーザー名は小文字にそろえる
+	if user.Username != "alice" || user.Id == 0 {
+		t.Fatalf("registered user = %+v", user)
+	}
+	if pair.TokenType != api.Bearer || pair.ExpiresIn != 15*60 {
+		t.Errorf("token pair = %+v", pair)
+	}
+
+	var me api.User
+	s.as(pair.AccessToken).do(t, http.MethodGet, "/me", nil).expect(t, http.StatusOK).decode(t, &me)
+	if me.Id != user.Id || me.Username != "alice" {
+		t.Errorf("GET /me = %+v, want %+v", me, user)
+	}
+	// 作成者はトークンのユーザーになる
+	s.join(t, user.Id, api.ProjectRoleEditor)
+	id := s.as(pair.AccessToken).createTask(t, "task", nil)
+	if task := s.as(pair.AccessToken).getTask(t, id); task.CreatedBy == nil || *task.CreatedBy != "alice" {
+		t.Errorf("created_by = %v, want alice", task.CreatedBy)
+	}
+
+	// リフレッシュトークンは1回だけ使える
+	var refreshed api.TokenPair
+	s.do(t, http.MethodPost, "/auth/refresh", map[string]string{"refresh_token": pair.RefreshToken}).
+		expect(t, http.StatusOK).decode(t, &refreshed)
+	if refreshed.RefreshToken == pair.RefreshToken {
+		t.Fatal("refresh returned the same refresh token")
+	}
+	s.do(t, http.MethodPost, "/auth/refresh", map[string]string{"refresh_token": pair.RefreshToken}).
+		expect(t, http.StatusUnauthorized).problem(t)
+	// 使い回しを検知すると、交換済みのトークンも失効する
+	s.do(t, http.MethodPost, "/auth/refresh", map[string]string{"refresh_token": refreshed.RefreshToken}).
+		expect(t, http.StatusUnauthorized).problem(t)
+
+	var again api.TokenPair
+	s.do(t, http.MethodPost, "/auth/login", map[string]string{"username": "alice", "password": "password123"}).
+		expect(t, http.StatusOK).decode(t, &again)
+	s.do(t, http.MethodPost, "/auth/logout", map[string]string{"refresh_token": again.RefreshToken}).expect(t, http.StatusNoContent)
+	s.do(t, http.MethodPost, "/auth/refresh", map[string]string{"refresh_token": again.RefreshToken}).
+		expect(t, http.StatusUnauthorized).problem(t)
+	// ログアウト後もアクセストークンは有効期限まで使える
+	s.as(again.AccessToken).do(t, http.MethodGet, "/me", nil).expect(t, http.StatusOK)
+}
+
+func TestAuthHandlerRejectsInvalidCredentials(t *testing.T) {
+	s := newAuthTestServer(t)
+	s.register(t, "alice")
+
+	tests := []struct {
+		name   string
+		path   string
+		body   interface{}
+		status int
+		code   string
+	}{
+		{"duplicate username", "/auth/register", map[string]string{"username": "ALICE", "password": "password123"}, http.StatusConflict, "conflict"},
+		{"short password", "/auth/register", map[string]string{"username": "bob", "password": "short"}, http.StatusBadRequest, "validation_failed"},
+		{"wrong password", "/auth/login", map[string]string{"username": "alice", "password": "password124"}, http.StatusUnauthorized, "unauthorized"},
+		{"unknown user", "/auth/login", map[string]string{"username": "carol", "password": "password123"}, http.StatusUnauthorized, "unauthorized"},
+		{"malformed refresh token", "/auth/refresh", map[string]string{"refresh_token": "not.a.token"}, http.StatusUnauthorized, "unauthorized"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := s.do(t, http.MethodPost, tt.path, tt.body).expect(t, tt.status).problem(t)
+			if p.Code != tt.code {
+				t.Errorf("code = %q, want %q", p.Code, tt.code)
+			}
+		})
+	}
+
+	// 不正なアクセストークンは認証を必須にしていなくても拒否する
+	open := newTestServer(t)
+	open.do(t, http.MethodGet, "/tasks", nil).expect(t, http.StatusOK)
+	resp := open.as("invalid").do(t, http.MethodGet, "/tasks", nil).expect(t, http.StatusUnauthorized)
+	if got := resp.Header.Get("WWW-Authenticate"); !strings.Contains(got, `error="invalid_token"`) {
+		t.Errorf("WWW-Authenticate = %q, want invalid_token", got)
+	}
+	open.do(t, http.MethodGet, "/me", nil).expect(t, http.StatusUnauthorized).problem(t)
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/authz"
+	"github.com/yuchi1128/task-management-system/backend/internal/problem"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+// 一括操作の結果
+const (
+	bulkSucceeded = "succeeded"
+	bulkFailed    = "failed"
+)
+
+// bulkItemResponse は一括操作のタスク1件分の結果です
+type bulkItemResponse struct {
+	ID     int              `json:"id"`
+	Status string           `json:"status"`
+	Task   *api.Task        `json:"task,omitempty"`
+	Error  *problem.Problem `json:"error,omitempty"`
+}
+
+// BulkTasks は指定したIDまたは絞り込み条件に一致するタスクを1つのトランザクションで一括操作します
+// 失敗したタスクの変更だけを取り消し、タスクごとの結果を返します
+func (h *TaskHandler) BulkTasks(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling BulkTasks request")
+	var input api.BulkTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		log.Printf("Error decoding request body: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid request body"))
+		return
+	}
+	op, fieldErrors := bulkOperation(r.Context(), input)
+	if len(fieldErrors) > 0 {
+		problem.Write(w, r, problem.Validation(fieldErrors))
+		return
+	}
+
+	// ID を指定した場合は1件でも権限がなければ全体を拒否し、絞り込み条件の場合は tasks:write を持つプロジェクトのタスクに限る
+	// 存在しないタスクはストアがタスクごとの失敗として返す
+	for _, id := range op.IDs {
+		if err := h.authz.Task(r.Context(), id, authz.WriteTasks); err != nil && !errors.Is(err, store.ErrNotFound) {
+			log.Printf("Error authorizing task %d: %v", id, err)
+			writeStoreError(w, r, err, "Task", "Failed to run bulk operation")
+			return
+		}
+	}
+	if op.Filter != nil {
+		op.Filter.Member = h.authz.Scope(r.Context(), authz.WriteTasks)
+	}
+
+	results, err := h.store.BulkTasks(r.Context(), op)
+	if err != nil {
+		log.Printf("Error running bulk operation: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to run bulk operation")
+		return
+	}
+
+	response := struct {
+		Action    string             `json:"action"`
+		DryRun    bool               `json:"dry_run"`
+		Succeeded int                `json:"succeeded"`
+		Failed    int                `json:"failed"`
+		Results   []bulkItemResponse `json:"results"`
+	}{
+		Action:  op.Action,
+		DryRun:  op.DryRun,
+		Results: make([]bulkItemResponse, 0, len(results)),
+	}
+	for _, result := range results {
+		item := bulkItemResponse{ID: result.ID, Status: bulkSucceeded, Task: result.Task}
+		if result.Err != nil {
+			item.Status = bulkFailed
+			item.Error = classifyStoreError(result.Err, "Task", "Failed to update task")
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+		response.Results = append(response.Results, item)
+	}
+
+	log.Printf("Bulk %s: %d succeeded, %d failed (dry run: %t)", op.Action, response.Succeeded, response.Failed, op.DryRun)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// 一括操作のリクエストを検証し、ストアの一括操作に変換
+// 値の形式は OpenAPI の検証で確認済みなので、項目の組み合わせを確認します
+func bulkOperation(ctx context.Context, input api.BulkTaskRequest) (store.BulkOperation, []problem.FieldError) {
+	var fieldErrors []problem.FieldError
+	fail := func(field, message string) {
+		fieldErrors = append(fieldErrors, problem.FieldError{Field: field, Location: "body", Message: message})
+	}
+
+	op := store.BulkOperation{Action: string(input.Action)}
+	if input.DryRun != nil {
+		op.DryRun = *input.DryRun
+	}
+
+	// 対象は ids と filter のどちらか一方で指定する
+	switch {
+	case input.Ids != nil && input.Filter != nil:
+		fail("ids", "specify either ids or filter, not both")
+	case input.Ids != nil:
+		op.IDs = *input.Ids
+	case input.Filter != nil:
+		query := url.Values{}
+		for name, value := range *input.Filter {
+			if !slices.Contains(taskFilterParams, name) {
+				fail("filter."+name, "is not a task filter parameter")
+				continue
+			}
+			query.Set(name, value)
+		}
+		filter, filterErrors := parseTaskFilter(ctx, query)
+		for _, e := range filterErrors {
+			fail("filter."+e.Field, e.Message)
+		}
+		op.Filter = &filter
+	default:
+		fail("ids", "specify either ids or filter")
+	}
+
+	// 操作に必要な値
+	switch input.Action {
+	case api.BulkTaskRequestActionUpdateStatus:
+		if input.Status == nil {
+			fail("status", "is required for update_status")
+		} else {
+			op.Status = string(*input.Status)
+		}
+	case api.BulkTaskRequestActionUpdatePriority:
+		if input.Priority == nil {
+			fail("priority", "is required for update_priority")
+		} else {
+			op.Priority = string(*input.Priority)
+		}
+	case api.BulkTaskRequestActionAddLabels, api.BulkTaskRequestActionRemoveLabels:
+		if input.LabelIds == nil {
+			fail("label_ids", "is required for "+op.Action)
+		} else {
+			op.LabelIDs = *input.LabelIds
+		}
+	case api.BulkTaskRequestActionShiftDates:
+		if input.ShiftDays == nil || *input.ShiftDays == 0 {
+			fail("shift_days", "is required for shift_dates and must not be 0")
+		} else {
+			op.ShiftDays = *input.ShiftDays
+		}
+	}
+	return op, fieldErrors
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// bulkResponse は一括操作のレスポンスです
+type bulkResponse struct {
+	DryRun    bool               `json:"dry_run"`
+	Succeeded int                `json:"succeeded"`
+	Failed    int                `json:"failed"`
+	Results   []bulkItemResponse `json:"results"`
+}
+
+func TestTaskHandlerBulk(t *testing.T) {
+	s := newTestServer(t)
+	first := s.createTask(t, "first", map[string]interface{}{"end_date": "2024-01-10T00:00:00Z"})
+	second := s.createTask(t, "second", map[string]interface{}{"priority": "High"})
+
+	// 試行では結果を返すだけで変更しない
+	var result bulkResponse
+	s.do(t, http.MethodPost, "/tasks/bulk", map[string]interface{}{
+		"action": "update_status", "ids": []int{first, second}, "status": "Completed", "dry_run": true,
+	}).expect(t, http.StatusOK).decode(t, &result)
+	if !result.DryRun || result.Succeeded != 2 || *result.Results[0].Task.Status != api.TaskStatusCompleted {
+		t.Fatalf("dry run = %+v, want 2 completed tasks", result)
+	}
+	if task := s.getTask(t, first); *task.Status != api.TaskStatusNotStarted {
+		t.Fatalf("status after dry run = %s, want unchanged", *task.Status)
+	}
+
+	// 存在しないタスクだけが失敗し、他のタスクの変更は確定する
+	s.do(t, http.MethodPost, "/tasks/bulk", map[string]interface{}{
+		"action": "update_status", "ids": []int{first, 999, second}, "status": "Completed",
+	}).expect(t, http.StatusOK).decode(t, &result)
+	if result.Succeeded != 2 || result.Failed != 1 {
+		t.Fatalf("bulk update = %+v, want 2 succeeded and 1 failed", result)
+	}
+	for _, item := range result.Results {
+		if item.ID == 999 && (item.Status != bulkFailed || item.Error == nil || item.Error.Code != "not_found") {
+			t.Errorf("result for a missing task = %+v, want not_found", item)
+		}
+	}
+	if task := s.getTask(t, second); *task.Status != api.TaskStatusCompleted {
+		t.Errorf("status = %s, want Completed", *task.Status)
+	}
+
+	// 絞り込み条件に一致するタスクを対象にする
+	label := labelByName(t, s, "重要")
+	s.do(t, http.MethodPost, "/tasks/bulk", map[string]interface{}{
+		"action": "add_labels", "filter": map[string]string{"priority": "High"}, "label_ids": []int{label.ID},
+	}).expect(t, http.StatusOK).decode(t, &result)
+	if result.Succeeded != 1 || result.Results[0].ID != second {
+		t.Fatalf("bulk add_labels = %+v, want only the high priority task", result)
+	}
+	if task := s.getTask(t, second); len(task.Labels) != 1 || task.Labels[0].ID != label.ID {
+		t.Errorf("labels = %+v, want %s", task.Labels, label.Name)
+	}
+
+	// 日付を移動し、未設定の日付はそのままにする
+	s.do(t, http.MethodPost, "/tasks/bulk", map[string]interface{}{
+		"action": "shift_dates", "ids": []int{first, second}, "shift_days": 7,
+	}).expect(t, http.StatusOK)
+	if task := s.getTask(t, first); !task.EndDate.Equal(time.Date(2024, 1, 17, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("end_date = %v, want 2024-01-17", task.EndDate)
+	}
+	if task := s.getTask(t, second); task.EndDate != nil {
+		t.Errorf("end_date = %v, want unset", task.EndDate)
+	}
+
+	s.do(t, http.MethodPost, "/tasks/bulk", map[string]interface{}{"action": "delete", "ids": []int{first}}).expect(t, http.StatusOK)
+	var trash struct {
+		Total int `json:"total"`
+	}
+	s.do(t, http.MethodGet, "/trash", nil).expect(t, http.StatusOK).decode(t, &trash)
+	if trash.Total != 1 {
+		t.Errorf("trash total = %d after bulk delete, want 1", trash.Total)
+	}
+}
+
+func TestTaskHandlerBulkRejectsInvalidInput(t *testing.T) {
+	s := newTestServer(t)
+	id := s.createTask(t, "task", nil)
+	tests := []struct {
+		name  string
+		body  map[string]interface{}
+		field string
+	}{
+		{"no target", map[string]interface{}{"action": "delete"}, "ids"},
+		{"ids and filter", map[string]interface{}{"action": "delete", "ids": []int{id}, "filter": map[string]string{"status": "Completed"}}, "ids"},
+		{"missing status", map[string]interface{}{"action": "update_status", "ids": []int{id}}, "status"},
+		{"zero shift", map[string]interface{}{"action": "shift_dates", "ids": []int{id}, "shift_days": 0}, "shift_days"},
+		{"unknown filter", map[string]interface{}{"action": "delete", "filter": map[string]string{"sort": "name"}}, "filter.sort"},
+		{"invalid filter value", map[string]interface{}{"action": "delete", "filter": map[string]string{"overdue": "soon"}}, "filter.overdue"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := s.do(t, http.MethodPost, "/tasks/bulk", tt.body).expect(t, http.StatusBadRequest).problem(t)
+			if len(p.Errors) == 0 || p.Errors[0].Field != tt.field {
+				t.Errorf("errors = %+v, want %s", p.Errors, tt.field)
+			}
+		})
+	}
+	if task := s.getTask(t, id); task.DeletedAt != nil {
+		t.Errorf("task was deleted by a rejected request")
+	}
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/authz"
+	"github.com/yuchi1128/task-management-system/backend/internal/problem"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+type CommentHandler struct {
+	store store.CommentStore
+	authz *authz.Authorizer
+}
+
+func NewCommentHandler(commentStore store.CommentStore, authorizer *authz.Authorizer) *CommentHandler {
+	return &CommentHandler{store: commentStore, authz: authorizer}
+}
+
+// GetComments はタスクのコメントを古い順に取得します
+func (h *CommentHandler) GetComments(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling GetComments request")
+	taskID, _, ok := commentPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), taskID, authz.ReadProject); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to fetch comments")
+		return
+	}
+
+	var query store.CommentQuery
+	query.Page, query.Limit = parsePaging(r.URL.Query())
+	result, err := h.store.ListComments(r.Context(), taskID, query)
+	if err != nil {
+		log.Printf("Error fetching comments: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to fetch comments")
+		return
+	}
+
+	log.Printf("Fetched %d of %d comments", len(result.Comments), result.Total)
+	response := api.CommentPage{
+		Comments: result.Comments,
+		Total:    result.Total,
+		Page:     query.Page,
+		PageSize: query.PageSize(),
+	}
+	if response.Comments == nil {
+		response.Comments = []api.Comment{}
+	}
+	writeJSONWithETag(w, r, "", response)
+}
+
+// CreateComment はタスクにコメントを追加します
+func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling CreateComment request")
+	taskID, _, ok := commentPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), taskID, authz.WriteTasks); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to create comment")
+		return
+	}
+	input, ok := decodeCommentInput(w, r)
+	if !ok {
+		return
+	}
+
+	comment, err := h.store.CreateComment(r.Context(), taskID, input.Body)
+	if err != nil {
+		log.Printf("Error creating comment: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to create comment")
+		return
+	}
+
+	log.Printf("Comment created successfully with ID: %d", comment.Id)
+	w.Header().Set("ETag", versionETag(comment.Version))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(comment)
+}
+
+// UpdateComment はコメントの本文を編集します（変更前の本文は編集履歴に残ります）
+func (h *CommentHandler) UpdateComment(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling UpdateComment request")
+	taskID, commentID, ok := commentPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), taskID, authz.WriteTasks); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to update comment")
+		return
+	}
+	input, ok := decodeCommentInput(w, r)
+	if !ok {
+		return
+	}
+
+	var comment api.Comment
+	err := ifMatch(r, func(version int) (err error) {
+		comment, err = h.store.UpdateComment(r.Context(), taskID, commentID, input.Body, version)
+		return err
+	})
+	if err != nil {
+		log.Printf("Error updating comment: %v", err)
+		writeStoreError(w, r, err, "Comment", "Failed to update comment")
+		return
+	}
+
+	log.Println("Comment updated successfully")
+	writeVersioned(w, comment.Version, comment)
+}
+
+// DeleteComment はコメントを編集履歴ごと削除します
+func (h *CommentHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling DeleteComment request")
+	taskID, commentID, ok := commentPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), taskID, authz.WriteTasks); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to delete comment")
+		return
+	}
+
+	err := ifMatch(r, func(version int) error {
+		return h.store.DeleteComment(r.Context(), taskID, commentID, version)
+	})
+	if err != nil {
+		log.Printf("Error deleting comment: %v", err)
+		writeStoreError(w, r, err, "Comment", "Failed to delete comment")
+		return
+	}
+
+	log.Println("Comment deleted successfully")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetCommentHistory はコメントの編集前の本文を新しい順に取得します
+func (h *CommentHandler) GetCommentHistory(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling GetCommentHistory request")
+	taskID, commentID, ok := commentPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), taskID, authz.ReadProject); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to fetch comment history")
+		return
+	}
+
+	revisions, err := h.store.CommentHistory(r.Context(), taskID, commentID)
+	if err != nil {
+		log.Printf("Error fetching comment history: %v", err)
+		writeStoreError(w, r, err, "Comment", "Failed to fetch comment history")
+		return
+	}
+
+	log.Printf("Fetched %d comment revisions", len(revisions))
+	writeJSONWithETag(w, r, "", api.CommentHistory{Revisions: revisions})
+}
+
+// commentPath は /tasks/{id}/comments[/{comment_id}[/history]] からタスクとコメントのIDを取り出します
+// コメントIDを含まないパスでは0を返します。IDが不正な場合はエラーを書き込んで false を返します
+func commentPath(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	pathParts := strings.Split(r.URL.Path[len("/tasks/"):], "/")
+	taskID, err := strconv.Atoi(pathParts[0])
+	if err != nil {
+		log.Printf("Invalid task ID: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid task ID"))
+		return 0, 0, false
+	}
+	if len(pathParts) < 3 {
+		return taskID, 0, true
+	}
+	commentID, err := strconv.Atoi(pathParts[2])
+	if err != nil {
+		log.Printf("Invalid comment ID: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid comment ID"))
+		return 0, 0, false
+	}
+	return taskID, commentID, true
+}
+
+// コメントの本文をリクエストから読み込む（失敗した場合はエラーを書き込んで false を返す）
+func decodeCommentInput(w http.ResponseWriter, r *http.Request) (api.CommentInput, bool) {
+	var input api.CommentInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		log.Printf("Error decoding request body: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid request body"))
+		return api.CommentInput{}, false
+	}
+	if strings.TrimSpace(input.Body) == "" {
+		problem.Write(w, r, problem.Validation([]problem.FieldError{{Field: "body", Location: "body", Message: "body is required"}}))
+		return api.CommentInput{}, false
+	}
+	return input, true
+}
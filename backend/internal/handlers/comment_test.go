@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+func TestCommentHandler(t *testing.T) {
+	s := newTestServer(t)
+	taskID := s.createTask(t, "write report", nil)
+	path := fmt.Sprintf("/tasks/%d/comments", taskID)
+
+	// 生の HTML と安全でないリンクは保存する前に取り除く
+	var comment api.Comment
+	resp := s.do(t, http.MethodPost, path, map[string]string{"body": "see <b>[spec](javascript:alert(1))</b>"}).expect(t, http.StatusCreated)
+	resp.decode(t, &comment)
+	if comment.Body != "see &lt;b>[spec](#)&lt;/b>" || comment.TaskId != taskID || comment.EditedAt != nil {
+		t.Fatalf("created comment = %+v", comment)
+	}
+	etag := resp.Header.Get("ETag")
+	if task := s.getTask(t, taskID); task.CommentCount == nil || *task.CommentCount != 1 {
+		t.Errorf("comment_count = %v, want 1", task.CommentCount)
+	}
+
+	commentPath := fmt.Sprintf("%s/%d", path, comment.Id)
+	var edited api.Comment
+	s.do(t, http.MethodPut, commentPath, map[string]string{"body": "see the spec"}, "If-Match", etag).expect(t, http.StatusOK).decode(t, &edited)
+	if edited.Body != "see the spec" || edited.EditedAt == nil || edited.Version <= comment.Version {
+		t.Fatalf("edited comment = %+v", edited)
+	}
+	s.do(t, http.MethodPut, commentPath, map[string]string{"body": "stale"}, "If-Match", etag).expect(t, http.StatusPreconditionFailed).problem(t)
+
+	var history api.CommentHistory
+	s.do(t, http.MethodGet, commentPath+"/history", nil).expect(t, http.StatusOK).decode(t, &history)
+	if len(history.Revisions) != 1 || history.Revisions[0].Body != comment.Body {
+		t.Errorf("history = %+v, want the original body", history)
+	}
+
+	var page api.CommentPage
+	s.do(t, http.MethodGet, path, nil).expect(t, http.StatusOK).decode(t, &page)
+	if page.Total != 1 || page.Comments[0].Body != "see the spec" {
+		t.Errorf("comments = %+v", page)
+	}
+
+	s.do(t, http.MethodDelete, commentPath, nil).expect(t, http.StatusNoContent)
+	s.do(t, http.MethodGet, commentPath+"/history", nil).expect(t, http.StatusNotFound).problem(t)
+	if task := s.getTask(t, taskID); task.CommentCount == nil || *task.CommentCount != 0 {
+		t.Errorf("comment_count after delete = %v, want 0", task.CommentCount)
+	}
+}
+
+func TestCommentHandlerRejectsInvalidInput(t *testing.T) {
+	s := newTestServer(t)
+	taskID := s.createTask(t, "task", nil)
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{"blank body", http.MethodPost, fmt.Sprintf("/tasks/%d/comments", taskID), "  \n ", http.StatusBadRequest},
+		{"missing task", http.MethodPost, "/tasks/999/comments", "hi", http.StatusNotFound},
+		{"missing comment", http.MethodPut, fmt.Sprintf("/tasks/%d/comments/999", taskID), "hi", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.do(t, tt.method, tt.path, map[string]string{"body": tt.body}).expect(t, tt.status).problem(t)
+		})
+	}
+}
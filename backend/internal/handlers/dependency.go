@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/authz"
+	"github.com/yuchi1128/task-management-system/backend/internal/problem"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+type DependencyHandler struct {
+	store store.DependencyStore
+	authz *authz.Authorizer
+}
+
+func NewDependencyHandler(dependencyStore store.DependencyStore, authorizer *authz.Authorizer) *DependencyHandler {
+	return &DependencyHandler{store: dependencyStore, authz: authorizer}
+}
+
+// AddDependency はタスクが別のタスクの完了を待つよう依存関係を追加します
+func (h *DependencyHandler) AddDependency(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling AddDependency request")
+	pathParts := r.URL.Path[len("/tasks/"):]
+	taskID, err := strconv.Atoi(pathParts[:len(pathParts)-len("/dependencies")])
+	if err != nil {
+		log.Printf("Invalid task ID: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid task ID"))
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), taskID, authz.WriteTasks); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to add task dependency")
+		return
+	}
+
+	var input struct {
+		DependsOnID int `json:"depends_on_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		log.Printf("Error decoding request body: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid request body"))
+		return
+	}
+	// 依存先は閲覧できるタスクに限る（存在しない場合はストアの検証に任せる）
+	if err := h.authz.Task(r.Context(), input.DependsOnID, authz.ReadProject); err != nil && !errors.Is(err, store.ErrNotFound) {
+		log.Printf("Error authorizing dependency: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to add task dependency")
+		return
+	}
+
+	// If-Match はタスクのETagと比較する
+	var task api.Task
+	err = ifMatch(r, func(version int) (err error) {
+		task, err = h.store.AddTaskDependency(r.Context(), taskID, input.DependsOnID, version)
+		return err
+	})
+	if err != nil {
+		log.Printf("Error adding task dependency: %v", err)
+		writeStoreError(w, r, err, "Task dependency", "Failed to add task dependency")
+		return
+	}
+
+	log.Printf("Task %d now depends on task %d", taskID, input.DependsOnID)
+	w.Header().Set("ETag", versionETag(*task.Version))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(task)
+}
+
+// RemoveDependency は依存関係を削除します
+func (h *DependencyHandler) RemoveDependency(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling RemoveDependency request")
+	// /tasks/{id}/dependencies/{depends_on_id}
+	pathParts := strings.Split(r.URL.Path[len("/tasks/"):], "/")
+	taskID, err := strconv.Atoi(pathParts[0])
+	if err != nil {
+		log.Printf("Invalid task ID: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid task ID"))
+		return
+	}
+	dependsOnID, err := strconv.Atoi(pathParts[len(pathParts)-1])
+	if err != nil {
+		log.Printf("Invalid dependency ID: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid dependency ID"))
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), taskID, authz.WriteTasks); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to remove task dependency")
+		return
+	}
+
+	err = ifMatch(r, func(version int) error {
+		return h.store.RemoveTaskDependency(r.Context(), taskID, dependsOnID, version)
+	})
+	if err != nil {
+		log.Printf("Error removing task dependency: %v", err)
+		writeStoreError(w, r, err, "Task dependency", "Failed to remove task dependency")
+		return
+	}
+
+	log.Println("Task dependency removed successfully")
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+func TestDependencyHandler(t *testing.T) {
+	s := newTestServerWithOptions(t, store.Options{EnforceDependencies: true})
+	design := s.createTask(t, "design", nil)
+	build := s.createTask(t, "build", nil)
+	path := fmt.Sprintf("/tasks/%d/dependencies", build)
+
+	var task api.Task
+	s.do(t, http.MethodPost, path, map[string]int{"depends_on_id": design}).expect(t, http.StatusCreated).decode(t, &task)
+	if !*task.Blocked || len(task.BlockedBy) != 1 || task.BlockedBy[0] != design {
+		t.Fatalf("task = blocked %v, blocked by %v, want blocked by design", *task.Blocked, task.BlockedBy)
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		id     int
+		status int
+	}{
+		{"cycle", fmt.Sprintf("/tasks/%d/dependencies", design), build, http.StatusUnprocessableEntity},
+		{"duplicate", path, design, http.StatusConflict},
+		{"missing task", path, 999, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := s.do(t, http.MethodPost, tt.path, map[string]int{"depends_on_id": tt.id}).expect(t, tt.status).problem(t)
+			if len(p.Errors) == 0 || p.Errors[0].Field != "depends_on_id" {
+				t.Errorf("errors = %+v, want depends_on_id", p.Errors)
+			}
+		})
+	}
+
+	// 完了していない依存先があるタスクは開始できない
+	p := s.do(t, http.MethodPatch, fmt.Sprintf("/tasks/%d", build), map[string]string{"status": "InProgress"}).
+		expect(t, http.StatusConflict).problem(t)
+	if len(p.Errors) == 0 || p.Errors[0].Field != "status" {
+		t.Errorf("errors = %+v, want status", p.Errors)
+	}
+
+	s.do(t, http.MethodDelete, fmt.Sprintf("%s/%d", path, design), nil).expect(t, http.StatusNoContent)
+	if task := s.getTask(t, build); *task.Blocked || len(task.DependsOn) != 0 {
+		t.Errorf("task after removing the dependency = blocked %v, depends on %v", *task.Blocked, task.DependsOn)
+	}
+	s.do(t, http.MethodDelete, fmt.Sprintf("%s/%d", path, design), nil).expect(t, http.StatusNotFound).problem(t)
+}
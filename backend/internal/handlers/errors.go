@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/yuchi1128/task-management-system/backend/internal/authz"
+	"github.com/yuchi1128/task-management-system/backend/internal/problem"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+// writeStoreError はストアのエラーを分類し、対応するProblemレスポンスを書き込みます
+// resource は404の説明に使うリソース名、failure は分類できないエラーの説明です
+func writeStoreError(w http.ResponseWriter, r *http.Request, err error, resource, failure string) {
+	problem.Write(w, r, classifyStoreError(err, resource, failure))
+}
+
+func classifyStoreError(err error, resource, failure string) *problem.Problem {
+	var constraintErr *store.ConstraintError
+	errors.As(err, &constraintErr)
+
+	var denied *authz.DeniedError
+	errors.As(err, &denied)
+
+	var p *problem.Problem
+	switch {
+	case errors.Is(err, context.Canceled):
+		p = problem.New(problem.StatusClientClosedRequest, problem.CodeRequestCanceled, "Request was canceled")
+	case errors.Is(err, context.DeadlineExceeded):
+		p = problem.New(http.StatusGatewayTimeout, problem.CodeTimeout, "Request timed out")
+	case errors.Is(err, store.ErrInvalidCursor):
+		p = problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid cursor")
+		p.Errors = []problem.FieldError{{Field: "cursor", Location: "query", Message: "cursor is malformed or was issued for a different query"}}
+	case errors.Is(err, store.ErrInvalidSort):
+		p = problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid sort")
+		p.Errors = []problem.FieldError{{Field: "sort", Location: "query", Message: strings.TrimPrefix(err.Error(), store.ErrInvalidSort.Error()+": ")}}
+	case errors.Is(err, store.ErrNotFound):
+		p = problem.New(http.StatusNotFound, problem.CodeNotFound, resource+" not found")
+	case errors.Is(err, store.ErrVersionMismatch):
+		p = problem.New(http.StatusPreconditionFailed, problem.CodePreconditionFailed, resource+" has been modified; fetch it again to get the current ETag")
+	case errors.Is(err, store.ErrHasSubtasks):
+		p = problem.New(http.StatusConflict, problem.CodeConflict, resource+" has subtasks; move or delete them first")
+	case errors.Is(err, store.ErrTaskBlocked):
+		p = problem.New(http.StatusConflict, problem.CodeConflict, "Task is blocked by incomplete tasks; complete them first")
+		p.Errors = []problem.FieldError{{Field: "status", Location: "body", Message: strings.TrimPrefix(err.Error(), store.ErrTaskBlocked.Error()+": ")}}
+	case denied != nil:
+		// 不足している権限と、それを持つ役割を返す
+		p = problem.New(http.StatusForbidden, problem.CodeForbidden, capitalize(denied.Error()))
+		p.Permission = permissionDenial(denied)
+	case errors.Is(err, store.ErrForbidden):
+		p = problem.New(http.StatusForbidden, problem.CodeForbidden, "Not allowed to modify this "+strings.ToLower(resource))
+	case errors.Is(err, store.ErrLastAdmin):
+		p = problem.New(http.StatusConflict, problem.CodeConflict, "Project must keep at least one admin")
+	case errors.Is(err, store.ErrProjectArchived):
+		p = problem.New(http.StatusConflict, problem.CodeConflict, "Project is archived; unarchive it first")
+	case errors.Is(err, store.ErrConflict):
+		p = problem.New(http.StatusConflict, problem.CodeConflict, resource+" conflicts with an existing record")
+	case errors.Is(err, store.ErrInvalidReference):
+		p = problem.New(http.StatusUnprocessableEntity, problem.CodeInvalidReference, "Referenced record does not exist")
+	case errors.Is(err, store.ErrConstraint):
+		p = problem.New(http.StatusUnprocessableEntity, problem.CodeConstraintViolation, "Value violates a constraint")
+	default:
+		log.Printf("Unexpected store error: %v", err)
+		return problem.New(http.StatusInternalServerError, problem.CodeInternal, failure)
+	}
+
+	if constraintErr != nil && constraintErr.Field != "" {
+		message := p.Detail
+		// 繰り返しの規則やコメントの本文の誤り、プロジェクトをまたぐ参照はストアが検証したものなので、理由をそのまま返す
+		switch constraintErr.Constraint {
+		case "tasks_recurrence_rule", "tasks_recurrence_ended", "task_comments_body", "task_labels_project", "tasks_parent_project":
+			message = constraintErr.Err.Error()
+		}
+		p.Errors = []problem.FieldError{{
+			Field:    constraintErr.Field,
+			Location: "body",
+			Message:  message,
+		}}
+	}
+	return p
+}
+
+// 権限の不足を Problem の permission に変換
+func permissionDenial(denied *authz.DeniedError) *problem.Permission {
+	permission := &problem.Permission{Required: string(denied.Permission), ProjectID: denied.ProjectID, Role: string(denied.Role)}
+	for _, role := range authz.RolesWith(denied.Permission) {
+		permission.GrantedTo = append(permission.GrantedTo, string(role))
+	}
+	return permission
+}
+
+// 先頭の文字を大文字にする（エラーメッセージを Problem の detail にするため）
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/yuchi1128/task-management-system/backend/internal/problem"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+func TestClassifyStoreError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"not found", fmt.Errorf("get: %w", store.ErrNotFound), http.StatusNotFound, problem.CodeNotFound},
+		{"version mismatch", store.ErrVersionMismatch, http.StatusPreconditionFailed, problem.CodePreconditionFailed},
+		{"conflict", &store.ConstraintError{Kind: store.ErrConflict, Field: "name"}, http.StatusConflict, problem.CodeConflict},
+		{"invalid reference", &store.ConstraintError{Kind: store.ErrInvalidReference, Field: "label_ids"}, http.StatusUnprocessableEntity, problem.CodeInvalidReference},
+		{"constraint", &store.ConstraintError{Kind: store.ErrConstraint, Field: "status"}, http.StatusUnprocessableEntity, problem.CodeConstraintViolation},
+		{"canceled", fmt.Errorf("%w: query", context.Canceled), problem.StatusClientClosedRequest, problem.CodeRequestCanceled},
+		{"timeout", context.DeadlineExceeded, http.StatusGatewayTimeout, problem.CodeTimeout},
+		{"unexpected", errors.New("connection refused"), http.StatusInternalServerError, problem.CodeInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := classifyStoreError(tt.err, "Task", "Failed")
+			if p.Status != tt.status || p.Code != tt.code {
+				t.Errorf("classifyStoreError(%v) = %d %s, want %d %s", tt.err, p.Status, p.Code, tt.status, tt.code)
+			}
+		})
+	}
+}
+
+func TestClassifyStoreErrorFields(t *testing.T) {
+	p := classifyStoreError(&store.ConstraintError{Kind: store.ErrConflict, Field: "name", Constraint: "labels_name_key"}, "Label", "Failed")
+	if len(p.Errors) != 1 || p.Errors[0].Field != "name" || p.Errors[0].Location != "body" {
+		t.Errorf("errors = %+v, want the name field", p.Errors)
+	}
+	// 内部のエラーの内容は応答に含めない
+	p = classifyStoreError(errors.New("pq: password authentication failed"), "Task", "Failed to fetch tasks")
+	if p.Detail != "Failed to fetch tasks" {
+		t.Errorf("detail = %q, want the generic failure", p.Detail)
+	}
+}
+
+func TestRoutingErrors(t *testing.T) {
+	s := newTestServer(t)
+	if p := s.do(t, http.MethodGet, "/nowhere", nil).expect(t, http.StatusNotFound).problem(t); p.Code != problem.CodeNotFound {
+		t.Errorf("code = %q, want %q", p.Code, problem.CodeNotFound)
+	}
+	if p := s.do(t, http.MethodPatch, "/labels", "{}").expect(t, http.StatusMethodNotAllowed).problem(t); p.Code != problem.CodeMethodNotAllowed {
+		t.Errorf("code = %q, want %q", p.Code, problem.CodeMethodNotAllowed)
+	}
+}
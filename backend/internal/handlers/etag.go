@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+// versionETag はリソースの版から強いETagを作成します
+func versionETag(version int) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// ifMatch はIf-Matchヘッダーの版を指定して update を呼び出します
+// ETagはカンマ区切りで複数指定でき（RFC 9110 13.1.1）、版が一致しない場合ストアは何も変更しないため、
+// 現在の版と一致するまで順に試します。一致する版がなければ store.ErrVersionMismatch を返して412にします
+func ifMatch(r *http.Request, update func(version int) error) error {
+	versions, err := ifMatchVersions(r)
+	if err != nil {
+		return err
+	}
+	for _, version := range versions {
+		if err := update(version); !errors.Is(err, store.ErrVersionMismatch) {
+			return err
+		}
+	}
+	return store.ErrVersionMismatch
+}
+
+// ifMatchVersions はIf-Matchヘッダーからストアに渡す版を取り出します
+// ヘッダーがない場合と "*" を含む場合は store.AnyVersion だけを返します
+// 弱いETagや版として解釈できないETagはどの版とも一致しないため、
+// 版を1つも取り出せない場合は store.ErrVersionMismatch を返します
+func ifMatchVersions(r *http.Request) ([]int, error) {
+	header := strings.Join(r.Header.Values("If-Match"), ",")
+	if strings.TrimSpace(header) == "" {
+		return []int{store.AnyVersion}, nil
+	}
+	var versions []int
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return []int{store.AnyVersion}, nil
+		}
+		if version, ok := parseVersionETag(tag); ok && !slices.Contains(versions, version) {
+			versions = append(versions, version)
+		}
+	}
+	if len(versions) == 0 {
+		return nil, store.ErrVersionMismatch
+	}
+	return versions, nil
+}
+
+// parseVersionETag は versionETag で作成した強いETagから版を取り出します
+func parseVersionETag(tag string) (int, bool) {
+	tag, ok := strings.CutPrefix(tag, `"`)
+	if !ok {
+		return 0, false
+	}
+	tag, ok = strings.CutSuffix(tag, `"`)
+	if !ok {
+		return 0, false
+	}
+	version, err := strconv.Atoi(tag)
+	if err != nil || version < 1 {
+		return 0, false
+	}
+	return version, true
+}
+
+// notModified はIf-None-Matchヘッダーが etag に一致するかを判定します（弱い比較）
+func notModified(r *http.Request, etag string) bool {
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	for _, tag := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// writeJSONWithETag はレスポンスにETagを付けてJSONで書き込みます
+// etag が空の場合はボディのハッシュから弱いETagを作成します
+// If-None-Matchが一致した場合はボディを書かずに304を返します
+func writeJSONWithETag(w http.ResponseWriter, r *http.Request, etag string, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	body = append(body, '\n')
+	if etag == "" {
+		sum := sha256.Sum256(body)
+		etag = `W/"` + hex.EncodeToString(sum[:16]) + `"`
+	}
+
+	w.Header().Set("ETag", etag)
+	if notModified(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// writeVersioned は更新後のリソースを版のETagと一緒にJSONで書き込みます
+func writeVersioned(w http.ResponseWriter, version int, v interface{}) {
+	w.Header().Set("ETag", versionETag(version))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+func TestIfMatchVersions(t *testing.T) {
+	tests := []struct {
+		header  string
+		want    string
+		wantErr bool
+	}{
+		{"", "[0]", false},
+		{"*", "[0]", false},
+		{`"3"`, "[3]", false},
+		{` "12" `, "[12]", false},
+		// 弱いETagや版でないETagはどの版とも一致しない
+		{`W/"3"`, "", true},
+		{`"abc"`, "", true},
+		{`"0"`, "", true},
+		{`3`, "", true},
+		// カンマ区切りのリストでは版として解釈できるETagだけを取り出す
+		{`"2", "3"`, "[2 3]", false},
+		{`W/"2", "4", "4"`, "[4]", false},
+		{`"3", *`, "[0]", false},
+		{`W/"2", "abc"`, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "/tasks/1", nil)
+			r.Header.Set("If-Match", tt.header)
+			got, err := ifMatchVersions(r)
+			if tt.wantErr {
+				if !errors.Is(err, store.ErrVersionMismatch) {
+					t.Errorf("ifMatchVersions(%q) = %v, %v, want ErrVersionMismatch", tt.header, got, err)
+				}
+				return
+			}
+			if err != nil || fmt.Sprint(got) != tt.want {
+				t.Errorf("ifMatchVersions(%q) = %v, %v, want %s", tt.header, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestIfMatch(t *testing.T) {
+	// 現在の版が 3 のリソースを更新する
+	update := func(version int) error {
+		if version != store.AnyVersion && version != 3 {
+			return store.ErrVersionMismatch
+		}
+		return nil
+	}
+	tests := []struct {
+		header string
+		want   error
+	}{
+		{`"3"`, nil},
+		{`"1", "3"`, nil},
+		{`"1", "2"`, store.ErrVersionMismatch},
+		{"*", nil},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPut, "/tasks/1", nil)
+		r.Header.Set("If-Match", tt.header)
+		if err := ifMatch(r, update); !errors.Is(err, tt.want) {
+			t.Errorf("ifMatch(%q) = %v, want %v", tt.header, err, tt.want)
+		}
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	tests := []struct {
+		header string
+		etag   string
+		want   bool
+	}{
+		{"", `"1"`, false},
+		{"*", `"1"`, true},
+		{`"1"`, `"1"`, true},
+		{`"2", "1"`, `"1"`, true},
+		// 弱い比較なので W/ の有無は区別しない
+		{`W/"abc"`, `"abc"`, true},
+		{`"abc"`, `W/"abc"`, true},
+		{`"2"`, `"1"`, false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+		if tt.header != "" {
+			r.Header.Set("If-None-Match", tt.header)
+		}
+		if got := notModified(r, tt.etag); got != tt.want {
+			t.Errorf("notModified(%q, %q) = %v, want %v", tt.header, tt.etag, got, tt.want)
+		}
+	}
+}
+
+func TestTaskHandlerConditionalRequests(t *testing.T) {
+	s := newTestServer(t)
+	id := s.createTask(t, "write report", nil)
+	path := fmt.Sprintf("/tasks/%d", id)
+
+	etag := s.do(t, http.MethodGet, path, nil).expect(t, http.StatusOK).Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("GET returned no ETag")
+	}
+	s.do(t, http.MethodGet, path, nil, "If-None-Match", etag).expect(t, http.StatusNotModified)
+
+	// 取得した版で更新すると新しいETagになり、古いETagでの更新は412になる
+	updated := s.do(t, http.MethodPatch, path, map[string]interface{}{"status": "InProgress"}, "If-Match", etag).
+		expect(t, http.StatusOK).Header.Get("ETag")
+	if updated == "" || updated == etag {
+		t.Fatalf("ETag after PATCH = %q, want a new ETag other than %q", updated, etag)
+	}
+	p := s.do(t, http.MethodPatch, path, map[string]interface{}{"status": "Completed"}, "If-Match", etag).
+		expect(t, http.StatusPreconditionFailed).problem(t)
+	if p.Code != "precondition_failed" {
+		t.Errorf("code = %q, want precondition_failed", p.Code)
+	}
+	s.do(t, http.MethodDelete, path, nil, "If-Match", etag).expect(t, http.StatusPreconditionFailed)
+	if task := s.getTask(t, id); string(*task.Status) != "InProgress" {
+		t.Errorf("status = %s after rejected requests, want InProgress", *task.Status)
+	}
+
+	// 一覧は内容から作るETagで、変更がなければ304になる
+	listETag := s.do(t, http.MethodGet, "/tasks", nil).expect(t, http.StatusOK).Header.Get("ETag")
+	s.do(t, http.MethodGet, "/tasks", nil, "If-None-Match", listETag).expect(t, http.StatusNotModified)
+	s.do(t, http.MethodPatch, path, map[string]interface{}{"name": "renamed"}).expect(t, http.StatusOK)
+	s.do(t, http.MethodGet, "/tasks", nil, "If-None-Match", listETag).expect(t, http.StatusOK)
+
+	// 複数のETagはいずれかが現在の版に一致すればよい
+	current := s.do(t, http.MethodGet, path, nil).Header.Get("ETag")
+	s.do(t, http.MethodDelete, path, nil, "If-Match", etag+", "+current).expect(t, http.StatusNoContent)
+}
+
+func TestLabelHandlerConditionalRequests(t *testing.T) {
+	s := newTestServer(t)
+	s.do(t, http.MethodPost, "/labels", map[string]string{"name": "bug", "color": "#FF0000"}).expect(t, http.StatusCreated)
+	path := fmt.Sprintf("/labels/%d", labelByName(t, s, "bug").ID)
+
+	etag := s.do(t, http.MethodGet, path, nil).expect(t, http.StatusOK).Header.Get("ETag")
+	// 更新後のラベルと新しいETagを返す
+	resp := s.do(t, http.MethodPut, path, map[string]string{"name": "defect", "color": "#00FF00"}, "If-Match", etag).expect(t, http.StatusOK)
+	var updated api.Label
+	resp.decode(t, &updated)
+	if updated.Name != "defect" || resp.Header.Get("ETag") != versionETag(updated.Version) || resp.Header.Get("ETag") == etag {
+		t.Errorf("PUT returned %+v with ETag %q, want the updated label and its new ETag", updated, resp.Header.Get("ETag"))
+	}
+	s.do(t, http.MethodPut, path, map[string]string{"name": "issue", "color": "#0000FF"}, "If-Match", etag).
+		expect(t, http.StatusPreconditionFailed).problem(t)
+	s.do(t, http.MethodDelete, path, nil, "If-Match", etag).expect(t, http.StatusPreconditionFailed)
+	if label := labelByName(t, s, "defect"); label.Color != "#00FF00" {
+		t.Errorf("label after rejected update = %+v", label)
+	}
+}
+
+func TestPutHandlersReturnUpdatedEntity(t *testing.T) {
+	s := newTestServer(t)
+	id := s.createTask(t, "write report", nil)
+	path := fmt.Sprintf("/tasks/%d", id)
+
+	resp := s.do(t, http.MethodPut, path, map[string]interface{}{"name": "write annual report", "priority": "High", "status": "InProgress"}).
+		expect(t, http.StatusOK)
+	var task api.Task
+	resp.decode(t, &task)
+	if *task.Name != "write annual report" || resp.Header.Get("ETag") != versionETag(*task.Version) {
+		t.Errorf("PUT %s returned %+v with ETag %q, want the updated task and its ETag", path, task, resp.Header.Get("ETag"))
+	}
+
+	s.do(t, http.MethodPost, "/labels", map[string]string{"name": "bug", "color": "#FF0000"}).expect(t, http.StatusCreated)
+	label := labelByName(t, s, "bug")
+	resp = s.do(t, http.MethodPut, path+"/labels", map[string]interface{}{"label_ids": []int{label.ID}}, "If-Match", resp.Header.Get("ETag")).
+		expect(t, http.StatusOK)
+	var labeled api.Task
+	resp.decode(t, &labeled)
+	if len(labeled.Labels) != 1 || labeled.Labels[0].ID != label.ID || resp.Header.Get("ETag") != versionETag(*labeled.Version) ||
+		*labeled.Version <= *task.Version {
+		t.Errorf("PUT %s/labels returned %+v with ETag %q, want the labeled task and its new ETag", path, labeled, resp.Header.Get("ETag"))
+	}
+}
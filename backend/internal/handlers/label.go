@@ -4,31 +4,62 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"slices"
 	"strconv"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/authz"
+	"github.com/yuchi1128/task-management-system/backend/internal/problem"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
 )
 
 type LabelHandler struct {
-	db *sqlx.DB
+	store store.LabelStore
+	// tasks はラベルを更新したタスクを返すために使います
+	tasks store.TaskStore
+	authz *authz.Authorizer
 }
 
-func NewLabelHandler(db *sqlx.DB) *LabelHandler {
-	return &LabelHandler{db}
+func NewLabelHandler(labelStore store.LabelStore, taskStore store.TaskStore, authorizer *authz.Authorizer) *LabelHandler {
+	return &LabelHandler{labelStore, taskStore, authorizer}
 }
 
-// ラベル一覧を取得
+// ラベル一覧を取得（project_id を指定した場合はそのプロジェクトのラベルだけ）
 func (h *LabelHandler) GetLabels(w http.ResponseWriter, r *http.Request) {
 	log.Println("Handling GetLabels request")
+	var projectID int
+	if raw := r.URL.Query().Get("project_id"); raw != "" {
+		var err error
+		if projectID, err = strconv.Atoi(raw); err != nil {
+			problem.Write(w, r, problem.Validation([]problem.FieldError{{Field: "project_id", Location: "query", Message: "must be an integer"}}))
+			return
+		}
+		// 閲覧できないプロジェクトを指定した場合は、空の一覧ではなくエラーにする
+		if err := h.authz.Project(r.Context(), projectID, authz.ReadProject); err != nil {
+			log.Printf("Error authorizing project: %v", err)
+			writeStoreError(w, r, err, "Project", "Failed to fetch labels")
+			return
+		}
+	}
+	h.listLabels(w, r, projectID)
+}
 
-	var labels []api.Label
-	err := h.db.Select(&labels, "SELECT * FROM labels ORDER BY name")
+// projectID のプロジェクトのラベル一覧を返す（0 の場合はすべて）
+// 閲覧できないプロジェクトのラベルは含めない
+func (h *LabelHandler) listLabels(w http.ResponseWriter, r *http.Request, projectID int) {
+	labels, err := h.store.ListLabels(r.Context(), projectID)
 	if err != nil {
 		log.Printf("Error fetching labels: %v", err)
-		http.Error(w, "Failed to fetch labels", http.StatusInternalServerError)
+		writeStoreError(w, r, err, "Label", "Failed to fetch labels")
 		return
 	}
+	visible, err := h.authz.Projects(r.Context(), authz.ReadProject)
+	if err != nil {
+		log.Printf("Error fetching project roles: %v", err)
+		writeStoreError(w, r, err, "Label", "Failed to fetch labels")
+		return
+	}
+	labels = slices.DeleteFunc(labels, func(l api.Label) bool { return !visible(l.ProjectId) })
 
 	response := struct {
 		Labels []api.Label `json:"labels"`
@@ -36,29 +67,34 @@ func (h *LabelHandler) GetLabels(w http.ResponseWriter, r *http.Request) {
 		Labels: labels,
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	writeJSONWithETag(w, r, "", response)
 }
 
-// 新しいラベルを作成
+// 新しいラベルを既定のプロジェクトに作成
 func (h *LabelHandler) CreateLabel(w http.ResponseWriter, r *http.Request) {
 	log.Println("Handling CreateLabel request")
+	h.createLabel(w, r, store.DefaultProjectID, "Label")
+}
+
+// projectID のプロジェクトにラベルを作成する
+// resource は404の説明に使うリソース名です
+func (h *LabelHandler) createLabel(w http.ResponseWriter, r *http.Request, projectID int, resource string) {
 	var input api.LabelInput
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		log.Printf("Error decoding request body: %v", err)
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid request body"))
 		return
 	}
 
-	var labelID int
-	err := h.db.QueryRow(
-		"INSERT INTO labels (name, color) VALUES ($1, $2) RETURNING id",
-		input.Name, input.Color,
-	).Scan(&labelID)
+	if err := h.authz.Project(r.Context(), projectID, authz.WriteLabels); err != nil {
+		log.Printf("Error authorizing label creation: %v", err)
+		writeStoreError(w, r, err, "Project", "Failed to create label")
+		return
+	}
 
-	if err != nil {
+	if _, err := h.store.CreateLabel(r.Context(), projectID, input); err != nil {
 		log.Printf("Error creating label: %v", err)
-		http.Error(w, "Failed to create label", http.StatusInternalServerError)
+		writeStoreError(w, r, err, resource, "Failed to create label")
 		return
 	}
 
@@ -72,20 +108,24 @@ func (h *LabelHandler) GetLabel(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
 		log.Printf("Invalid label ID: %v", err)
-		http.Error(w, "Invalid label ID", http.StatusBadRequest)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid label ID"))
 		return
 	}
 
-	var label api.Label
-	err = h.db.Get(&label, "SELECT * FROM labels WHERE id = $1", id)
+	if err := h.authz.Label(r.Context(), id, authz.ReadProject); err != nil {
+		log.Printf("Error authorizing label: %v", err)
+		writeStoreError(w, r, err, "Label", "Failed to fetch label")
+		return
+	}
+
+	label, err := h.store.GetLabel(r.Context(), id)
 	if err != nil {
 		log.Printf("Error fetching label: %v", err)
-		http.Error(w, "Label not found", http.StatusNotFound)
+		writeStoreError(w, r, err, "Label", "Failed to fetch label")
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(label)
+	writeJSONWithETag(w, r, versionETag(label.Version), label)
 }
 
 // 指定したIDのラベルを更新
@@ -95,28 +135,39 @@ func (h *LabelHandler) UpdateLabel(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
 		log.Printf("Invalid label ID: %v", err)
-		http.Error(w, "Invalid label ID", http.StatusBadRequest)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid label ID"))
+		return
+	}
+
+	if err := h.authz.Label(r.Context(), id, authz.WriteLabels); err != nil {
+		log.Printf("Error authorizing label: %v", err)
+		writeStoreError(w, r, err, "Label", "Failed to update label")
 		return
 	}
 
 	var input api.LabelInput
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		log.Printf("Error decoding request body: %v", err)
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid request body"))
 		return
 	}
 
-	_, err = h.db.Exec(
-		"UPDATE labels SET name = $1, color = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3",
-		input.Name, input.Color, id,
-	)
+	err = ifMatch(r, func(version int) error {
+		return h.store.UpdateLabel(r.Context(), id, input, version)
+	})
 	if err != nil {
 		log.Printf("Error updating label: %v", err)
-		http.Error(w, "Failed to update label", http.StatusInternalServerError)
+		writeStoreError(w, r, err, "Label", "Failed to update label")
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	label, err := h.store.GetLabel(r.Context(), id)
+	if err != nil {
+		log.Printf("Error fetching label: %v", err)
+		writeStoreError(w, r, err, "Label", "Failed to fetch label")
+		return
+	}
+	writeVersioned(w, label.Version, label)
 }
 
 // 指定したIDのラベルを削除
@@ -126,14 +177,22 @@ func (h *LabelHandler) DeleteLabel(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
 		log.Printf("Invalid label ID: %v", err)
-		http.Error(w, "Invalid label ID", http.StatusBadRequest)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid label ID"))
 		return
 	}
 
-	_, err = h.db.Exec("DELETE FROM labels WHERE id = $1", id)
+	if err := h.authz.Label(r.Context(), id, authz.WriteLabels); err != nil {
+		log.Printf("Error authorizing label: %v", err)
+		writeStoreError(w, r, err, "Label", "Failed to delete label")
+		return
+	}
+
+	err = ifMatch(r, func(version int) error {
+		return h.store.DeleteLabel(r.Context(), id, version)
+	})
 	if err != nil {
 		log.Printf("Error deleting label: %v", err)
-		http.Error(w, "Failed to delete label", http.StatusInternalServerError)
+		writeStoreError(w, r, err, "Label", "Failed to delete label")
 		return
 	}
 
@@ -150,7 +209,13 @@ func (h *LabelHandler) UpdateTaskLabels(w http.ResponseWriter, r *http.Request)
 	taskID, err := strconv.Atoi(taskIDStr)
 	if err != nil {
 		log.Printf("Invalid task ID: %v", err)
-		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid task ID"))
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), taskID, authz.WriteTasks); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to update task labels")
 		return
 	}
 
@@ -160,43 +225,25 @@ func (h *LabelHandler) UpdateTaskLabels(w http.ResponseWriter, r *http.Request)
 	}
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		log.Printf("Error decoding request body: %v", err)
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid request body"))
 		return
 	}
 
-	// トランザクション開始
-	tx, err := h.db.Beginx()
+	// If-Match はタスクのETagと比較する
+	err = ifMatch(r, func(version int) error {
+		return h.store.SetTaskLabels(r.Context(), taskID, input.LabelIDs, version)
+	})
 	if err != nil {
-		log.Printf("Error starting transaction: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		log.Printf("Error updating task labels: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to update task labels")
 		return
 	}
-	defer tx.Rollback()
 
-	// 既存のラベル関連を削除
-	_, err = tx.Exec("DELETE FROM task_labels WHERE task_id = $1", taskID)
+	task, err := h.tasks.GetTask(r.Context(), taskID)
 	if err != nil {
-		log.Printf("Error deleting task labels: %v", err)
-		http.Error(w, "Failed to update task labels", http.StatusInternalServerError)
+		log.Printf("Error fetching task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to fetch task")
 		return
 	}
-
-	// 新しいラベル関連を追加
-	for _, labelID := range input.LabelIDs {
-		_, err = tx.Exec("INSERT INTO task_labels (task_id, label_id) VALUES ($1, $2)", taskID, labelID)
-		if err != nil {
-			log.Printf("Error inserting task label: %v", err)
-			http.Error(w, "Failed to update task labels", http.StatusInternalServerError)
-			return
-		}
-	}
-
-	// トランザクション確定
-	if err = tx.Commit(); err != nil {
-		log.Printf("Error committing transaction: %v", err)
-		http.Error(w, "Failed to update task labels", http.StatusInternalServerError)
-		return
-	}
-
-	w.WriteHeader(http.StatusOK)
+	writeVersioned(w, *task.Version, task)
 }
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// labelByName は名前でラベルを探します
+func labelByName(t *testing.T, s *testServer, name string) api.Label {
+	t.Helper()
+	var list struct {
+		Labels []api.Label `json:"labels"`
+	}
+	s.do(t, http.MethodGet, "/labels", nil).expect(t, http.StatusOK).decode(t, &list)
+	for _, l := range list.Labels {
+		if l.Name == name {
+			return l
+		}
+	}
+	t.Fatalf("label %q not found in %+v", name, list.Labels)
+	return api.Label{}
+}
+
+func TestLabelHandler(t *testing.T) {
+	s := newTestServer(t)
+	s.do(t, http.MethodPost, "/labels", map[string]string{"name": "bug", "color": "#FF0000"}).expect(t, http.StatusCreated)
+	label := labelByName(t, s, "bug")
+
+	path := fmt.Sprintf("/labels/%d", label.ID)
+	s.do(t, http.MethodPut, path, map[string]string{"name": "defect", "color": "#00FF00"}).expect(t, http.StatusOK)
+	var got api.Label
+	s.do(t, http.MethodGet, path, nil).expect(t, http.StatusOK).decode(t, &got)
+	if got.Name != "defect" || got.Color != "#00FF00" {
+		t.Fatalf("updated label = %+v", got)
+	}
+
+	task := s.createTask(t, "task", nil)
+	s.do(t, http.MethodPut, fmt.Sprintf("/tasks/%d/labels", task), map[string][]int{"label_ids": {label.ID}}).expect(t, http.StatusOK)
+	if labels := s.getTask(t, task).Labels; len(labels) != 1 || labels[0].ID != label.ID {
+		t.Fatalf("task labels = %+v, want defect", labels)
+	}
+
+	s.do(t, http.MethodDelete, path, nil).expect(t, http.StatusNoContent)
+	s.do(t, http.MethodGet, path, nil).expect(t, http.StatusNotFound)
+	if labels := s.getTask(t, task).Labels; len(labels) != 0 {
+		t.Errorf("task labels after deleting the label = %+v, want none", labels)
+	}
+}
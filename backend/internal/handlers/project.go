@@ -0,0 +1,445 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/authz"
+	"github.com/yuchi1128/task-management-system/backend/internal/problem"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+type ProjectHandler struct {
+	store   store.ProjectStore
+	members store.MemberStore
+	authz   *authz.Authorizer
+	tasks   *TaskHandler
+	labels  *LabelHandler
+}
+
+// NewProjectHandler はプロジェクトのハンドラーを作成します
+// プロジェクトのタスクとラベルの一覧・作成は tasks と labels のハンドラーに任せます
+func NewProjectHandler(projectStore store.ProjectStore, memberStore store.MemberStore, authorizer *authz.Authorizer, tasks *TaskHandler, labels *LabelHandler) *ProjectHandler {
+	return &ProjectHandler{store: projectStore, members: memberStore, authz: authorizer, tasks: tasks, labels: labels}
+}
+
+// GetProjects はプロジェクトの一覧を名前順で取得します（archived=true の場合はアーカイブしたもの）
+// 閲覧できないプロジェクトは含めません
+func (h *ProjectHandler) GetProjects(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling GetProjects request")
+	p := filterParser{query: r.URL.Query()}
+	archived := p.bool("archived")
+	if len(p.errors) > 0 {
+		problem.Write(w, r, problem.Validation(p.errors))
+		return
+	}
+
+	projects, err := h.store.ListProjects(r.Context(), archived != nil && *archived)
+	if err != nil {
+		log.Printf("Error fetching projects: %v", err)
+		writeStoreError(w, r, err, "Project", "Failed to fetch projects")
+		return
+	}
+	visible, err := h.authz.Projects(r.Context(), authz.ReadProject)
+	if err != nil {
+		log.Printf("Error fetching project roles: %v", err)
+		writeStoreError(w, r, err, "Project", "Failed to fetch projects")
+		return
+	}
+	projects = slices.DeleteFunc(projects, func(p api.Project) bool { return !visible(p.Id) })
+	if projects == nil {
+		projects = []api.Project{}
+	}
+
+	response := struct {
+		Projects []api.Project `json:"projects"`
+	}{
+		Projects: projects,
+	}
+	writeJSONWithETag(w, r, "", response)
+}
+
+// CreateProject はプロジェクトを作成します（作成したユーザーがプロジェクトの admin になります）
+func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling CreateProject request")
+	var input api.PostProjectsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		log.Printf("Error decoding request body: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid request body"))
+		return
+	}
+
+	project, err := h.store.CreateProject(r.Context(), input)
+	if err != nil {
+		log.Printf("Error creating project: %v", err)
+		writeStoreError(w, r, err, "Project", "Failed to create project")
+		return
+	}
+
+	log.Printf("Project created successfully with ID: %d", project.Id)
+	w.Header().Set("ETag", versionETag(project.Version))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(project)
+}
+
+// GetProject はIDのプロジェクトを取得します
+func (h *ProjectHandler) GetProject(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling GetProject request")
+	id, ok := projectPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authz.Project(r.Context(), id, authz.ReadProject); err != nil {
+		log.Printf("Error authorizing project: %v", err)
+		writeStoreError(w, r, err, "Project", "Failed to fetch project")
+		return
+	}
+
+	project, err := h.store.GetProject(r.Context(), id)
+	if err != nil {
+		log.Printf("Error fetching project: %v", err)
+		writeStoreError(w, r, err, "Project", "Failed to fetch project")
+		return
+	}
+
+	writeJSONWithETag(w, r, versionETag(project.Version), project)
+}
+
+// UpdateProject はプロジェクトの名前と説明を更新します
+func (h *ProjectHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling UpdateProject request")
+	id, ok := projectPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authz.Project(r.Context(), id, authz.ManageProject); err != nil {
+		log.Printf("Error authorizing project: %v", err)
+		writeStoreError(w, r, err, "Project", "Failed to update project")
+		return
+	}
+
+	var input api.PutProjectsPidJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		log.Printf("Error decoding request body: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid request body"))
+		return
+	}
+
+	var project api.Project
+	err := ifMatch(r, func(version int) (err error) {
+		project, err = h.store.UpdateProject(r.Context(), id, input, version)
+		return err
+	})
+	if err != nil {
+		log.Printf("Error updating project: %v", err)
+		writeStoreError(w, r, err, "Project", "Failed to update project")
+		return
+	}
+
+	writeVersioned(w, project.Version, project)
+}
+
+// ArchiveProject はプロジェクトをアーカイブし、そのタスクとラベルを読み取り専用にします
+func (h *ProjectHandler) ArchiveProject(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling ArchiveProject request")
+	h.setArchived(w, r, true)
+}
+
+// UnarchiveProject はプロジェクトをアーカイブから戻します
+func (h *ProjectHandler) UnarchiveProject(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling UnarchiveProject request")
+	h.setArchived(w, r, false)
+}
+
+// プロジェクトのアーカイブの状態を変更し、変更後のプロジェクトを返す
+func (h *ProjectHandler) setArchived(w http.ResponseWriter, r *http.Request, archived bool) {
+	id, ok := projectPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authz.Project(r.Context(), id, authz.ManageProject); err != nil {
+		log.Printf("Error authorizing project: %v", err)
+		writeStoreError(w, r, err, "Project", "Failed to archive project")
+		return
+	}
+
+	var project api.Project
+	err := ifMatch(r, func(version int) (err error) {
+		project, err = h.store.SetProjectArchived(r.Context(), id, archived, version)
+		return err
+	})
+	if err != nil {
+		log.Printf("Error archiving project: %v", err)
+		writeStoreError(w, r, err, "Project", "Failed to archive project")
+		return
+	}
+
+	log.Printf("Project %d archived: %t", id, project.ArchivedAt != nil)
+	writeVersioned(w, project.Version, project)
+}
+
+// GetProjectTasks はプロジェクトのタスクを GET /tasks と同じ条件で取得します（アーカイブしたプロジェクトも含む）
+func (h *ProjectHandler) GetProjectTasks(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling GetProjectTasks request")
+	id, ok := projectPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authz.Project(r.Context(), id, authz.ReadProject); err != nil {
+		log.Printf("Error authorizing project: %v", err)
+		writeStoreError(w, r, err, "Project", "Failed to fetch tasks")
+		return
+	}
+
+	filter, fieldErrors := parseTaskFilter(r.Context(), r.URL.Query())
+	if len(fieldErrors) > 0 {
+		problem.Write(w, r, problem.Validation(fieldErrors))
+		return
+	}
+	// プロジェクトが存在しない場合は空の一覧ではなく404にする
+	if _, err := h.store.GetProject(r.Context(), id); err != nil {
+		log.Printf("Error fetching project: %v", err)
+		writeStoreError(w, r, err, "Project", "Failed to fetch tasks")
+		return
+	}
+	filter.ProjectID = &id
+	h.tasks.listTasks(w, r, filter)
+}
+
+// CreateProjectTask はプロジェクトにタスクを作成します
+func (h *ProjectHandler) CreateProjectTask(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling CreateProjectTask request")
+	id, ok := projectPath(w, r)
+	if !ok {
+		return
+	}
+	h.tasks.createTask(w, r, id, "Project")
+}
+
+// GetProjectLabels はプロジェクトのラベルを名前順で取得します
+func (h *ProjectHandler) GetProjectLabels(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling GetProjectLabels request")
+	id, ok := projectPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authz.Project(r.Context(), id, authz.ReadProject); err != nil {
+		log.Printf("Error authorizing project: %v", err)
+		writeStoreError(w, r, err, "Project", "Failed to fetch labels")
+		return
+	}
+
+	// プロジェクトが存在しない場合は空の一覧ではなく404にする
+	if _, err := h.store.GetProject(r.Context(), id); err != nil {
+		log.Printf("Error fetching project: %v", err)
+		writeStoreError(w, r, err, "Project", "Failed to fetch labels")
+		return
+	}
+	h.labels.listLabels(w, r, id)
+}
+
+// CreateProjectLabel はプロジェクトにラベルを作成します
+func (h *ProjectHandler) CreateProjectLabel(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling CreateProjectLabel request")
+	id, ok := projectPath(w, r)
+	if !ok {
+		return
+	}
+	h.labels.createLabel(w, r, id, "Project")
+}
+
+// MoveTask はタスクをサブタスクごと別のプロジェクトに移動し、ラベルを移動先のラベルに付け替えます
+func (h *ProjectHandler) MoveTask(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling MoveTask request")
+	pathParts := r.URL.Path[len("/tasks/"):]
+	id, err := strconv.Atoi(strings.TrimSuffix(pathParts, "/move"))
+	if err != nil {
+		log.Printf("Invalid task ID: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid task ID"))
+		return
+	}
+
+	var input api.PostTasksIdMoveJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		log.Printf("Error decoding request body: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid request body"))
+		return
+	}
+	move := store.TaskMove{
+		ProjectID:         input.ProjectId,
+		DropMissingLabels: input.MissingLabels != nil && *input.MissingLabels == api.TaskMoveMissingLabelsDrop,
+	}
+	// JSONのオブジェクトのキーは文字列なので、移動元のラベルIDに変換する
+	if input.LabelMap != nil {
+		move.LabelMap = make(map[int]int, len(*input.LabelMap))
+		for key, to := range *input.LabelMap {
+			from, err := strconv.Atoi(key)
+			if err != nil {
+				problem.Write(w, r, problem.Validation([]problem.FieldError{{
+					Field:    "label_map." + key,
+					Location: "body",
+					Message:  fmt.Sprintf("key %q is not a label ID", key),
+				}}))
+				return
+			}
+			move.LabelMap[from] = to
+		}
+	}
+
+	// 移動元と移動先の両方で tasks:write が必要（移動先が存在しない場合はストアの検証に任せる）
+	if err := h.authz.Task(r.Context(), id, authz.WriteTasks); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to move task")
+		return
+	}
+	if err := h.authz.Project(r.Context(), move.ProjectID, authz.WriteTasks); err != nil && !errors.Is(err, store.ErrNotFound) {
+		log.Printf("Error authorizing target project: %v", err)
+		writeStoreError(w, r, err, "Project", "Failed to move task")
+		return
+	}
+
+	// If-Match は移動するタスクのETagと比較する
+	var task api.Task
+	err = ifMatch(r, func(version int) (err error) {
+		task, err = h.store.MoveTask(r.Context(), id, move, version)
+		return err
+	})
+	if err != nil {
+		log.Printf("Error moving task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to move task")
+		return
+	}
+
+	log.Printf("Task %d moved to project %d", id, move.ProjectID)
+	writeVersioned(w, *task.Version, task)
+}
+
+// GetProjectMembers はプロジェクトのメンバーと役割をユーザー名の順に取得します
+func (h *ProjectHandler) GetProjectMembers(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling GetProjectMembers request")
+	id, ok := projectPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authz.Project(r.Context(), id, authz.ReadProject); err != nil {
+		log.Printf("Error authorizing project: %v", err)
+		writeStoreError(w, r, err, "Project", "Failed to fetch project members")
+		return
+	}
+
+	members, err := h.members.ListProjectMembers(r.Context(), id)
+	if err != nil {
+		log.Printf("Error fetching project members: %v", err)
+		writeStoreError(w, r, err, "Project", "Failed to fetch project members")
+		return
+	}
+	if members == nil {
+		members = []api.ProjectMember{}
+	}
+
+	response := struct {
+		Members []api.ProjectMember `json:"members"`
+	}{
+		Members: members,
+	}
+	writeJSONWithETag(w, r, "", response)
+}
+
+// SetProjectMember はユーザーをプロジェクトのメンバーにするか、役割を変更します
+func (h *ProjectHandler) SetProjectMember(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling SetProjectMember request")
+	id, userID, ok := memberPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authz.Project(r.Context(), id, authz.ManageProject); err != nil {
+		log.Printf("Error authorizing project: %v", err)
+		writeStoreError(w, r, err, "Project", "Failed to update project member")
+		return
+	}
+
+	var input api.PutProjectsPidMembersUidJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		log.Printf("Error decoding request body: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid request body"))
+		return
+	}
+
+	member, err := h.members.SetProjectMember(r.Context(), id, userID, input.Role)
+	if err != nil {
+		log.Printf("Error updating project member: %v", err)
+		writeStoreError(w, r, err, "Project or user", "Failed to update project member")
+		return
+	}
+
+	log.Printf("User %d is now %s of project %d", userID, member.Role, id)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(member)
+}
+
+// RemoveProjectMember はユーザーをプロジェクトのメンバーから外します
+func (h *ProjectHandler) RemoveProjectMember(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling RemoveProjectMember request")
+	id, userID, ok := memberPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authz.Project(r.Context(), id, authz.ManageProject); err != nil {
+		log.Printf("Error authorizing project: %v", err)
+		writeStoreError(w, r, err, "Project", "Failed to remove project member")
+		return
+	}
+
+	if err := h.members.RemoveProjectMember(r.Context(), id, userID); err != nil {
+		log.Printf("Error removing project member: %v", err)
+		writeStoreError(w, r, err, "Project member", "Failed to remove project member")
+		return
+	}
+
+	log.Printf("User %d removed from project %d", userID, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// パスからプロジェクトIDを取り出す（失敗した場合はエラーを書き込んで false を返す）
+func projectPath(w http.ResponseWriter, r *http.Request) (int, bool) {
+	pathParts := strings.Split(r.URL.Path[len("/projects/"):], "/")
+	id, err := strconv.Atoi(pathParts[0])
+	if err != nil {
+		log.Printf("Invalid project ID: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid project ID"))
+		return 0, false
+	}
+	return id, true
+}
+
+// パスからプロジェクトIDとメンバーのユーザーIDを取り出す（失敗した場合はエラーを書き込んで false を返す）
+func memberPath(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	id, ok := projectPath(w, r)
+	if !ok {
+		return 0, 0, false
+	}
+	pathParts := strings.Split(r.URL.Path[len("/projects/"):], "/")
+	userID, err := strconv.Atoi(pathParts[len(pathParts)-1])
+	if err != nil {
+		log.Printf("Invalid user ID: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid user ID"))
+		return 0, 0, false
+	}
+	return id, userID, true
+}
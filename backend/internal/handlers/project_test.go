@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+type projectList struct {
+	Projects []api.Project `json:"projects"`
+}
+
+// join はユーザーを既定のプロジェクトのメンバーにします（最初の admin は起動時の設定で指定するため、テストではストアで設定する）
+func (s *testServer) join(t *testing.T, userID int, role api.ProjectRole) {
+	t.Helper()
+	if _, err := s.store.SetProjectMember(context.Background(), store.DefaultProjectID, userID, role); err != nil {
+		t.Fatalf("SetProjectMember: %v", err)
+	}
+}
+
+func TestProjectHandler(t *testing.T) {
+	s := newTestServer(t)
+	var project api.Project
+	s.do(t, http.MethodPost, "/projects", map[string]string{"name": "side project", "description": "weekends"}).
+		expect(t, http.StatusCreated).decode(t, &project)
+	path := fmt.Sprintf("/projects/%d", project.Id)
+
+	var got api.Project
+	s.do(t, http.MethodGet, path, nil).expect(t, http.StatusOK).decode(t, &got)
+	if got.Name != "side project" || *got.Description != "weekends" {
+		t.Fatalf("GET %s = %+v", path, got)
+	}
+	s.do(t, http.MethodPut, path, map[string]string{"name": "hobby"}, "If-Match", versionETag(got.Version)).expect(t, http.StatusOK)
+	s.do(t, http.MethodPut, path, map[string]string{"name": "stale"}, "If-Match", versionETag(got.Version)).
+		expect(t, http.StatusPreconditionFailed).problem(t)
+
+	var list projectList
+	s.do(t, http.MethodGet, "/projects", nil).expect(t, http.StatusOK).decode(t, &list)
+	if len(list.Projects) != 2 || list.Projects[1].Name != "hobby" {
+		t.Fatalf("GET /projects = %+v, want the default project and hobby", list.Projects)
+	}
+	p := s.do(t, http.MethodPost, "/projects", map[string]string{"name": "hobby"}).expect(t, http.StatusConflict).problem(t)
+	if len(p.Errors) == 0 || p.Errors[0].Field != "name" {
+		t.Errorf("errors = %+v, want name", p.Errors)
+	}
+
+	// プロジェクトのタスクとラベルはそのプロジェクトに作成され、一覧もそのプロジェクトに絞り込まれる
+	var task api.Task
+	s.do(t, http.MethodPost, path+"/tasks", map[string]interface{}{"name": "paint", "priority": "Low", "status": "NotStarted"}).
+		expect(t, http.StatusCreated).decode(t, &task)
+	s.createTask(t, "in default project", nil)
+	var tasks struct {
+		Tasks []api.Task `json:"tasks"`
+		Total int        `json:"total"`
+	}
+	s.do(t, http.MethodGet, path+"/tasks", nil).expect(t, http.StatusOK).decode(t, &tasks)
+	if tasks.Total != 1 || *tasks.Tasks[0].Id != *task.Id || *tasks.Tasks[0].ProjectId != project.Id {
+		t.Errorf("project tasks = %+v, want only paint", tasks)
+	}
+	s.do(t, http.MethodPost, path+"/labels", map[string]string{"name": "outdoor", "color": "#00FF00"}).expect(t, http.StatusCreated)
+	var labels struct {
+		Labels []api.Label `json:"labels"`
+	}
+	s.do(t, http.MethodGet, path+"/labels", nil).expect(t, http.StatusOK).decode(t, &labels)
+	if len(labels.Labels) != 1 || labels.Labels[0].Name != "outdoor" {
+		t.Errorf("project labels = %+v, want only outdoor", labels.Labels)
+	}
+	// 別のプロジェクトのラベルは付けられない
+	p = s.do(t, http.MethodPut, fmt.Sprintf("/tasks/%d/labels", *task.Id), map[string][]int{"label_ids": {labelByName(t, s, "重要").ID}}).
+		expect(t, http.StatusUnprocessableEntity).problem(t)
+	if p.Code != "invalid_reference" {
+		t.Errorf("code = %q, want invalid_reference", p.Code)
+	}
+
+	// アーカイブしたプロジェクトは一覧から外れ、読み取り専用になる
+	s.do(t, http.MethodPost, path+"/archive", nil).expect(t, http.StatusOK).decode(t, &got)
+	if got.ArchivedAt == nil {
+		t.Fatalf("archived project = %+v, want archived_at", got)
+	}
+	s.do(t, http.MethodGet, "/projects", nil).expect(t, http.StatusOK).decode(t, &list)
+	if len(list.Projects) != 1 {
+		t.Errorf("GET /projects after archiving = %+v, want only the default project", list.Projects)
+	}
+	s.do(t, http.MethodGet, "/projects?archived=true", nil).expect(t, http.StatusOK).decode(t, &list)
+	if len(list.Projects) != 1 || list.Projects[0].Id != project.Id {
+		t.Errorf("GET /projects?archived=true = %+v, want hobby", list.Projects)
+	}
+	s.do(t, http.MethodGet, path+"/tasks", nil).expect(t, http.StatusOK)
+	s.do(t, http.MethodPatch, fmt.Sprintf("/tasks/%d", *task.Id), map[string]string{"status": "Completed"}).
+		expect(t, http.StatusConflict).problem(t)
+	s.do(t, http.MethodPost, path+"/tasks", map[string]interface{}{"name": "x", "priority": "Low", "status": "NotStarted"}).
+		expect(t, http.StatusConflict).problem(t)
+
+	var restored api.Project
+	s.do(t, http.MethodPost, path+"/unarchive", nil).expect(t, http.StatusOK).decode(t, &restored)
+	if restored.ArchivedAt != nil {
+		t.Errorf("unarchived project = %+v, want no archived_at", restored)
+	}
+	s.do(t, http.MethodPatch, fmt.Sprintf("/tasks/%d", *task.Id), map[string]string{"status": "Completed"}).expect(t, http.StatusOK)
+
+	s.do(t, http.MethodGet, "/projects/999", nil).expect(t, http.StatusNotFound).problem(t)
+	s.do(t, http.MethodGet, "/projects/999/tasks", nil).expect(t, http.StatusNotFound).problem(t)
+	s.do(t, http.MethodGet, "/projects/999/labels", nil).expect(t, http.StatusNotFound).problem(t)
+}
+
+func TestProjectHandlerMoveTask(t *testing.T) {
+	s := newTestServer(t)
+	var target api.Project
+	s.do(t, http.MethodPost, "/projects", map[string]string{"name": "side project"}).expect(t, http.StatusCreated).decode(t, &target)
+	s.do(t, http.MethodPost, fmt.Sprintf("/projects/%d/labels", target.Id), map[string]string{"name": "outdoor", "color": "#00FF00"}).
+		expect(t, http.StatusCreated)
+	var labels struct {
+		Labels []api.Label `json:"labels"`
+	}
+	s.do(t, http.MethodGet, fmt.Sprintf("/projects/%d/labels", target.Id), nil).expect(t, http.StatusOK).decode(t, &labels)
+	outdoor := labels.Labels[0]
+
+	id := s.createTask(t, "paint", nil)
+	child := s.createTask(t, "buy paint", map[string]interface{}{"parent_id": id})
+	important := labelByName(t, s, "重要")
+	work := labelByName(t, s, "仕事")
+	s.do(t, http.MethodPut, fmt.Sprintf("/tasks/%d/labels", id), map[string][]int{"label_ids": {important.ID, work.ID}}).expect(t, http.StatusOK)
+
+	path := fmt.Sprintf("/tasks/%d/move", id)
+	p := s.do(t, http.MethodPost, path, map[string]interface{}{"project_id": target.Id, "label_map": map[string]int{"x": 1}}).
+		expect(t, http.StatusBadRequest).problem(t)
+	if len(p.Errors) == 0 || p.Errors[0].Field != "label_map.x" {
+		t.Errorf("errors = %+v, want label_map.x", p.Errors)
+	}
+	s.do(t, http.MethodPost, path, map[string]interface{}{"project_id": 999}).expect(t, http.StatusUnprocessableEntity).problem(t)
+
+	// label_map で指定したラベルは付け替え、それ以外は drop で外す
+	var moved api.Task
+	s.do(t, http.MethodPost, path, map[string]interface{}{
+		"project_id":     target.Id,
+		"label_map":      map[string]int{fmt.Sprint(important.ID): outdoor.ID},
+		"missing_labels": "drop",
+	}).expect(t, http.StatusOK).decode(t, &moved)
+	if *moved.ProjectId != target.Id || len(moved.Labels) != 1 || moved.Labels[0].ID != outdoor.ID {
+		t.Errorf("moved task = %+v, want it in the target project with the outdoor label", moved)
+	}
+	if got := s.getTask(t, child); *got.ProjectId != target.Id {
+		t.Errorf("subtask project = %d, want %d", *got.ProjectId, target.Id)
+	}
+}
+
+func TestProjectRoles(t *testing.T) {
+	s := newAuthTestServer(t)
+	alicePair, alice := s.register(t, "alice")
+	bobPair, bob := s.register(t, "bob")
+	carolPair, _ := s.register(t, "carol")
+	s.join(t, alice.Id, api.ProjectRoleAdmin)
+	admin, viewer, outsider := s.as(alicePair.AccessToken), s.as(bobPair.AccessToken), s.as(carolPair.AccessToken)
+
+	membersPath := fmt.Sprintf("/projects/%d/members", store.DefaultProjectID)
+	admin.do(t, http.MethodPut, fmt.Sprintf("%s/%d", membersPath, bob.Id), map[string]string{"role": "viewer"}).expect(t, http.StatusOK)
+	id := admin.createTask(t, "task", nil)
+	taskPath := fmt.Sprintf("/tasks/%d", id)
+
+	// viewer は閲覧できるが変更できず、不足している権限とそれを持つ役割が返る
+	viewer.do(t, http.MethodGet, taskPath, nil).expect(t, http.StatusOK)
+	p := viewer.do(t, http.MethodPatch, taskPath, map[string]string{"status": "Completed"}).expect(t, http.StatusForbidden).problem(t)
+	if p.Permission == nil || p.Permission.Required != "tasks:write" || p.Permission.Role != "viewer" ||
+		fmt.Sprint(p.Permission.GrantedTo) != "[editor admin]" {
+		t.Errorf("permission = %+v, want tasks:write denied to a viewer", p.Permission)
+	}
+	viewer.do(t, http.MethodPost, "/labels", map[string]string{"name": "bug", "color": "#FF0000"}).expect(t, http.StatusForbidden).problem(t)
+	viewer.do(t, http.MethodPut, fmt.Sprintf("%s/%d", membersPath, bob.Id), map[string]string{"role": "admin"}).
+		expect(t, http.StatusForbidden).problem(t)
+
+	// メンバーでないプロジェクトのタスクは一覧に含まれず、存在しない場合と同じく404になる
+	var list struct {
+		Total int `json:"total"`
+	}
+	outsider.do(t, http.MethodGet, "/tasks", nil).expect(t, http.StatusOK).decode(t, &list)
+	if list.Total != 0 {
+		t.Errorf("outsider sees %d tasks, want 0", list.Total)
+	}
+	outsider.do(t, http.MethodGet, taskPath, nil).expect(t, http.StatusNotFound).problem(t)
+	outsider.do(t, http.MethodGet, "/tasks/999", nil).expect(t, http.StatusNotFound).problem(t)
+	labelsPath := fmt.Sprintf("/labels?project_id=%d", store.DefaultProjectID)
+	viewer.do(t, http.MethodGet, labelsPath, nil).expect(t, http.StatusOK)
+	outsider.do(t, http.MethodGet, labelsPath, nil).expect(t, http.StatusNotFound).problem(t)
+	p = outsider.do(t, http.MethodPost, "/tasks", map[string]string{"name": "x", "priority": "Low", "status": "NotStarted"}).expect(t, http.StatusNotFound).problem(t)
+	if p.Detail != "Project not found" {
+		t.Errorf("detail = %q, want the default project to be hidden", p.Detail)
+	}
+
+	// プロジェクトを作成したユーザーはその admin になり、他のユーザーからは見えない
+	var project api.Project
+	outsider.do(t, http.MethodPost, "/projects", map[string]string{"name": "carol's"}).expect(t, http.StatusCreated).decode(t, &project)
+	outsider.do(t, http.MethodPost, fmt.Sprintf("/projects/%d/archive", project.Id), nil).expect(t, http.StatusOK)
+	var projects projectList
+	admin.do(t, http.MethodGet, "/projects?archived=true", nil).expect(t, http.StatusOK).decode(t, &projects)
+	if len(projects.Projects) != 0 {
+		t.Errorf("alice sees %+v, want no archived projects", projects.Projects)
+	}
+
+	var members struct {
+		Members []api.ProjectMember `json:"members"`
+	}
+	viewer.do(t, http.MethodGet, membersPath, nil).expect(t, http.StatusOK).decode(t, &members)
+	if len(members.Members) != 2 || members.Members[0].Username != "alice" || members.Members[0].Role != api.ProjectRoleAdmin ||
+		members.Members[1].Username != "bob" || members.Members[1].Role != api.ProjectRoleViewer {
+		t.Errorf("members = %+v, want alice as admin and bob as viewer", members.Members)
+	}
+
+	// 最後の admin は外したり役割を変えたりできない
+	selfPath := fmt.Sprintf("%s/%d", membersPath, alice.Id)
+	admin.do(t, http.MethodPut, selfPath, map[string]string{"role": "editor"}).expect(t, http.StatusConflict).problem(t)
+	admin.do(t, http.MethodDelete, selfPath, nil).expect(t, http.StatusConflict).problem(t)
+	admin.do(t, http.MethodPut, fmt.Sprintf("%s/%d", membersPath, bob.Id), map[string]string{"role": "admin"}).expect(t, http.StatusOK)
+	admin.do(t, http.MethodDelete, selfPath, nil).expect(t, http.StatusNoContent)
+	admin.do(t, http.MethodGet, taskPath, nil).expect(t, http.StatusNotFound).problem(t)
+
+	admin.do(t, http.MethodPut, fmt.Sprintf("%s/%d", membersPath, 999), map[string]string{"role": "viewer"}).
+		expect(t, http.StatusNotFound).problem(t)
+	viewer.do(t, http.MethodPut, fmt.Sprintf("%s/%d", membersPath, 999), map[string]string{"role": "viewer"}).
+		expect(t, http.StatusNotFound).problem(t)
+	viewer.do(t, http.MethodPut, fmt.Sprintf("%s/%d", membersPath, alice.Id), map[string]string{"role": "owner"}).
+		expect(t, http.StatusBadRequest).problem(t)
+}
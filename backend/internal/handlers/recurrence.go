@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/authz"
+	"github.com/yuchi1128/task-management-system/backend/internal/problem"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+type RecurrenceHandler struct {
+	store store.RecurrenceStore
+	authz *authz.Authorizer
+}
+
+func NewRecurrenceHandler(recurrenceStore store.RecurrenceStore, authorizer *authz.Authorizer) *RecurrenceHandler {
+	return &RecurrenceHandler{store: recurrenceStore, authz: authorizer}
+}
+
+// GetRecurrence は繰り返しのタスクの次の繰り返しの日付を取得します
+func (h *RecurrenceHandler) GetRecurrence(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling GetRecurrence request")
+	pathParts := r.URL.Path[len("/tasks/"):]
+	id, err := strconv.Atoi(pathParts[:len(pathParts)-len("/recurrence")])
+	if err != nil {
+		log.Printf("Invalid task ID: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid task ID"))
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), id, authz.ReadProject); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to fetch recurrence")
+		return
+	}
+
+	p := filterParser{query: r.URL.Query()}
+	count := store.DefaultOccurrences
+	if n := p.int("count"); n != nil {
+		if *n < 1 || *n > store.MaxOccurrences {
+			p.fail("count", fmt.Sprintf("must be between 1 and %d", store.MaxOccurrences))
+		}
+		count = *n
+	}
+	if len(p.errors) > 0 {
+		problem.Write(w, r, problem.Validation(p.errors))
+		return
+	}
+
+	preview, err := h.store.PreviewRecurrence(r.Context(), id, count)
+	if err != nil {
+		log.Printf("Error fetching recurrence: %v", err)
+		writeStoreError(w, r, err, "Recurring task", "Failed to fetch recurrence")
+		return
+	}
+
+	log.Printf("Fetched %d occurrences of task %d", len(preview.Occurrences), id)
+	writeJSONWithETag(w, r, "", preview)
+}
+
+// SkipOccurrence は繰り返しのタスクを完了せずに次の繰り返しの日付に移します
+func (h *RecurrenceHandler) SkipOccurrence(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling SkipOccurrence request")
+	h.update(w, r, "/recurrence/skip", "skip occurrence", h.store.SkipOccurrence)
+}
+
+// EndRecurrence はタスクの繰り返しを終了します（タスク自体は残ります）
+func (h *RecurrenceHandler) EndRecurrence(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling EndRecurrence request")
+	h.update(w, r, "/recurrence/end", "end recurrence", h.store.EndRecurrence)
+}
+
+// 繰り返しのタスクを change で更新し、更新後のタスクを返す
+func (h *RecurrenceHandler) update(w http.ResponseWriter, r *http.Request, suffix, action string, change func(ctx context.Context, id int, version int) (api.Task, error)) {
+	pathParts := r.URL.Path[len("/tasks/"):]
+	id, err := strconv.Atoi(strings.TrimSuffix(pathParts, suffix))
+	if err != nil {
+		log.Printf("Invalid task ID: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid task ID"))
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), id, authz.WriteTasks); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to "+action)
+		return
+	}
+
+	var task api.Task
+	err = ifMatch(r, func(version int) (err error) {
+		task, err = change(r.Context(), id, version)
+		return err
+	})
+	if err != nil {
+		log.Printf("Error updating recurrence: %v", err)
+		writeStoreError(w, r, err, "Recurring task", "Failed to "+action)
+		return
+	}
+
+	log.Printf("Recurrence of task %d updated (%s)", id, action)
+	writeVersioned(w, *task.Version, task)
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+func TestRecurrenceHandler(t *testing.T) {
+	s := newTestServer(t)
+	id := s.createTask(t, "weekly report", map[string]interface{}{
+		"start_date":          "2024-01-01T09:00:00+09:00",
+		"end_date":            "2024-01-01T18:00:00+09:00",
+		"recurrence_rule":     "freq=weekly;byday=mo,th",
+		"recurrence_timezone": "Asia/Tokyo",
+	})
+	path := fmt.Sprintf("/tasks/%d/recurrence", id)
+	jst := time.FixedZone("JST", 9*60*60)
+
+	var preview api.RecurrencePreview
+	s.do(t, http.MethodGet, path+"?count=3", nil).expect(t, http.StatusOK).decode(t, &preview)
+	if preview.Rule != "FREQ=WEEKLY;BYDAY=MO,TH" || preview.Timezone != "Asia/Tokyo" || len(preview.Occurrences) != 3 {
+		t.Fatalf("preview = %+v, want 3 occurrences of the normalized rule", preview)
+	}
+	// 期間の長さを保ったまま移す
+	next := preview.Occurrences[0]
+	if !next.StartDate.Equal(time.Date(2024, 1, 4, 9, 0, 0, 0, jst)) || !next.EndDate.Equal(time.Date(2024, 1, 4, 18, 0, 0, 0, jst)) {
+		t.Errorf("next occurrence = %v - %v, want 2024-01-04 09:00-18:00 JST", next.StartDate, next.EndDate)
+	}
+
+	var task api.Task
+	s.do(t, http.MethodPost, path+"/skip", nil).expect(t, http.StatusOK).decode(t, &task)
+	if !task.StartDate.Equal(*next.StartDate) {
+		t.Errorf("start_date after skip = %v, want %v", task.StartDate, next.StartDate)
+	}
+
+	// 完了すると次の繰り返しのタスクを作る
+	s.do(t, http.MethodPatch, fmt.Sprintf("/tasks/%d", id), map[string]string{"status": "Completed"}).expect(t, http.StatusOK)
+	var list struct {
+		Tasks []api.Task `json:"tasks"`
+	}
+	s.do(t, http.MethodGet, "/tasks?status=NotStarted", nil).expect(t, http.StatusOK).decode(t, &list)
+	if len(list.Tasks) != 1 || list.Tasks[0].SeriesId == nil || *list.Tasks[0].SeriesId != id ||
+		!list.Tasks[0].StartDate.Equal(time.Date(2024, 1, 8, 9, 0, 0, 0, jst)) {
+		t.Fatalf("next task = %+v, want the 2024-01-08 occurrence of series %d", list.Tasks, id)
+	}
+	nextID := *list.Tasks[0].Id
+
+	var ended api.Task
+	s.do(t, http.MethodPost, fmt.Sprintf("/tasks/%d/recurrence/end", nextID), nil).expect(t, http.StatusOK).decode(t, &ended)
+	if ended.RecurrenceRule != nil {
+		t.Errorf("recurrence_rule after end = %q, want nil", *ended.RecurrenceRule)
+	}
+	s.do(t, http.MethodGet, fmt.Sprintf("/tasks/%d/recurrence", nextID), nil).expect(t, http.StatusNotFound).problem(t)
+}
+
+func TestRecurrenceHandlerRejectsInvalidRules(t *testing.T) {
+	s := newTestServer(t)
+	tests := []struct {
+		name   string
+		fields map[string]interface{}
+		field  string
+	}{
+		{"unsupported rule", map[string]interface{}{"start_date": "2024-01-01T00:00:00Z", "recurrence_rule": "FREQ=HOURLY"}, "recurrence_rule"},
+		{"no dates", map[string]interface{}{"recurrence_rule": "FREQ=DAILY"}, "recurrence_rule"},
+		{"unknown time zone", map[string]interface{}{"start_date": "2024-01-01T00:00:00Z", "recurrence_rule": "FREQ=DAILY", "recurrence_timezone": "Mars/Olympus"}, "recurrence_timezone"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := map[string]interface{}{"name": "task", "priority": "Middle", "status": "NotStarted"}
+			for k, v := range tt.fields {
+				input[k] = v
+			}
+			p := s.do(t, http.MethodPost, "/tasks", input).expect(t, http.StatusUnprocessableEntity).problem(t)
+			if len(p.Errors) == 0 || p.Errors[0].Field != tt.field {
+				t.Errorf("errors = %+v, want %s", p.Errors, tt.field)
+			}
+		})
+	}
+
+	id := s.createTask(t, "once", nil)
+	s.do(t, http.MethodPost, fmt.Sprintf("/tasks/%d/recurrence/skip", id), nil).expect(t, http.StatusNotFound).problem(t)
+	s.do(t, http.MethodGet, fmt.Sprintf("/tasks/%d/recurrence?count=0", id), nil).expect(t, http.StatusBadRequest).problem(t)
+}
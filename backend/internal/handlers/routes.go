@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/auth"
+	"github.com/yuchi1128/task-management-system/backend/internal/authz"
+	"github.com/yuchi1128/task-management-system/backend/internal/blob"
+	"github.com/yuchi1128/task-management-system/backend/internal/middleware"
+	"github.com/yuchi1128/task-management-system/backend/internal/problem"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+// Deps はハンドラーが使う依存関係です
+type Deps struct {
+	Store       store.Store
+	Blobs       blob.Store
+	Attachments AttachmentOptions
+	Issuer      *auth.Issuer
+	// AuthRequired が false の場合は、トークンのないリクエストを認証なしとして受け付けます
+	AuthRequired bool
+	BcryptCost   int
+}
+
+// Routes は API のルートを router に登録します
+// サーバーとテストは同じルーティングを使うため、ルートとミドルウェアはここでだけ定義します
+func Routes(router *mux.Router, deps Deps) error {
+	router.NotFoundHandler = problem.NotFoundHandler()
+	router.MethodNotAllowedHandler = problem.MethodNotAllowedHandler()
+
+	// アクセストークンを検証し、ユーザーをコンテキストに設定
+	router.Use(middleware.Authenticate(deps.Issuer, deps.Store, deps.AuthRequired, "/auth/register", "/auth/login", "/auth/refresh", "/auth/logout"))
+
+	// OpenAPI定義に沿ってリクエストを検証
+	swagger, err := api.GetSwagger()
+	if err != nil {
+		return err
+	}
+	taskDates := middleware.DateOrder("start_date", "end_date")
+	validator, err := middleware.OpenAPIValidator(swagger, map[string][]middleware.BodyRule{
+		"PostTasks":    {taskDates},
+		"PutTasksId":   {taskDates},
+		"PatchTasksId": {taskDates},
+	})
+	if err != nil {
+		return err
+	}
+	router.Use(validator)
+
+	// ユーザー登録とログイン
+	authHandler := NewAuthHandler(deps.Store, deps.Issuer, deps.BcryptCost)
+	router.HandleFunc("/auth/register", authHandler.Register).Methods("POST")
+	router.HandleFunc("/auth/login", authHandler.Login).Methods("POST")
+	router.HandleFunc("/auth/refresh", authHandler.Refresh).Methods("POST")
+	router.HandleFunc("/auth/logout", authHandler.Logout).Methods("POST")
+	router.HandleFunc("/me", authHandler.Me).Methods("GET")
+
+	// プロジェクトの役割による権限の判定
+	authorizer := authz.New(deps.Store)
+
+	taskHandler := NewTaskHandler(deps.Store, authorizer)
+	router.HandleFunc("/tasks", taskHandler.GetTasks).Methods("GET")
+	router.HandleFunc("/tasks", taskHandler.CreateTask).Methods("POST")
+	router.HandleFunc("/tasks/bulk", taskHandler.BulkTasks).Methods("POST")
+	router.HandleFunc("/tasks/{id:[0-9]+}", taskHandler.GetTask).Methods("GET")
+	router.HandleFunc("/tasks/{id:[0-9]+}", taskHandler.UpdateTask).Methods("PUT")
+	router.HandleFunc("/tasks/{id:[0-9]+}", taskHandler.PatchTask).Methods("PATCH")
+	router.HandleFunc("/tasks/{id:[0-9]+}", taskHandler.DeleteTask).Methods("DELETE")
+	router.HandleFunc("/tasks/{id:[0-9]+}/subtasks", taskHandler.GetSubtasks).Methods("GET")
+	router.HandleFunc("/tasks/{id:[0-9]+}/restore", taskHandler.RestoreTask).Methods("POST")
+	router.HandleFunc("/trash", taskHandler.GetTrash).Methods("GET")
+	router.HandleFunc("/me/tasks", taskHandler.GetMyTasks).Methods("GET")
+
+	// タスクの担当者
+	assigneeHandler := NewAssigneeHandler(deps.Store, authorizer)
+	router.HandleFunc("/tasks/{id:[0-9]+}/assignees", assigneeHandler.UpdateTaskAssignees).Methods("PUT")
+
+	labelHandler := NewLabelHandler(deps.Store, deps.Store, authorizer)
+	router.HandleFunc("/labels", labelHandler.GetLabels).Methods("GET")
+	router.HandleFunc("/labels", labelHandler.CreateLabel).Methods("POST")
+	router.HandleFunc("/labels/{id:[0-9]+}", labelHandler.GetLabel).Methods("GET")
+	router.HandleFunc("/labels/{id:[0-9]+}", labelHandler.UpdateLabel).Methods("PUT")
+	router.HandleFunc("/labels/{id:[0-9]+}", labelHandler.DeleteLabel).Methods("DELETE")
+	router.HandleFunc("/tasks/{id:[0-9]+}/labels", labelHandler.UpdateTaskLabels).Methods("PUT")
+
+	// プロジェクトとメンバー、プロジェクト間のタスクの移動
+	projectHandler := NewProjectHandler(deps.Store, deps.Store, authorizer, taskHandler, labelHandler)
+	router.HandleFunc("/projects", projectHandler.GetProjects).Methods("GET")
+	router.HandleFunc("/projects", projectHandler.CreateProject).Methods("POST")
+	router.HandleFunc("/projects/{pid:[0-9]+}", projectHandler.GetProject).Methods("GET")
+	router.HandleFunc("/projects/{pid:[0-9]+}", projectHandler.UpdateProject).Methods("PUT")
+	router.HandleFunc("/projects/{pid:[0-9]+}/archive", projectHandler.ArchiveProject).Methods("POST")
+	router.HandleFunc("/projects/{pid:[0-9]+}/unarchive", projectHandler.UnarchiveProject).Methods("POST")
+	router.HandleFunc("/projects/{pid:[0-9]+}/tasks", projectHandler.GetProjectTasks).Methods("GET")
+	router.HandleFunc("/projects/{pid:[0-9]+}/tasks", projectHandler.CreateProjectTask).Methods("POST")
+	router.HandleFunc("/projects/{pid:[0-9]+}/labels", projectHandler.GetProjectLabels).Methods("GET")
+	router.HandleFunc("/projects/{pid:[0-9]+}/labels", projectHandler.CreateProjectLabel).Methods("POST")
+	router.HandleFunc("/projects/{pid:[0-9]+}/members", projectHandler.GetProjectMembers).Methods("GET")
+	router.HandleFunc("/projects/{pid:[0-9]+}/members/{uid:[0-9]+}", projectHandler.SetProjectMember).Methods("PUT")
+	router.HandleFunc("/projects/{pid:[0-9]+}/members/{uid:[0-9]+}", projectHandler.RemoveProjectMember).Methods("DELETE")
+	router.HandleFunc("/tasks/{id:[0-9]+}/move", projectHandler.MoveTask).Methods("POST")
+
+	// タスクの依存関係
+	dependencyHandler := NewDependencyHandler(deps.Store, authorizer)
+	router.HandleFunc("/tasks/{id:[0-9]+}/dependencies", dependencyHandler.AddDependency).Methods("POST")
+	router.HandleFunc("/tasks/{id:[0-9]+}/dependencies/{dependsOnId:[0-9]+}", dependencyHandler.RemoveDependency).Methods("DELETE")
+
+	// 繰り返しタスク
+	recurrenceHandler := NewRecurrenceHandler(deps.Store, authorizer)
+	router.HandleFunc("/tasks/{id:[0-9]+}/recurrence", recurrenceHandler.GetRecurrence).Methods("GET")
+	router.HandleFunc("/tasks/{id:[0-9]+}/recurrence/skip", recurrenceHandler.SkipOccurrence).Methods("POST")
+	router.HandleFunc("/tasks/{id:[0-9]+}/recurrence/end", recurrenceHandler.EndRecurrence).Methods("POST")
+
+	// タスクのコメント
+	commentHandler := NewCommentHandler(deps.Store, authorizer)
+	router.HandleFunc("/tasks/{id:[0-9]+}/comments", commentHandler.GetComments).Methods("GET")
+	router.HandleFunc("/tasks/{id:[0-9]+}/comments", commentHandler.CreateComment).Methods("POST")
+	router.HandleFunc("/tasks/{id:[0-9]+}/comments/{commentId:[0-9]+}", commentHandler.UpdateComment).Methods("PUT")
+	router.HandleFunc("/tasks/{id:[0-9]+}/comments/{commentId:[0-9]+}", commentHandler.DeleteComment).Methods("DELETE")
+	router.HandleFunc("/tasks/{id:[0-9]+}/comments/{commentId:[0-9]+}/history", commentHandler.GetCommentHistory).Methods("GET")
+
+	// タスクの添付ファイル
+	attachmentHandler := NewAttachmentHandler(deps.Store, authorizer, deps.Blobs, deps.Attachments)
+	router.HandleFunc("/tasks/{id:[0-9]+}/attachments", attachmentHandler.GetAttachments).Methods("GET")
+	router.HandleFunc("/tasks/{id:[0-9]+}/attachments", attachmentHandler.UploadAttachment).Methods("POST")
+	router.HandleFunc("/tasks/{id:[0-9]+}/attachments/{attachmentId:[0-9]+}", attachmentHandler.GetAttachment).Methods("GET")
+	router.HandleFunc("/tasks/{id:[0-9]+}/attachments/{attachmentId:[0-9]+}", attachmentHandler.DeleteAttachment).Methods("DELETE")
+	router.HandleFunc("/tasks/{id:[0-9]+}/attachments/{attachmentId:[0-9]+}/content", attachmentHandler.DownloadAttachment).Methods("GET")
+
+	// 変更履歴
+	auditHandler := NewAuditHandler(deps.Store, authorizer)
+	router.HandleFunc("/tasks/{id:[0-9]+}/history", auditHandler.GetTaskHistory).Methods("GET")
+	router.HandleFunc("/audit", auditHandler.GetAudit).Methods("GET")
+	return nil
+}
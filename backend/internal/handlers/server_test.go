@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/auth"
+	"github.com/yuchi1128/task-management-system/backend/internal/blob"
+	"github.com/yuchi1128/task-management-system/backend/internal/middleware"
+	"github.com/yuchi1128/task-management-system/backend/internal/problem"
+	"github.com/yuchi1128/task-management-system/backend/internal/requestid"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+func TestMain(m *testing.M) {
+	// ハンドラーのログはテストの結果に関係しないため出力しない
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// testServer は MemoryStore に接続した、cmd/main.go と同じルーティングのテスト用サーバーです
+type testServer struct {
+	*httptest.Server
+	store *store.MemoryStore
+	// token を設定すると、リクエストに Bearer トークンとして付けます
+	token string
+}
+
+// newTestServer は既定のオプションでテスト用サーバーを起動します
+// トークンのないリクエストは認証なしとして受け付けます
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	return newTestServerWithOptions(t, store.Options{})
+}
+
+// newTestServerWithOptions はストアのオプションを指定してテスト用サーバーを起動します
+func newTestServerWithOptions(t *testing.T, opts store.Options) *testServer {
+	t.Helper()
+	return startTestServer(t, opts, false)
+}
+
+// newAuthTestServer は認証を必須にしたテスト用サーバーを起動します
+func newAuthTestServer(t *testing.T) *testServer {
+	t.Helper()
+	return startTestServer(t, store.Options{}, true)
+}
+
+func startTestServer(t *testing.T, opts store.Options, authRequired bool) *testServer {
+	t.Helper()
+	dataStore := store.NewMemoryStore(opts)
+	blobStore, err := blob.NewFS(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFS: %v", err)
+	}
+	router := mux.NewRouter()
+	deps := Deps{
+		Store:        dataStore,
+		Blobs:        blobStore,
+		Attachments:  AttachmentOptions{MaxSize: 1 << 20},
+		Issuer:       auth.NewIssuer([]byte(strings.Repeat("s", auth.MinSecretLength)), 15*time.Minute, time.Hour),
+		AuthRequired: authRequired,
+		// bcrypt のコストはテストが遅くならないよう最小にする
+		BcryptCost: 4,
+	}
+	if err := Routes(router, deps); err != nil {
+		t.Fatalf("Routes: %v", err)
+	}
+
+	server := httptest.NewServer(requestid.Middleware(router))
+	t.Cleanup(server.Close)
+	return &testServer{Server: server, store: dataStore}
+}
+
+// testResponse は読み込み済みのレスポンスです
+type testResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// do はリクエストを送信します。body が string の場合はそのまま、それ以外は JSON にして送ります
+// header には名前と値を交互に指定します
+func (s *testServer) do(t *testing.T, method, path string, body interface{}, header ...string) testResponse {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		data, ok := body.(string)
+		if !ok {
+			encoded, err := json.Marshal(body)
+			if err != nil {
+				t.Fatalf("marshal request body: %v", err)
+			}
+			data = string(encoded)
+		}
+		reader = strings.NewReader(data)
+	}
+	req, err := http.NewRequest(method, s.URL+path, reader)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	if body != nil {
+		contentType := "application/json"
+		if method == http.MethodPatch {
+			contentType = middleware.MergePatchContentType
+		}
+		req.Header.Set("Content-Type", contentType)
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+
+	resp, err := s.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read response of %s %s: %v", method, path, err)
+	}
+	return testResponse{StatusCode: resp.StatusCode, Header: resp.Header, Body: data}
+}
+
+// expect はステータスコードを確認します
+func (r testResponse) expect(t *testing.T, status int) testResponse {
+	t.Helper()
+	if r.StatusCode != status {
+		t.Fatalf("status = %d, want %d: %s", r.StatusCode, status, bytes.TrimSpace(r.Body))
+	}
+	return r
+}
+
+// decode はボディを JSON として v に読み込みます
+func (r testResponse) decode(t *testing.T, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(r.Body, v); err != nil {
+		t.Fatalf("decode %s: %v", r.Body, err)
+	}
+}
+
+// problem はボディを problem+json として読み込みます
+func (r testResponse) problem(t *testing.T) problem.Problem {
+	t.Helper()
+	if got := r.Header.Get("Content-Type"); got != problem.ContentType {
+		t.Fatalf("Content-Type = %q, want %q", got, problem.ContentType)
+	}
+	var p problem.Problem
+	r.decode(t, &p)
+	return p
+}
+
+// createTask はタスクを作成してIDを返します。fields は既定の入力に上書きする項目です
+func (s *testServer) createTask(t *testing.T, name string, fields map[string]interface{}) int {
+	t.Helper()
+	input := map[string]interface{}{"name": name, "priority": "Middle", "status": "NotStarted"}
+	for k, v := range fields {
+		input[k] = v
+	}
+	var created struct {
+		ID int `json:"id"`
+	}
+	s.do(t, http.MethodPost, "/tasks", input).expect(t, http.StatusCreated).decode(t, &created)
+	return created.ID
+}
+
+// getTask はタスクを取得します
+func (s *testServer) getTask(t *testing.T, id int) api.Task {
+	t.Helper()
+	var task api.Task
+	s.do(t, http.MethodGet, fmt.Sprintf("/tasks/%d", id), nil).expect(t, http.StatusOK).decode(t, &task)
+	return task
+}
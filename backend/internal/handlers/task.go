@@ -2,147 +2,129 @@ package handlers
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"strconv"
-	"time"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/authz"
+	"github.com/yuchi1128/task-management-system/backend/internal/problem"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
 )
 
-type TaskEntity struct {
-	ID          int       `db:"id"`
-	Name        string    `db:"name"`
-	Description string    `db:"description"`
-	StartDate   time.Time `db:"start_date"`
-	EndDate     time.Time `db:"end_date"`
-	Priority    string    `db:"priority"`
-	Status      string    `db:"status"`
-	CreatedAt   time.Time `db:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at"`
-}
-
-func (e TaskEntity) ToAPITask() api.Task {
-	id := int(e.ID)
-	return api.Task{
-		Id:          &id,
-		Name:        &e.Name,
-		Description: &e.Description,
-		StartDate:   &e.StartDate,
-		EndDate:     &e.EndDate,
-		Priority:    (*api.TaskPriority)(&e.Priority),
-		Status:      (*api.TaskStatus)(&e.Status),
-		CreatedAt:   &e.CreatedAt,
-		UpdatedAt:   &e.UpdatedAt,
-	}
-}
-
 type TaskHandler struct {
-	db *sqlx.DB
+	store store.TaskStore
+	authz *authz.Authorizer
 }
 
-func NewTaskHandler(db *sqlx.DB) *TaskHandler {
-	return &TaskHandler{db: db}
+func NewTaskHandler(taskStore store.TaskStore, authorizer *authz.Authorizer) *TaskHandler {
+	return &TaskHandler{store: taskStore, authz: authorizer}
 }
 
 // タスク一覧を取得
 func (h *TaskHandler) GetTasks(w http.ResponseWriter, r *http.Request) {
 	log.Println("Handling GetTasks request")
 	query := r.URL.Query()
-	status := query.Get("status")
-	name := query.Get("name")
-	description := query.Get("description")
-	page, _ := strconv.Atoi(query.Get("page"))
-	if page < 1 {
-		page = 1
+	filter, fieldErrors := parseTaskFilter(r.Context(), query)
+	if len(fieldErrors) > 0 {
+		problem.Write(w, r, problem.Validation(fieldErrors))
+		return
 	}
-	limit := 1000
-	offset := (page - 1) * limit
+	h.listTasks(w, r, filter)
+}
 
-	sqlQuery := "SELECT * FROM tasks WHERE 1=1"
-	var args []interface{}
-	if status != "" {
-		sqlQuery += fmt.Sprintf(" AND status = $%d", len(args)+1)
-		args = append(args, status)
-	}
-	if name != "" {
-		sqlQuery += fmt.Sprintf(" AND name ILIKE $%d", len(args)+1)
-		args = append(args, "%"+name+"%")
-	}
-	if description != "" {
-		sqlQuery += fmt.Sprintf(" AND description ILIKE $%d", len(args)+1)
-		args = append(args, "%"+description+"%")
-	}
-	sqlQuery += fmt.Sprintf(" ORDER BY priority, end_date LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
-	args = append(args, limit, offset)
+// GetTrash はゴミ箱のタスクを最近削除したものから順に取得します
+func (h *TaskHandler) GetTrash(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling GetTrash request")
+	h.listTasks(w, r, store.TaskFilter{Trashed: true})
+}
 
-	log.Printf("Executing query: %s with args: %v", sqlQuery, args)
+// ページングと並び順のパラメータを読み取り、タスク一覧のページを返す
+func (h *TaskHandler) listTasks(w http.ResponseWriter, r *http.Request, filter store.TaskFilter) {
+	query := r.URL.Query()
+	// 閲覧できるプロジェクトのタスクに絞り込む
+	filter.Member = h.authz.Scope(r.Context(), authz.ReadProject)
+	page, pageSize := parsePaging(query)
+	taskQuery := store.TaskQuery{
+		Filter: filter,
+		Sort:   query.Get("sort"),
+		Page:   page,
+		Limit:  pageSize,
+		Cursor: query.Get("cursor"),
+	}
 
-	var taskEntities []TaskEntity
-	err := h.db.Select(&taskEntities, sqlQuery, args...)
+	result, err := h.store.ListTasks(r.Context(), taskQuery)
 	if err != nil {
 		log.Printf("Error fetching tasks: %v", err)
-		http.Error(w, "Failed to fetch tasks", http.StatusInternalServerError)
+		writeStoreError(w, r, err, "Task", "Failed to fetch tasks")
 		return
 	}
 
-	var tasks []api.Task
-	for _, entity := range taskEntities {
-		task := entity.ToAPITask()
-
-		var labels []api.Label
-		query := `
-			SELECT l.* FROM labels l
-			JOIN task_labels tl ON l.id = tl.label_id
-			WHERE tl.task_id = $1
-			ORDER BY l.name
-		`
-		err := h.db.Select(&labels, query, *task.Id)
-		if err != nil {
-			log.Printf("Error fetching labels for task %d: %v", *task.Id, err)
-		} else {
-			task.Labels = labels
-		}
-
-		tasks = append(tasks, task)
-	}
-
-	log.Printf("Fetched %d tasks", len(tasks))
+	log.Printf("Fetched %d of %d tasks", len(result.Tasks), result.Total)
 	response := struct {
-		Tasks []api.Task `json:"tasks"`
-		Total int        `json:"total"`
+		Tasks      []api.Task `json:"tasks"`
+		Total      int        `json:"total"`
+		Page       int        `json:"page"`
+		PageSize   int        `json:"page_size"`
+		NextCursor *string    `json:"next_cursor"`
 	}{
-		Tasks: tasks,
-		Total: len(tasks),
+		Tasks:    result.Tasks,
+		Total:    result.Total,
+		Page:     page,
+		PageSize: taskQuery.PageSize(),
+	}
+	if result.NextCursor != "" {
+		response.NextCursor = &result.NextCursor
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	writeJSONWithETag(w, r, "", response)
 }
 
-// タスクを作成
+// parsePaging はページ番号とページサイズを読み取ります（page_size の別名として limit も受け付ける）
+// ページサイズの既定値と上限はストアで適用します
+func parsePaging(query url.Values) (page, pageSize int) {
+	page, _ = strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	pageSizeParam := query.Get("page_size")
+	if pageSizeParam == "" {
+		pageSizeParam = query.Get("limit")
+	}
+	pageSize, _ = strconv.Atoi(pageSizeParam)
+	return page, pageSize
+}
+
+// タスクを作成（親タスクがあれば親のプロジェクト、なければ既定のプロジェクトに作成する）
 func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 	log.Println("Handling CreateTask request")
+	h.createTask(w, r, 0, "Task")
+}
+
+// projectID のプロジェクトにタスクを作成する（0 の場合はストアが決める）
+// resource は404の説明に使うリソース名です
+func (h *TaskHandler) createTask(w http.ResponseWriter, r *http.Request, projectID int, resource string) {
 	var input api.TaskInput
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		log.Printf("Error decoding request body: %v", err)
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid request body"))
 		return
 	}
 
-	log.Printf("Creating task: %+v", input)
+	if err := h.authz.NewTask(r.Context(), projectID, input.ParentId); err != nil {
+		log.Printf("Error authorizing task creation: %v", err)
+		// メンバーでない場合は、作成先のプロジェクトが見つからないものとして扱う
+		writeStoreError(w, r, err, "Project", "Failed to create task")
+		return
+	}
 
-	var taskID int
-	err := h.db.QueryRow(
-		"INSERT INTO tasks (name, description, start_date, end_date, priority, status) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id",
-		input.Name, input.Description, input.StartDate, input.EndDate, input.Priority, input.Status,
-	).Scan(&taskID)
+	log.Printf("Creating task: %+v", input)
 
+	taskID, err := h.store.CreateTask(r.Context(), projectID, input)
 	if err != nil {
 		log.Printf("Error creating task: %v", err)
-		http.Error(w, "Failed to create task", http.StatusInternalServerError)
+		writeStoreError(w, r, err, resource, "Failed to create task")
 		return
 	}
 
@@ -155,42 +137,31 @@ func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// タスクのラベルを更新
+// IDのタスクを取得します
 func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 	log.Println("Handling GetTask request")
 	idStr := r.URL.Path[len("/tasks/"):]
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
 		log.Printf("Invalid task ID: %v", err)
-		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid task ID"))
 		return
 	}
 
-	var taskEntity TaskEntity
-	err = h.db.Get(&taskEntity, "SELECT * FROM tasks WHERE id = $1", id)
-	if err != nil {
-		log.Printf("Error fetching task: %v", err)
-		http.Error(w, "Task not found", http.StatusNotFound)
+	if err := h.authz.Task(r.Context(), id, authz.ReadProject); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to fetch task")
 		return
 	}
 
-	task := taskEntity.ToAPITask()
-
-	// タスク取得後、ラベル情報も取得
-	var labels []api.Label
-	query := `
-        SELECT l.* FROM labels l
-        JOIN task_labels tl ON l.id = tl.label_id
-        WHERE tl.task_id = $1
-        ORDER BY l.name
-    `
-	err = h.db.Select(&labels, query, id)
-	if err == nil {
-		task.Labels = labels
+	task, err := h.store.GetTask(r.Context(), id)
+	if err != nil {
+		log.Printf("Error fetching task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to fetch task")
+		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(task)
+	writeJSONWithETag(w, r, versionETag(*task.Version), task)
 }
 
 // UpdateTask は指定したIDのタスクを更新します
@@ -200,49 +171,230 @@ func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
 		log.Printf("Invalid task ID: %v", err)
-		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid task ID"))
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), id, authz.WriteTasks); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to update task")
 		return
 	}
 
 	var input api.TaskInput
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		log.Printf("Error decoding request body: %v", err)
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid request body"))
 		return
 	}
 
-	_, err = h.db.Exec(
-		"UPDATE tasks SET name = $1, description = $2, start_date = $3, end_date = $4, priority = $5, status = $6, updated_at = CURRENT_TIMESTAMP WHERE id = $7",
-		input.Name, input.Description, input.StartDate, input.EndDate, input.Priority, input.Status, id,
-	)
+	err = ifMatch(r, func(version int) error {
+		return h.store.UpdateTask(r.Context(), id, input, version)
+	})
 	if err != nil {
 		log.Printf("Error updating task: %v", err)
-		http.Error(w, "Failed to update task", http.StatusInternalServerError)
+		writeStoreError(w, r, err, "Task", "Failed to update task")
 		return
 	}
 
 	log.Println("Task updated successfully")
-	w.WriteHeader(http.StatusOK)
+	task, err := h.store.GetTask(r.Context(), id)
+	if err != nil {
+		log.Printf("Error fetching task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to fetch task")
+		return
+	}
+	writeVersioned(w, *task.Version, task)
+}
+
+// PatchTask は指定したIDのタスクを JSON Merge Patch（RFC 7396）で部分更新します
+// 指定したフィールドだけを更新し、null を指定した任意項目は空にします
+func (h *TaskHandler) PatchTask(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling PatchTask request")
+	idStr := r.URL.Path[len("/tasks/"):]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		log.Printf("Invalid task ID: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid task ID"))
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), id, authz.WriteTasks); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to update task")
+		return
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+		log.Printf("Error decoding request body: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid request body"))
+		return
+	}
+	patch, fieldErrors := decodeTaskPatch(fields)
+	if len(fieldErrors) > 0 {
+		problem.Write(w, r, problem.Validation(fieldErrors))
+		return
+	}
+
+	var task api.Task
+	err = ifMatch(r, func(version int) (err error) {
+		task, err = h.store.PatchTask(r.Context(), id, patch, version)
+		return err
+	})
+	if err != nil {
+		log.Printf("Error patching task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to update task")
+		return
+	}
+
+	log.Println("Task patched successfully")
+	writeVersioned(w, *task.Version, task)
+}
+
+// GetSubtasks は直下のサブタスクを一覧と同じ絞り込み・ページングで取得します
+// tree=true の場合は、タスク自身をゴミ箱にない子孫すべてを children に入れたツリーとして返します
+func (h *TaskHandler) GetSubtasks(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling GetSubtasks request")
+	pathParts := r.URL.Path[len("/tasks/"):]
+	id, err := strconv.Atoi(pathParts[:len(pathParts)-len("/subtasks")])
+	if err != nil {
+		log.Printf("Invalid task ID: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid task ID"))
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), id, authz.ReadProject); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to fetch subtasks")
+		return
+	}
+
+	query := r.URL.Query()
+	if tree, _ := strconv.ParseBool(query.Get("tree")); tree {
+		task, err := h.store.TaskTree(r.Context(), id)
+		if err != nil {
+			log.Printf("Error fetching task tree: %v", err)
+			writeStoreError(w, r, err, "Task", "Failed to fetch subtasks")
+			return
+		}
+		writeJSONWithETag(w, r, "", task)
+		return
+	}
+
+	filter, fieldErrors := parseTaskFilter(r.Context(), query)
+	if len(fieldErrors) > 0 {
+		problem.Write(w, r, problem.Validation(fieldErrors))
+		return
+	}
+	// 親が存在しない場合は空の一覧ではなく404にする
+	if _, err := h.store.GetTask(r.Context(), id); err != nil {
+		log.Printf("Error fetching task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to fetch subtasks")
+		return
+	}
+	// サブタスクは親と同じプロジェクトにあるので、アーカイブしたプロジェクトでも返す
+	filter.ParentID = &id
+	filter.IncludeArchived = true
+	h.listTasks(w, r, filter)
 }
 
-// タスクを削除
+// RestoreTask はゴミ箱のタスクをラベルの関連付けごと元に戻します
+func (h *TaskHandler) RestoreTask(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling RestoreTask request")
+	pathParts := r.URL.Path[len("/tasks/"):]
+	id, err := strconv.Atoi(pathParts[:len(pathParts)-len("/restore")])
+	if err != nil {
+		log.Printf("Invalid task ID: %v", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid task ID"))
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), id, authz.WriteTasks); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task in trash", "Failed to restore task")
+		return
+	}
+
+	var task api.Task
+	err = ifMatch(r, func(version int) (err error) {
+		task, err = h.store.RestoreTask(r.Context(), id, version)
+		return err
+	})
+	if err != nil {
+		log.Printf("Error restoring task: %v", err)
+		writeStoreError(w, r, err, "Task in trash", "Failed to restore task")
+		return
+	}
+
+	log.Println("Task restored successfully")
+	w.Header().Set("ETag", versionETag(*task.Version))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(task)
+}
+
+// DeleteTask はタスクをゴミ箱に移動します（保持期間を過ぎると完全に削除されます）
 func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	log.Println("Handling DeleteTask request")
 	idStr := r.URL.Path[len("/tasks/"):]
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
 		log.Printf("Invalid task ID: %v", err)
-		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Invalid task ID"))
+		return
+	}
+
+	if err := h.authz.Task(r.Context(), id, authz.WriteTasks); err != nil {
+		log.Printf("Error authorizing task: %v", err)
+		writeStoreError(w, r, err, "Task", "Failed to delete task")
 		return
 	}
 
-	_, err = h.db.Exec("DELETE FROM tasks WHERE id = $1", id)
+	err = ifMatch(r, func(version int) error {
+		return h.store.DeleteTask(r.Context(), id, version)
+	})
 	if err != nil {
 		log.Printf("Error deleting task: %v", err)
-		http.Error(w, "Failed to delete task", http.StatusInternalServerError)
+		writeStoreError(w, r, err, "Task", "Failed to delete task")
 		return
 	}
 
 	log.Println("Task deleted successfully")
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// Merge Patch のメンバーを部分更新の内容に変換
+// 必須項目に null を指定した場合と、型が合わない場合はエラーにします
+func decodeTaskPatch(fields map[string]json.RawMessage) (store.TaskPatch, []problem.FieldError) {
+	var patch store.TaskPatch
+	var fieldErrors []problem.FieldError
+	decode := func(name string, dst interface{}) bool {
+		raw, ok := fields[name]
+		if !ok {
+			return false
+		}
+		if err := json.Unmarshal(raw, dst); err != nil {
+			fieldErrors = append(fieldErrors, problem.FieldError{Field: name, Location: "body", Message: err.Error()})
+			return false
+		}
+		return true
+	}
+	required := func(name string) *string {
+		var v *string
+		if decode(name, &v) && v == nil {
+			fieldErrors = append(fieldErrors, problem.FieldError{Field: name, Location: "body", Message: name + " cannot be null"})
+		}
+		return v
+	}
+
+	patch.Name = required("name")
+	patch.Priority = required("priority")
+	patch.Status = required("status")
+	patch.Description.Set = decode("description", &patch.Description.Value)
+	patch.StartDate.Set = decode("start_date", &patch.StartDate.Value)
+	patch.EndDate.Set = decode("end_date", &patch.EndDate.Value)
+	patch.ParentID.Set = decode("parent_id", &patch.ParentID.Value)
+	patch.RecurrenceRule.Set = decode("recurrence_rule", &patch.RecurrenceRule.Value)
+	patch.RecurrenceTimezone.Set = decode("recurrence_timezone", &patch.RecurrenceTimezone.Value)
+	return patch, fieldErrors
+}
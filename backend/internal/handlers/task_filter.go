@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/internal/auth"
+	"github.com/yuchi1128/task-management-system/backend/internal/problem"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+// taskFilterParams は parseTaskFilter が読み取るパラメータです
+var taskFilterParams = []string{
+	"status", "priority", "q", "name", "description", "label_ids", "label_match", "has_labels",
+	"assignee", "unassigned",
+	"start_date_from", "start_date_to", "end_date_from", "end_date_to",
+	"created_at_from", "created_at_to", "updated_at_from", "updated_at_to", "overdue", "parent_id",
+	"project_id", "include_archived",
+}
+
+// parseTaskFilter はクエリパラメータからタスクの絞り込み条件を作成します
+// 複数の値はカンマ区切りでも、同じパラメータの繰り返しでも指定できます
+// assignee の me は ctx のログインしているユーザーに置き換えます
+func parseTaskFilter(ctx context.Context, query url.Values) (store.TaskFilter, []problem.FieldError) {
+	p := filterParser{query: query}
+	filter := store.TaskFilter{
+		Statuses:    p.list("status"),
+		Priorities:  p.list("priority"),
+		Query:       query.Get("q"),
+		Name:        query.Get("name"),
+		Description: query.Get("description"),
+		LabelIDs:    p.ints("label_ids"),
+		LabelMatch:  query.Get("label_match"),
+		HasLabels:   p.bool("has_labels"),
+		Assignees:   p.assignees(ctx, "assignee"),
+		Unassigned:  p.bool("unassigned"),
+		StartDate:   p.timeRange("start_date"),
+		EndDate:     p.timeRange("end_date"),
+		CreatedAt:   p.timeRange("created_at"),
+		UpdatedAt:   p.timeRange("updated_at"),
+		Overdue:     p.bool("overdue"),
+		ParentID:    p.int("parent_id"),
+		ProjectID:   p.int("project_id"),
+	}
+	if includeArchived := p.bool("include_archived"); includeArchived != nil {
+		filter.IncludeArchived = *includeArchived
+	}
+	switch filter.LabelMatch {
+	case "":
+		filter.LabelMatch = store.LabelMatchAny
+	case store.LabelMatchAny, store.LabelMatchAll:
+	default:
+		p.fail("label_match", "must be one of any, all")
+	}
+	return filter, p.errors
+}
+
+// filterParser は変換に失敗したパラメータをエラーとして記録しながら値を取り出します
+type filterParser struct {
+	query  url.Values
+	errors []problem.FieldError
+}
+
+func (p *filterParser) fail(name, message string) {
+	p.errors = append(p.errors, problem.FieldError{Field: name, Location: "query", Message: message})
+}
+
+func (p *filterParser) list(name string) []string {
+	var values []string
+	for _, raw := range p.query[name] {
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+// assignees はユーザー名の一覧を返します（me はログインしているユーザー、ユーザー名は小文字にそろえる）
+func (p *filterParser) assignees(ctx context.Context, name string) []string {
+	var usernames []string
+	for _, v := range p.list(name) {
+		if v != "me" {
+			usernames = append(usernames, strings.ToLower(v))
+			continue
+		}
+		user, ok := auth.FromContext(ctx)
+		if !ok {
+			p.fail(name, "me requires authentication")
+			return nil
+		}
+		usernames = append(usernames, user.Username)
+	}
+	return usernames
+}
+
+func (p *filterParser) ints(name string) []int {
+	var values []int
+	for _, v := range p.list(name) {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			p.fail(name, "must be a comma-separated list of integers")
+			return nil
+		}
+		values = append(values, n)
+	}
+	return values
+}
+
+func (p *filterParser) int(name string) *int {
+	raw := p.query.Get(name)
+	if raw == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		p.fail(name, "must be an integer")
+		return nil
+	}
+	return &n
+}
+
+func (p *filterParser) bool(name string) *bool {
+	raw := p.query.Get(name)
+	if raw == "" {
+		return nil
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		p.fail(name, "must be true or false")
+		return nil
+	}
+	return &v
+}
+
+func (p *filterParser) time(name string) *time.Time {
+	raw := p.query.Get(name)
+	if raw == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		p.fail(name, "must be an RFC 3339 date-time")
+		return nil
+	}
+	return &t
+}
+
+// timeRange は <name>_from と <name>_to から日時の範囲を作成します
+func (p *filterParser) timeRange(name string) store.TimeRange {
+	return store.TimeRange{
+		From: p.time(name + "_from"),
+		To:   p.time(name + "_to"),
+	}
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+func TestParseTaskFilter(t *testing.T) {
+	query, err := url.ParseQuery("status=NotStarted,InProgress&status=Completed&priority=High&label_ids=1,2&label_ids=3" +
+		"&label_match=all&has_labels=false&end_date_from=2024-01-01T00:00:00Z&overdue=true")
+	if err != nil {
+		t.Fatalf("ParseQuery: %v", err)
+	}
+	filter, fieldErrors := parseTaskFilter(context.Background(), query)
+	if len(fieldErrors) > 0 {
+		t.Fatalf("field errors = %+v", fieldErrors)
+	}
+	if got := fmt.Sprint(filter.Statuses); got != "[NotStarted InProgress Completed]" {
+		t.Errorf("Statuses = %s", got)
+	}
+	if got := fmt.Sprint(filter.LabelIDs); got != "[1 2 3]" || filter.LabelMatch != store.LabelMatchAll {
+		t.Errorf("LabelIDs = %s, LabelMatch = %q", got, filter.LabelMatch)
+	}
+	if filter.HasLabels == nil || *filter.HasLabels || filter.Overdue == nil || !*filter.Overdue {
+		t.Errorf("HasLabels = %v, Overdue = %v", filter.HasLabels, filter.Overdue)
+	}
+	if filter.EndDate.From == nil || filter.EndDate.To != nil || filter.StartDate.From != nil {
+		t.Errorf("EndDate = %+v, StartDate = %+v", filter.EndDate, filter.StartDate)
+	}
+
+	// 指定しない場合はいずれかのラベルに一致する
+	if filter, _ := parseTaskFilter(context.Background(), url.Values{}); filter.LabelMatch != store.LabelMatchAny {
+		t.Errorf("default LabelMatch = %q, want %q", filter.LabelMatch, store.LabelMatchAny)
+	}
+}
+
+func TestParseTaskFilterErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"label_ids=1,x", "[label_ids]"},
+		{"label_match=some", "[label_match]"},
+		{"has_labels=maybe&overdue=1", "[has_labels]"},
+		{"end_date_to=2024-01-01", "[end_date_to]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery: %v", err)
+			}
+			_, fieldErrors := parseTaskFilter(context.Background(), query)
+			var fields []string
+			for _, e := range fieldErrors {
+				if e.Location != "query" {
+					t.Errorf("%s: location = %q, want query", e.Field, e.Location)
+				}
+				fields = append(fields, e.Field)
+			}
+			if got := fmt.Sprint(fields); got != tt.want {
+				t.Errorf("fields = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+func TestTaskHandlerCRUD(t *testing.T) {
+	s := newTestServer(t)
+	id := s.createTask(t, "write report", map[string]interface{}{"description": "quarterly"})
+
+	path := fmt.Sprintf("/tasks/%d", id)
+	s.do(t, http.MethodPut, path, map[string]interface{}{
+		"name": "write annual report", "priority": "High", "status": "InProgress",
+	}).expect(t, http.StatusOK)
+	task := s.getTask(t, id)
+	if *task.Name != "write annual report" || *task.Priority != api.TaskPriorityHigh || *task.Status != api.TaskStatusInProgress {
+		t.Fatalf("updated task = %+v", task)
+	}
+
+	var list struct {
+		Tasks []api.Task `json:"tasks"`
+		Total int        `json:"total"`
+	}
+	s.do(t, http.MethodGet, "/tasks?status=InProgress", nil).expect(t, http.StatusOK).decode(t, &list)
+	if list.Total != 1 || *list.Tasks[0].Id != id {
+		t.Fatalf("GET /tasks?status=InProgress = %+v, want the updated task", list)
+	}
+
+	s.do(t, http.MethodDelete, path, nil).expect(t, http.StatusNoContent)
+	p := s.do(t, http.MethodGet, path, nil).expect(t, http.StatusNotFound).problem(t)
+	if p.Code != "not_found" {
+		t.Errorf("code = %q, want not_found", p.Code)
+	}
+}
+
+func TestTaskHandlerRejectsInvalidInput(t *testing.T) {
+	s := newTestServer(t)
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   interface{}
+		status int
+	}{
+		{"missing name", http.MethodPost, "/tasks", map[string]interface{}{"priority": "High", "status": "NotStarted"}, http.StatusBadRequest},
+		{"unknown priority", http.MethodPost, "/tasks", map[string]interface{}{"name": "x", "priority": "Urgent", "status": "NotStarted"}, http.StatusBadRequest},
+		{"malformed body", http.MethodPost, "/tasks", `{"name":`, http.StatusBadRequest},
+		{"unknown sort key", http.MethodGet, "/tasks?sort=owner", nil, http.StatusBadRequest},
+		{"missing task", http.MethodGet, "/tasks/999", nil, http.StatusNotFound},
+		{"update missing task", http.MethodPut, "/tasks/999", map[string]interface{}{"name": "x", "priority": "High", "status": "NotStarted"}, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.do(t, tt.method, tt.path, tt.body).expect(t, tt.status).problem(t)
+		})
+	}
+}
+
+func TestTaskHandlerPaging(t *testing.T) {
+	s := newTestServer(t)
+	for i := 0; i < 5; i++ {
+		s.createTask(t, fmt.Sprintf("task %d", i), nil)
+	}
+
+	type taskList struct {
+		Tasks      []api.Task `json:"tasks"`
+		Total      int        `json:"total"`
+		Page       int        `json:"page"`
+		PageSize   int        `json:"page_size"`
+		NextCursor *string    `json:"next_cursor"`
+	}
+	var list taskList
+	s.do(t, http.MethodGet, "/tasks?page=2&page_size=2", nil).expect(t, http.StatusOK).decode(t, &list)
+	if list.Total != 5 || list.Page != 2 || list.PageSize != 2 || len(list.Tasks) != 2 || *list.Tasks[0].Name != "task 2" {
+		t.Fatalf("page 2 = %+v, want task 2 and 3 of 5", list)
+	}
+
+	// limit は page_size の別名で、next_cursor をたどると全件を1回ずつ読める
+	var names []string
+	path := "/tasks?limit=2"
+	for {
+		var page taskList
+		s.do(t, http.MethodGet, path, nil).expect(t, http.StatusOK).decode(t, &page)
+		for _, task := range page.Tasks {
+			names = append(names, *task.Name)
+		}
+		if page.NextCursor == nil {
+			break
+		}
+		path = "/tasks?limit=2&cursor=" + *page.NextCursor
+	}
+	if fmt.Sprint(names) != "[task 0 task 1 task 2 task 3 task 4]" {
+		t.Errorf("tasks read through cursors = %v", names)
+	}
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"page size above maximum", "/tasks?page_size=1001"},
+		{"page size zero", "/tasks?limit=0"},
+		{"invalid cursor", "/tasks?cursor=invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.do(t, http.MethodGet, tt.path, nil).expect(t, http.StatusBadRequest).problem(t)
+		})
+	}
+}
+
+func TestTaskHandlerPatch(t *testing.T) {
+	s := newTestServer(t)
+	id := s.createTask(t, "write report", map[string]interface{}{
+		"description": "quarterly",
+		"start_date":  "2024-01-01T00:00:00Z",
+		"end_date":    "2024-01-31T00:00:00Z",
+	})
+	path := fmt.Sprintf("/tasks/%d", id)
+
+	// 指定した項目だけを変更し、更新後のタスクを返す
+	var patched api.Task
+	s.do(t, http.MethodPatch, path, map[string]interface{}{"status": "Completed"}).expect(t, http.StatusOK).decode(t, &patched)
+	if *patched.Status != api.TaskStatusCompleted || *patched.Name != "write report" ||
+		patched.Description == nil || *patched.Description != "quarterly" || patched.EndDate == nil {
+		t.Fatalf("patched task = %+v, want only the status changed", patched)
+	}
+
+	// null を指定した任意項目は空になる
+	var cleared api.Task
+	s.do(t, http.MethodPatch, path, `{"description":null,"end_date":null}`).expect(t, http.StatusOK).decode(t, &cleared)
+	if cleared.Description != nil || cleared.EndDate != nil || cleared.StartDate == nil {
+		t.Errorf("task after null patch = %+v, want description and end_date cleared", cleared)
+	}
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		field  string
+	}{
+		{"required field null", `{"name":null}`, http.StatusBadRequest, "name"},
+		{"wrong type", `{"priority":1}`, http.StatusBadRequest, "priority"},
+		// 片方の日付だけを変更しても、更新後の値で前後関係を確認する
+		{"end before stored start", `{"end_date":"2023-12-31T00:00:00Z"}`, http.StatusUnprocessableEntity, "end_date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := s.do(t, http.MethodPatch, path, tt.body).expect(t, tt.status).problem(t)
+			if len(p.Errors) == 0 || p.Errors[0].Field != tt.field {
+				t.Errorf("errors = %+v, want %s", p.Errors, tt.field)
+			}
+		})
+	}
+	if task := s.getTask(t, id); *task.Name != "write report" || task.EndDate != nil {
+		t.Errorf("task after rejected patches = %+v, want unchanged", task)
+	}
+
+	// PUT は全体の置き換えなので必須項目を省略できない
+	s.do(t, http.MethodPut, path, map[string]interface{}{"status": "Completed"}).expect(t, http.StatusBadRequest).problem(t)
+}
+
+func TestTaskHandlerTrash(t *testing.T) {
+	s := newTestServer(t)
+	id := s.createTask(t, "write report", nil)
+	other := s.createTask(t, "review", nil)
+	path := fmt.Sprintf("/tasks/%d", id)
+	label := labelByName(t, s, "仕事")
+	s.do(t, http.MethodPut, path+"/labels", map[string]interface{}{"label_ids": []int{label.ID}}).expect(t, http.StatusOK)
+
+	type taskList struct {
+		Tasks []api.Task `json:"tasks"`
+		Total int        `json:"total"`
+	}
+	s.do(t, http.MethodDelete, path, nil).expect(t, http.StatusNoContent)
+	var list taskList
+	s.do(t, http.MethodGet, "/tasks", nil).expect(t, http.StatusOK).decode(t, &list)
+	if list.Total != 1 || *list.Tasks[0].Id != other {
+		t.Fatalf("GET /tasks = %+v, want only the task not in the trash", list)
+	}
+	s.do(t, http.MethodGet, "/trash", nil).expect(t, http.StatusOK).decode(t, &list)
+	if list.Total != 1 || *list.Tasks[0].Id != id || list.Tasks[0].DeletedAt == nil {
+		t.Fatalf("GET /trash = %+v, want the deleted task", list)
+	}
+
+	// 元に戻すとラベルの関連付けも戻る
+	var restored api.Task
+	s.do(t, http.MethodPost, path+"/restore", nil).expect(t, http.StatusOK).decode(t, &restored)
+	if restored.DeletedAt != nil || len(restored.Labels) != 1 || restored.Labels[0].ID != label.ID {
+		t.Errorf("restored task = %+v, want it out of the trash with its label", restored)
+	}
+	s.do(t, http.MethodGet, "/trash", nil).expect(t, http.StatusOK).decode(t, &list)
+	if list.Total != 0 {
+		t.Errorf("GET /trash after restore = %+v, want empty", list)
+	}
+	// ゴミ箱にないタスクは元に戻せない
+	s.do(t, http.MethodPost, path+"/restore", nil).expect(t, http.StatusNotFound).problem(t)
+
+	// 完全に削除したタスクは元に戻せない
+	s.do(t, http.MethodDelete, path, nil).expect(t, http.StatusNoContent)
+	if _, err := s.store.PurgeTasks(context.Background(), time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("PurgeTasks: %v", err)
+	}
+	s.do(t, http.MethodPost, path+"/restore", nil).expect(t, http.StatusNotFound).problem(t)
+}
+
+func TestTaskHandlerSubtasks(t *testing.T) {
+	s := newTestServer(t)
+	parent := s.createTask(t, "epic", nil)
+	child := s.createTask(t, "story", map[string]interface{}{"parent_id": parent, "status": "Completed"})
+	s.createTask(t, "design", map[string]interface{}{"parent_id": parent})
+	s.createTask(t, "subtask of story", map[string]interface{}{"parent_id": child})
+
+	var list struct {
+		Tasks []api.Task `json:"tasks"`
+		Total int        `json:"total"`
+	}
+	path := fmt.Sprintf("/tasks/%d/subtasks", parent)
+	s.do(t, http.MethodGet, path+"?status=Completed", nil).expect(t, http.StatusOK).decode(t, &list)
+	if list.Total != 1 || *list.Tasks[0].Id != child {
+		t.Fatalf("completed subtasks = %+v, want only the story", list)
+	}
+	if rollup := s.getTask(t, parent).Subtasks; rollup == nil || rollup.Total != 2 || rollup.CompletionPercentage != 50 {
+		t.Errorf("rollup = %+v, want 1 of 2 completed", rollup)
+	}
+
+	var tree api.Task
+	s.do(t, http.MethodGet, path+"?tree=true", nil).expect(t, http.StatusOK).decode(t, &tree)
+	if *tree.Id != parent || len(tree.Children) != 2 {
+		t.Fatalf("tree = %+v, want the epic with 2 children", tree)
+	}
+	for _, c := range tree.Children {
+		if *c.Id == child && len(c.Children) != 1 {
+			t.Errorf("story children = %+v, want 1 subtask", c.Children)
+		}
+	}
+
+	// 自分のサブタスクを親にはできない
+	p := s.do(t, http.MethodPatch, fmt.Sprintf("/tasks/%d", parent), map[string]interface{}{"parent_id": child}).
+		expect(t, http.StatusUnprocessableEntity).problem(t)
+	if len(p.Errors) == 0 || p.Errors[0].Field != "parent_id" {
+		t.Errorf("errors = %+v, want parent_id", p.Errors)
+	}
+	s.do(t, http.MethodGet, "/tasks/999/subtasks", nil).expect(t, http.StatusNotFound).problem(t)
+}
@@ -0,0 +1,238 @@
+// Package markdown はユーザーが入力した Markdown を、クライアントで描画しても安全な形に整えます
+//
+// 生の HTML はタグとして解釈されないよう実体参照にし、リンクと画像の URL は
+// http, https, mailto と相対 URL だけを残します（それ以外は "#" に置き換えます）。
+// コードブロックとコードスパンの中は描画時にエスケープされるため変更しません
+package markdown
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// safeSchemes はリンクに使ってよい URL スキームです
+var safeSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+// Sanitize は Markdown から生の HTML と危険なリンクを取り除き、改行を LF にそろえて前後の空白を除きます
+func Sanitize(src string) string {
+	src = strings.ToValidUTF8(src, "�")
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\r", "\n")
+	src = strings.Map(func(r rune) rune {
+		if r != '\n' && r != '\t' && unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, src)
+
+	// コードブロック以外の連続した行はまとめて無害化する
+	// リンクの URL は行をまたいで次の行から始められるため、1行ずつでは見落とす
+	lines := strings.Split(src, "\n")
+	out := make([]string, 0, len(lines))
+	var text []string
+	flush := func() {
+		if len(text) > 0 {
+			out = append(out, sanitizeText(strings.Join(text, "\n")))
+			text = text[:0]
+		}
+	}
+	var fence string
+	indentedCode := false
+	prevBlank := true
+	for _, line := range lines {
+		blank := strings.TrimSpace(line) == ""
+		switch {
+		case fence != "":
+			// 開始と同じ文字で同じ長さ以上のフェンスで閉じる
+			if marker := fenceMarker(line); marker != "" && marker[0] == fence[0] && len(marker) >= len(fence) &&
+				strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), marker[:1])) == "" {
+				fence = ""
+			}
+			out = append(out, line)
+		case fenceMarker(line) != "":
+			flush()
+			fence = fenceMarker(line)
+			out = append(out, line)
+		case isIndentedCode(line) && (prevBlank || indentedCode):
+			flush()
+			indentedCode = true
+			out = append(out, line)
+		default:
+			indentedCode = indentedCode && blank
+			text = append(text, line)
+		}
+		prevBlank = blank
+	}
+	flush()
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+// fenceMarker はコードブロックのフェンス（3文字以上の ` か ~）で始まる行ならフェンスを返します
+func fenceMarker(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 || len(trimmed) < 3 {
+		return ""
+	}
+	c := trimmed[0]
+	if c != '`' && c != '~' {
+		return ""
+	}
+	n := len(trimmed) - len(strings.TrimLeft(trimmed, string(c)))
+	if n < 3 || (c == '`' && strings.Contains(trimmed[n:], "`")) {
+		return ""
+	}
+	return trimmed[:n]
+}
+
+// isIndentedCode は4文字以上の空白かタブで始まる行かを判定します
+func isIndentedCode(line string) bool {
+	return strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")
+}
+
+// sanitizeText はコードブロック以外の行（改行を含む）を無害化します
+func sanitizeText(text string) string {
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		switch c := text[i]; {
+		case c == '\\' && i+1 < len(text):
+			// バックスラッシュでエスケープした文字はそのまま
+			b.WriteString(text[i : i+2])
+			i += 2
+		case c == '`':
+			// コードスパンは同じ長さのバッククォートで閉じるまでそのまま
+			// 行をまたぐコードスパンは安全側に倒してコードとして扱わない
+			n := len(text[i:]) - len(strings.TrimLeft(text[i:], "`"))
+			ticks := text[i : i+n]
+			line := text[i+n:]
+			if eol := strings.IndexByte(line, '\n'); eol >= 0 {
+				line = line[:eol]
+			}
+			if end := strings.Index(line, ticks); end >= 0 {
+				end += i + n + n
+				b.WriteString(text[i:end])
+				i = end
+			} else {
+				b.WriteString(ticks)
+				i += n
+			}
+		case c == '<':
+			if end := autolinkEnd(text[i:]); end > 0 {
+				b.WriteString(text[i : i+end])
+				i += end
+			} else {
+				if i+1 < len(text) && isTagStart(text[i+1]) {
+					b.WriteString("&lt;")
+				} else {
+					b.WriteByte(c)
+				}
+				i++
+			}
+		case c == ']' && (strings.HasPrefix(text[i:], "](") || strings.HasPrefix(text[i:], "]:")):
+			// インラインのリンクと画像 [text](url "title") と参照定義 [label]: url "title"
+			// ラベルも URL の前も改行をまたげるため、行頭に限らずすべて確かめる
+			b.WriteString(text[i : i+2])
+			i += 2
+			lead := destinationLead(text[i:])
+			b.WriteString(text[i : i+lead])
+			i += lead
+			end := destinationEnd(text[i:])
+			b.WriteString(safeURL(text[i : i+end]))
+			i += end
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+// isTagStart は < の次の文字が HTML のタグ、コメント、処理命令の始まりかを判定します
+func isTagStart(c byte) bool {
+	return c == '/' || c == '!' || c == '?' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// autolinkEnd は s が安全なスキームの自動リンク <https://...> で始まる場合にその長さを返します
+func autolinkEnd(s string) int {
+	end := strings.IndexByte(s, '>')
+	if end < 0 {
+		return 0
+	}
+	url := s[1:end]
+	if strings.ContainsAny(url, " \t\n<") {
+		return 0
+	}
+	scheme, ok := urlScheme(url)
+	if !ok || !safeSchemes[scheme] {
+		return 0
+	}
+	return end + 1
+}
+
+// destinationLead はリンクの URL の前にある空白（改行は1つまで）の長さを返します
+func destinationLead(s string) int {
+	i := len(s) - len(strings.TrimLeft(s, " \t"))
+	if i < len(s) && s[i] == '\n' {
+		i++
+		i += len(s[i:]) - len(strings.TrimLeft(s[i:], " \t"))
+	}
+	return i
+}
+
+// destinationEnd はリンクの URL の長さを返します
+// <...> で囲まれていれば > まで、そうでなければ空白か対応しない ) の手前までです
+func destinationEnd(s string) int {
+	if strings.HasPrefix(s, "<") {
+		if end := strings.IndexAny(s[1:], "<>\n"); end >= 0 && s[1+end] == '>' {
+			return end + 2
+		}
+		// 閉じていない < は URL ではなくタグとして扱われうるので呼び出し側でエスケープする
+		return 0
+	}
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\':
+			i++
+		case c == '(':
+			depth++
+		case c == ')':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		case c == ' ' || c == '\t' || c == '\n' || c < 0x20:
+			return i
+		}
+	}
+	return len(s)
+}
+
+// safeURL はリンクの URL が安全でなければ "#" に置き換えます
+func safeURL(dest string) string {
+	trimmed := strings.TrimSpace(dest)
+	url := strings.TrimSuffix(strings.TrimPrefix(trimmed, "<"), ">")
+	// 描画時と同じくバックスラッシュのエスケープと文字参照を展開してから判定する
+	url = html.UnescapeString(strings.ReplaceAll(url, "\\", ""))
+	scheme, ok := urlScheme(url)
+	if !ok || safeSchemes[scheme] {
+		return dest
+	}
+	return "#"
+}
+
+// urlScheme は URL のスキームを小文字で返します（相対 URL では false）
+// ブラウザと同じく、スキームの前後の空白と制御文字は無視します
+func urlScheme(url string) (string, bool) {
+	url = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, url)
+	colon := strings.IndexByte(url, ':')
+	if colon < 1 || strings.ContainsAny(url[:colon], "/?#") {
+		return "", false
+	}
+	return strings.ToLower(url[:colon]), true
+}
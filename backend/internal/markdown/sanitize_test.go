@@ -0,0 +1,50 @@
+package markdown
+
+import "testing"
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain text", "hello **world**", "hello **world**"},
+		{"crlf and trim", "  a\r\nb\rc  ", "a\nb\nc"},
+		{"control chars", "a\x00b\x1bc", "abc"},
+		{"raw html", "<script>alert(1)</script>", "&lt;script>alert(1)&lt;/script>"},
+		{"comparison kept", "a < b", "a < b"},
+		{"safe link", "[a](https://example.com)", "[a](https://example.com)"},
+		{"relative link", "[a](/tasks/1 \"title\")", "[a](/tasks/1 \"title\")"},
+		{"mailto link", "[a](mailto:x@example.com)", "[a](mailto:x@example.com)"},
+		{"javascript link", "[a](javascript:alert(1))", "[a](#)"},
+		{"javascript image", "![a](JavaScript:alert(1))", "![a](#)"},
+		{"data link with title", "[a](data:text/html,x \"t\")", "[a](# \"t\")"},
+		{"bracketed destination", "[a](<javascript:alert(1)>)", "[a](#)"},
+		{"entity encoded scheme", "[a](&#106;avascript:alert(1))", "[a](#)"},
+		{"escaped scheme", "[a](java\\script:alert(1))", "[a](#)"},
+		{"destination on next line", "[a](\njavascript:alert(1))", "[a](\n#)"},
+		{"indented destination on next line", "[a](  \n    javascript:alert(1))", "[a](  \n    #)"},
+		{"destination after blank line", "[a](\n\njavascript:alert(1))", "[a](\n\njavascript:alert(1))"},
+		{"reference definition", "[a]: javascript:alert(1)\n\n[a]", "[a]: #\n\n[a]"},
+		{"reference definition on next line", "[a]:\n  javascript:alert(1)\n\n[a]", "[a]:\n  #\n\n[a]"},
+		{"reference label across lines", "[a\nb]: javascript:alert(1)\n\n[a b]", "[a\nb]: #\n\n[a b]"},
+		{"safe reference definition", "[a]: https://example.com \"t\"", "[a]: https://example.com \"t\""},
+		{"bracketed relative destination", "[a](<script>)", "[a](<script>)"},
+		{"unclosed bracketed destination", "[a](<img src=x onerror=alert(1)", "[a](&lt;img src=x onerror=alert(1)"},
+		{"safe autolink", "<https://example.com>", "<https://example.com>"},
+		{"unsafe autolink", "<javascript:alert(1)>", "&lt;javascript:alert(1)>"},
+		{"code span", "`<b>` and `[a](javascript:x)`", "`<b>` and `[a](javascript:x)`"},
+		{"code span across lines", "`a\n<b>`", "`a\n&lt;b>`"},
+		{"fenced code", "```\n<b>\n[a](javascript:x)\n```\n<b>", "```\n<b>\n[a](javascript:x)\n```\n&lt;b>"},
+		{"indented code", "text\n\n    <b>\n\n<b>", "text\n\n    <b>\n\n&lt;b>"},
+		{"paragraph continuation is not code", "text\n    <b>", "text\n    &lt;b>"},
+		{"escaped bracket", "\\](javascript:x)", "\\](javascript:x)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sanitize(tt.in); got != tt.want {
+				t.Errorf("Sanitize(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/yuchi1128/task-management-system/backend/internal/auth"
+	"github.com/yuchi1128/task-management-system/backend/internal/problem"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+// UserGetter はトークンのユーザーが削除されていないことを確認するために使います
+type UserGetter interface {
+	GetUser(ctx context.Context, id int) (store.UserEntity, error)
+}
+
+// Authenticate は Authorization ヘッダーのアクセストークンを検証し、ユーザーをコンテキストに設定するミドルウェアを返します
+// ユーザー名は変更履歴や作成者・更新者として記録されます
+// required が true の場合、public 以外のパスへのトークンのないリクエストを401で拒否します
+// 不正なトークンや期限切れのトークン、削除されたユーザーのトークンは required に関係なく拒否します
+// public のパス（登録やログイン）ではトークンを検証しません
+func Authenticate(issuer *auth.Issuer, users UserGetter, required bool, public ...string) mux.MiddlewareFunc {
+	publicPaths := make(map[string]bool, len(public))
+	for _, path := range public {
+		publicPaths[path] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// CORS のプリフライトと public のパスは認証しない
+			if r.Method == http.MethodOptions || publicPaths[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token, ok := bearerToken(r)
+			if !ok {
+				if required {
+					unauthorized(w, r, "", "Authentication required")
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			claims, err := issuer.Verify(token, auth.AccessToken)
+			if errors.Is(err, auth.ErrTokenExpired) {
+				unauthorized(w, r, "invalid_token", "Access token has expired")
+				return
+			}
+			if err != nil {
+				log.Printf("Invalid access token: %v", err)
+				unauthorized(w, r, "invalid_token", "Invalid access token")
+				return
+			}
+
+			// トークンの有効期限内でも、削除されたユーザーは受け付けない
+			entity, err := users.GetUser(r.Context(), claims.User().ID)
+			if errors.Is(err, store.ErrNotFound) {
+				unauthorized(w, r, "invalid_token", "User no longer exists")
+				return
+			}
+			if err != nil {
+				log.Printf("Error fetching user: %v", err)
+				problem.Write(w, r, problem.New(http.StatusInternalServerError, problem.CodeInternal, "Failed to authenticate"))
+				return
+			}
+
+			user := auth.User{ID: entity.ID, Username: entity.Username}
+			ctx := auth.NewContext(r.Context(), user)
+			ctx = store.WithActor(ctx, user.Username)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Authorization ヘッダーから Bearer トークンを取り出す
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// 401 を書き込む（RFC 6750 の WWW-Authenticate ヘッダーを付ける）
+func unauthorized(w http.ResponseWriter, r *http.Request, code, detail string) {
+	challenge := `Bearer realm="api"`
+	if code != "" {
+		challenge += `, error="` + code + `"`
+	}
+	w.Header().Set("WWW-Authenticate", challenge)
+	problem.Write(w, r, problem.New(http.StatusUnauthorized, problem.CodeUnauthorized, detail))
+}
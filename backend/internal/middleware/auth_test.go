@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/internal/auth"
+	"github.com/yuchi1128/task-management-system/backend/internal/store"
+)
+
+// testUsers は ID からユーザーを引くテスト用の UserGetter です
+type testUsers map[int]store.UserEntity
+
+func (u testUsers) GetUser(ctx context.Context, id int) (store.UserEntity, error) {
+	user, ok := u[id]
+	if !ok {
+		return store.UserEntity{}, store.ErrNotFound
+	}
+	return user, nil
+}
+
+func TestAuthenticate(t *testing.T) {
+	issuer := auth.NewIssuer([]byte(strings.Repeat("s", auth.MinSecretLength)), time.Minute, time.Hour)
+	sign := func(user auth.User) string {
+		token, _, err := issuer.Sign(user, auth.AccessToken)
+		if err != nil {
+			t.Fatalf("Sign: %v", err)
+		}
+		return token
+	}
+	alice := sign(auth.User{ID: 1, Username: "alice"})
+	deleted := sign(auth.User{ID: 2, Username: "bob"})
+
+	users := testUsers{1: {ID: 1, Username: "alice"}}
+	handler := Authenticate(issuer, users, true, "/auth/login")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _ := auth.FromContext(r.Context())
+		w.Write([]byte(user.Username))
+	}))
+
+	tests := []struct {
+		name       string
+		path       string
+		token      string
+		wantStatus int
+		wantUser   string
+	}{
+		{"valid token", "/tasks", alice, http.StatusOK, "alice"},
+		{"missing token", "/tasks", "", http.StatusUnauthorized, ""},
+		{"invalid token", "/tasks", "invalid", http.StatusUnauthorized, ""},
+		// トークンの有効期限内でも、削除されたユーザーは拒否する
+		{"deleted user", "/tasks", deleted, http.StatusUnauthorized, ""},
+		// public のパスではトークンを検証しない
+		{"public path with invalid token", "/auth/login", "invalid", http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus == http.StatusOK && rec.Body.String() != tt.wantUser {
+				t.Errorf("user = %q, want %q", rec.Body, tt.wantUser)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gorilla/mux"
+	"github.com/yuchi1128/task-management-system/backend/internal/problem"
+)
+
+// MergePatchContentType は JSON Merge Patch（RFC 7396）のメディアタイプです
+const MergePatchContentType = "application/merge-patch+json"
+
+func init() {
+	// kin-openapi は既定では Merge Patch のボディを読めないため、JSONとして検証させる
+	if openapi3filter.RegisteredBodyDecoder(MergePatchContentType) == nil {
+		openapi3filter.RegisterBodyDecoder(MergePatchContentType, openapi3filter.JSONBodyDecoder)
+	}
+}
+
+// BodyRule はOpenAPIでは表現できない項目間の制約をリクエストボディに対して検証します
+type BodyRule func(body map[string]interface{}) []problem.FieldError
+
+// OpenAPIValidator はOpenAPI定義に沿ってパス・クエリ・ボディを検証するミドルウェアを返します
+// rules にはoperationIdごとの追加の検証ルールを指定します
+func OpenAPIValidator(swagger *openapi3.T, rules map[string][]BodyRule) (mux.MiddlewareFunc, error) {
+	// サーバーURLに関係なくパスだけでルートを判定する
+	swagger.Servers = nil
+	router, err := gorillamux.NewRouter(swagger)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &openapi3filter.Options{
+		MultiError:         true,
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+	// multipart のボディ（添付ファイルのアップロード）は全体をメモリに読み込まないよう検証せず、ハンドラーに任せる
+	multipartOptions := *options
+	multipartOptions.ExcludeRequestBody = true
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				// 定義にないルートの扱いはルーターに任せる
+				if !errors.Is(err, routers.ErrPathNotFound) && !errors.Is(err, routers.ErrMethodNotAllowed) {
+					log.Printf("Error finding OpenAPI route: %v", err)
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			}
+			if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+				input.Options = &multipartOptions
+			}
+			var fieldErrors []problem.FieldError
+			if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+				fieldErrors = collectFieldErrors(err)
+			}
+			// スキーマ違反があっても項目間の制約を検証し、すべての違反をまとめて返す
+			fieldErrors = append(fieldErrors, applyBodyRules(r, rules[route.Operation.OperationID])...)
+			if len(fieldErrors) > 0 {
+				log.Printf("Request validation failed: %+v", fieldErrors)
+				problem.Write(w, r, problem.Validation(fieldErrors))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+// DateOrder は before の日時が after の日時より後にならないことを検証するルールです
+func DateOrder(before, after string) BodyRule {
+	return func(body map[string]interface{}) []problem.FieldError {
+		start, ok1 := body[before].(string)
+		end, ok2 := body[after].(string)
+		if !ok1 || !ok2 {
+			return nil
+		}
+		startTime, err1 := time.Parse(time.RFC3339, start)
+		endTime, err2 := time.Parse(time.RFC3339, end)
+		if err1 != nil || err2 != nil || !endTime.Before(startTime) {
+			return nil
+		}
+		return []problem.FieldError{{
+			Field:    after,
+			Location: "body",
+			Message:  after + " must not be before " + before,
+		}}
+	}
+}
+
+// kin-openapiのエラーを項目ごとのエラーに変換
+func collectFieldErrors(err error) []problem.FieldError {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var result []problem.FieldError
+		for _, inner := range e {
+			result = append(result, collectFieldErrors(inner)...)
+		}
+		return result
+	case *openapi3filter.RequestError:
+		location, field := "body", ""
+		if e.Parameter != nil {
+			location, field = e.Parameter.In, e.Parameter.Name
+		}
+		if inner, ok := e.Err.(openapi3.MultiError); ok {
+			var result []problem.FieldError
+			for _, ie := range inner {
+				result = append(result, schemaFieldError(location, field, ie))
+			}
+			return result
+		}
+		if e.Err != nil {
+			return []problem.FieldError{schemaFieldError(location, field, e.Err)}
+		}
+		return []problem.FieldError{{Field: field, Location: location, Message: e.Reason}}
+	default:
+		return []problem.FieldError{{Location: "request", Message: err.Error()}}
+	}
+}
+
+// スキーマ違反をJSONポインタ付きの項目エラーに変換
+func schemaFieldError(location, field string, err error) problem.FieldError {
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		path := schemaErr.JSONPointer()
+		if field != "" {
+			path = append([]string{field}, path...)
+		}
+		return problem.FieldError{
+			Field:    strings.Join(path, "."),
+			Location: location,
+			Message:  schemaErr.Reason,
+		}
+	}
+	var parseErr *openapi3filter.ParseError
+	if errors.As(err, &parseErr) {
+		message := parseErr.Reason
+		if message == "" {
+			message = parseErr.Error()
+		}
+		return problem.FieldError{Field: field, Location: location, Message: message}
+	}
+	return problem.FieldError{Field: field, Location: location, Message: err.Error()}
+}
+
+// 追加の検証ルールを適用（ボディは後続のハンドラー用に読み直せるよう戻しておく）
+func applyBodyRules(r *http.Request, rules []BodyRule) []problem.FieldError {
+	if len(rules) == 0 || r.Body == nil {
+		return nil
+	}
+	data, err := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil
+	}
+	var result []problem.FieldError
+	for _, rule := range rules {
+		result = append(result, rule(body)...)
+	}
+	return result
+}
@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/yuchi1128/task-management-system/backend/internal/problem"
+)
+
+const testSpec = `
+openapi: 3.0.0
+info:
+  title: test
+  version: "1"
+paths:
+  /tasks:
+    post:
+      operationId: createTask
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+                  minLength: 1
+                start_date:
+                  type: string
+                  format: date-time
+                end_date:
+                  type: string
+                  format: date-time
+      responses:
+        "201":
+          description: created
+`
+
+// newTestValidator はテスト用の定義で検証し、通過したリクエストに 201 を返すハンドラーを作成します
+func newTestValidator(t *testing.T) http.Handler {
+	t.Helper()
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(testSpec))
+	if err != nil {
+		t.Fatalf("LoadFromData: %v", err)
+	}
+	validator, err := OpenAPIValidator(swagger, map[string][]BodyRule{
+		"createTask": {DateOrder("start_date", "end_date")},
+	})
+	if err != nil {
+		t.Fatalf("OpenAPIValidator: %v", err)
+	}
+	return validator(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+}
+
+func TestOpenAPIValidator(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantFields []string
+	}{
+		{
+			name:       "valid",
+			body:       `{"name":"task","start_date":"2024-01-01T00:00:00Z","end_date":"2024-01-02T00:00:00Z"}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "dates out of order",
+			body:       `{"name":"task","start_date":"2024-01-02T00:00:00Z","end_date":"2024-01-01T00:00:00Z"}`,
+			wantStatus: http.StatusBadRequest,
+			wantFields: []string{"end_date"},
+		},
+		{
+			// スキーマ違反と項目間の制約の違反をまとめて返す
+			name:       "schema and date order errors",
+			body:       `{"name":"","start_date":"2024-01-02T00:00:00Z","end_date":"2024-01-01T00:00:00Z"}`,
+			wantStatus: http.StatusBadRequest,
+			wantFields: []string{"name", "end_date"},
+		},
+	}
+	handler := newTestValidator(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if len(tt.wantFields) == 0 {
+				return
+			}
+			var p problem.Problem
+			if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+				t.Fatalf("decode problem: %v", err)
+			}
+			var fields []string
+			for _, e := range p.Errors {
+				fields = append(fields, e.Field)
+			}
+			if strings.Join(fields, ",") != strings.Join(tt.wantFields, ",") {
+				t.Errorf("error fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package migrate
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+//go:embed migrations/*.sql
+var embedded embed.FS
+
+// lockKey は pg_advisory_lock に渡すキーです
+// 複数のサーバーが同時に起動してもマイグレーションが一度だけ適用されるようにします
+const lockKey int64 = 0x7461736b6d6967 // "taskmig"
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration は番号付きのスキーマ変更1件を表します
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status はマイグレーションの適用状況です
+type Status struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// Migrator はschema_migrationsテーブルを使ってマイグレーションを管理します
+type Migrator struct {
+	db         *sqlx.DB
+	migrations []Migration
+}
+
+// New はバイナリに埋め込まれたマイグレーションを読み込んだMigratorを返します
+func New(db *sqlx.DB) (*Migrator, error) {
+	sub, err := fs.Sub(embedded, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := Load(sub)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Load は NNNN_name.up.sql / NNNN_name.down.sql 形式のファイルを読み込みます
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		m := fileNamePattern.FindStringSubmatch(entry.Name())
+		if m == nil {
+			continue
+		}
+		version, _ := strconv.Atoi(m[1])
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		} else if mig.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+
+	var migrations []Migration
+	for _, mig := range byVersion {
+		if mig.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", mig.Version, mig.Name)
+		}
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Latest は最新のマイグレーション番号を返します
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up は未適用のマイグレーションを全て適用します
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.Latest())
+}
+
+// Down は最後に適用したマイグレーションを1件だけ取り消します
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			if _, ok := applied[m.migrations[i].Version]; ok {
+				return revert(ctx, conn, m.migrations[i])
+			}
+		}
+		log.Println("No migrations to revert")
+		return nil
+	})
+}
+
+// To は指定したバージョンまでマイグレーションを適用または取り消します
+func (m *Migrator) To(ctx context.Context, target int) error {
+	if target < 0 || target > m.Latest() {
+		return fmt.Errorf("unknown migration version: %d", target)
+	}
+
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		// 新しいバージョンから順に取り消す
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; ok && mig.Version > target {
+				if err := revert(ctx, conn, mig); err != nil {
+					return err
+				}
+			}
+		}
+
+		// 古いバージョンから順に適用する
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; !ok && mig.Version <= target {
+				if err := apply(ctx, conn, mig); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Status は各マイグレーションの適用状況を返します
+// 状況の確認だけなのでロックは取らず、schema_migrationsがなければ全て未適用として返します
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var exists bool
+	if err := m.db.GetContext(ctx, &exists, "SELECT to_regclass('schema_migrations') IS NOT NULL"); err != nil {
+		return nil, err
+	}
+	applied := make(map[int]time.Time)
+	if exists {
+		var err error
+		if applied, err = appliedVersions(ctx, m.db); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		status := Status{Version: mig.Version, Name: mig.Name}
+		if at, ok := applied[mig.Version]; ok {
+			status.AppliedAt = &at
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// advisory lockを取得した専用コネクション上でfnを実行する
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey); err != nil {
+			log.Printf("Error releasing migration lock: %v", err)
+		}
+	}()
+
+	_, err = conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// 適用済みのバージョンと適用日時を取得
+func appliedVersions(ctx context.Context, q sqlx.QueryerContext) (map[int]time.Time, error) {
+	var rows []struct {
+		Version   int       `db:"version"`
+		AppliedAt time.Time `db:"applied_at"`
+	}
+	if err := sqlx.SelectContext(ctx, q, &rows, "SELECT version, applied_at FROM schema_migrations"); err != nil {
+		return nil, err
+	}
+	applied := make(map[int]time.Time, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row.AppliedAt
+	}
+	return applied, nil
+}
+
+// マイグレーションを1件適用
+func apply(ctx context.Context, conn *sqlx.Conn, mig Migration) error {
+	log.Printf("Applying migration %04d_%s", mig.Version, mig.Name)
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, mig.Up); err != nil {
+		return fmt.Errorf("apply migration %04d_%s: %w", mig.Version, mig.Name, err)
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", mig.Version, mig.Name); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// マイグレーションを1件取り消す
+func revert(ctx context.Context, conn *sqlx.Conn, mig Migration) error {
+	if mig.Down == "" {
+		return fmt.Errorf("migration %04d_%s has no down script", mig.Version, mig.Name)
+	}
+
+	log.Printf("Reverting migration %04d_%s", mig.Version, mig.Name)
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, mig.Down); err != nil {
+		return fmt.Errorf("revert migration %04d_%s: %w", mig.Version, mig.Name, err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", mig.Version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
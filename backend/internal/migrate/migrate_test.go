@@ -0,0 +1,84 @@
+package migrate
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0002_add_index.up.sql":   {Data: []byte("CREATE INDEX i ON t (c);")},
+		"0002_add_index.down.sql": {Data: []byte("DROP INDEX i;")},
+		"0001_init.up.sql":        {Data: []byte("CREATE TABLE t (c INT);")},
+		"0001_init.down.sql":      {Data: []byte("DROP TABLE t;")},
+		"README.md":               {Data: []byte("not a migration")},
+	}
+	migrations, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(migrations) != 2 {
+		t.Fatalf("Load returned %d migrations, want 2", len(migrations))
+	}
+	first := migrations[0]
+	if first.Version != 1 || first.Name != "init" || first.Up != "CREATE TABLE t (c INT);" || first.Down != "DROP TABLE t;" {
+		t.Errorf("migrations[0] = %+v", first)
+	}
+	if migrations[1].Version != 2 {
+		t.Errorf("migrations[1].Version = %d, want 2", migrations[1].Version)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		want string
+	}{
+		{
+			name: "missing up script",
+			fsys: fstest.MapFS{"0001_init.down.sql": {Data: []byte("DROP TABLE t;")}},
+			want: "has no up script",
+		},
+		{
+			name: "conflicting names",
+			fsys: fstest.MapFS{
+				"0001_init.up.sql":  {Data: []byte("CREATE TABLE t (c INT);")},
+				"0001_other.up.sql": {Data: []byte("CREATE TABLE u (c INT);")},
+			},
+			want: "conflicting names",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Load(tt.fsys); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// 埋め込んだマイグレーションは1から連番で、すべて戻せること
+func TestEmbeddedMigrations(t *testing.T) {
+	sub, err := fs.Sub(embedded, "migrations")
+	if err != nil {
+		t.Fatalf("Sub: %v", err)
+	}
+	migrations, err := Load(sub)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("no embedded migrations")
+	}
+	for i, mig := range migrations {
+		if mig.Version != i+1 {
+			t.Errorf("migration %d_%s: version %d, want %d", mig.Version, mig.Name, mig.Version, i+1)
+		}
+		if strings.TrimSpace(mig.Down) == "" {
+			t.Errorf("migration %d_%s has no down script", mig.Version, mig.Name)
+		}
+	}
+}
@@ -1,4 +1,5 @@
-CREATE TABLE tasks (
+-- 既存の init.sql で作成済みのデータベースでもそのまま適用できるようにする
+CREATE TABLE IF NOT EXISTS tasks (
     id SERIAL PRIMARY KEY,
     name VARCHAR(255) NOT NULL,
     description TEXT,
@@ -10,7 +11,7 @@ CREATE TABLE tasks (
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
-CREATE TABLE labels (
+CREATE TABLE IF NOT EXISTS labels (
     id SERIAL PRIMARY KEY,
     name VARCHAR(50) NOT NULL,
     color VARCHAR(10) NOT NULL,
@@ -18,16 +19,19 @@ CREATE TABLE labels (
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
-CREATE TABLE task_labels (
+CREATE TABLE IF NOT EXISTS task_labels (
     task_id INTEGER REFERENCES tasks(id) ON DELETE CASCADE,
     label_id INTEGER REFERENCES labels(id) ON DELETE CASCADE,
     PRIMARY KEY (task_id, label_id)
 );
 
 -- サンプルラベルの作成
-INSERT INTO labels (name, color) VALUES 
-    ('重要', '#FF5252'), 
-    ('仕事', '#4CAF50'), 
-    ('個人', '#2196F3'), 
+INSERT INTO labels (name, color)
+SELECT name, color FROM (VALUES
+    ('重要', '#FF5252'),
+    ('仕事', '#4CAF50'),
+    ('個人', '#2196F3'),
     ('勉強', '#9C27B0'),
-    ('家庭', '#FF9800');
\ No newline at end of file
+    ('家庭', '#FF9800')
+) AS seed (name, color)
+WHERE NOT EXISTS (SELECT 1 FROM labels);
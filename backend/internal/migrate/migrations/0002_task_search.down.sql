@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS tasks_description_trgm_idx;
+DROP INDEX IF EXISTS tasks_name_trgm_idx;
+DROP INDEX IF EXISTS tasks_search_trgm_idx;
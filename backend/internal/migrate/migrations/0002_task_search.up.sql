@@ -0,0 +1,10 @@
+-- タスクの名前と説明の全文検索用のトライグラム索引
+-- 日本語の文字をトライグラムに含めるため、データベースの LC_CTYPE は C 以外（ja_JP.UTF-8 や en_US.UTF-8 など）にすること
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS tasks_search_trgm_idx
+    ON tasks USING GIN ((name || ' ' || COALESCE(description, '')) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS tasks_name_trgm_idx
+    ON tasks USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS tasks_description_trgm_idx
+    ON tasks USING GIN (description gin_trgm_ops);
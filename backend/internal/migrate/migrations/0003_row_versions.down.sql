@@ -0,0 +1,2 @@
+ALTER TABLE labels DROP COLUMN IF EXISTS version;
+ALTER TABLE tasks DROP COLUMN IF EXISTS version;
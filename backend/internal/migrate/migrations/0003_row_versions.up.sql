@@ -0,0 +1,3 @@
+-- 楽観的排他制御のための行バージョン（更新のたびに1ずつ増やす）
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE labels ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,4 @@
+-- ゴミ箱のタスクは元に戻せないため、列を削除する前に完全に削除する
+DELETE FROM tasks WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS tasks_deleted_at_idx;
+ALTER TABLE tasks DROP COLUMN IF EXISTS deleted_at;
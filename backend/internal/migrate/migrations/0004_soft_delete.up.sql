@@ -0,0 +1,5 @@
+-- タスクの論理削除（ゴミ箱）。deleted_at が設定されたタスクは一覧や更新の対象外になる
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+-- ゴミ箱の一覧と期限切れの完全削除で使う
+CREATE INDEX IF NOT EXISTS tasks_deleted_at_idx ON tasks (deleted_at) WHERE deleted_at IS NOT NULL;
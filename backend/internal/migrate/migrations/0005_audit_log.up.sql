@@ -0,0 +1,16 @@
+-- タスクとラベルの変更履歴。changes には項目ごとの変更前後の値を {"項目": {"old": ..., "new": ...}} の形で保存する
+-- タスクやラベルを完全に削除しても履歴は残すため、外部キーは設定しない
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    entity_type VARCHAR(20) NOT NULL CHECK (entity_type IN ('task', 'label')),
+    entity_id INTEGER NOT NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('create', 'update', 'delete', 'restore', 'purge')),
+    actor VARCHAR(255),
+    request_id VARCHAR(128),
+    changes JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- タスクごとの履歴と、期間での絞り込みで使う
+CREATE INDEX IF NOT EXISTS audit_log_entity_idx ON audit_log (entity_type, entity_id, id);
+CREATE INDEX IF NOT EXISTS audit_log_created_at_idx ON audit_log (created_at);
@@ -0,0 +1,7 @@
+-- サブタスク。親を完全に削除した場合、子は最上位のタスクになる
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES tasks(id) ON DELETE SET NULL;
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_parent_not_self;
+ALTER TABLE tasks ADD CONSTRAINT tasks_parent_not_self CHECK (parent_id <> id);
+
+-- 子タスクの一覧と集計で使う
+CREATE INDEX IF NOT EXISTS tasks_parent_id_idx ON tasks (parent_id) WHERE parent_id IS NOT NULL;
@@ -0,0 +1,12 @@
+-- タスクの依存関係。task_id のタスクは depends_on_id のタスクが完了するまで開始できない
+-- どちらかのタスクを完全に削除した場合は依存関係も削除する
+CREATE TABLE IF NOT EXISTS task_dependencies (
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    depends_on_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, depends_on_id),
+    CONSTRAINT task_dependencies_not_self CHECK (task_id <> depends_on_id)
+);
+
+-- 依存されているタスクからたどる場合に使う
+CREATE INDEX IF NOT EXISTS task_dependencies_depends_on_id_idx ON task_dependencies (depends_on_id);
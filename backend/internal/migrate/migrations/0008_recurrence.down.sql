@@ -0,0 +1,4 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS series_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS recurrence_start;
+ALTER TABLE tasks DROP COLUMN IF EXISTS recurrence_timezone;
+ALTER TABLE tasks DROP COLUMN IF EXISTS recurrence_rule;
@@ -0,0 +1,9 @@
+-- 繰り返しタスク。規則は繰り返しの最新のタスクだけが持ち、完了すると次のタスクに引き継ぐ
+-- recurrence_start は COUNT を数える起点（最初の繰り返しの日時）、series_id は最初のタスクのID
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS recurrence_rule TEXT;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS recurrence_timezone TEXT;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS recurrence_start TIMESTAMP;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS series_id INTEGER REFERENCES tasks(id) ON DELETE SET NULL;
+
+-- 同じ繰り返しのタスクの一覧で使う
+CREATE INDEX IF NOT EXISTS tasks_series_id_idx ON tasks (series_id) WHERE series_id IS NOT NULL;
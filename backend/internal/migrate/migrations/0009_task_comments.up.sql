@@ -0,0 +1,24 @@
+-- タスクのコメント。body は無害化した Markdown で、author は投稿者（認証なしの場合は NULL）
+-- タスクと一緒にゴミ箱に入り、タスクを完全に削除した場合はコメントも削除する
+CREATE TABLE IF NOT EXISTS task_comments (
+    id SERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    author VARCHAR(255),
+    body TEXT NOT NULL CONSTRAINT task_comments_body CHECK (body <> ''),
+    edited_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    version INTEGER NOT NULL DEFAULT 1
+);
+
+-- タスクごとのコメント一覧と件数の集計で使う
+CREATE INDEX IF NOT EXISTS task_comments_task_id_idx ON task_comments (task_id, id);
+
+-- コメントの編集前の本文。version は編集前のコメントの版、created_at はその本文を書いた日時
+CREATE TABLE IF NOT EXISTS task_comment_revisions (
+    comment_id INTEGER NOT NULL REFERENCES task_comments(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (comment_id, version)
+);
@@ -0,0 +1,16 @@
+-- タスクの添付ファイルのメタデータ。本体は blob_key のキーで blob ストレージに保存する
+-- タスクを完全に削除した場合はメタデータも削除し、参照されなくなった本体は定期的に削除する
+CREATE TABLE IF NOT EXISTS task_attachments (
+    id SERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL CHECK (size >= 0),
+    checksum CHAR(64) NOT NULL,
+    blob_key VARCHAR(512) NOT NULL CONSTRAINT task_attachments_blob_key UNIQUE,
+    uploaded_by VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- タスクごとの添付ファイル一覧で使う
+CREATE INDEX IF NOT EXISTS task_attachments_task_id_idx ON task_attachments (task_id, id);
@@ -0,0 +1,6 @@
+ALTER TABLE labels DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE labels DROP COLUMN IF EXISTS created_by;
+ALTER TABLE tasks DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE tasks DROP COLUMN IF EXISTS created_by;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS users;
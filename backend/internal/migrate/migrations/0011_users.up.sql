@@ -0,0 +1,25 @@
+-- ユーザー。username は小文字にそろえて保存し、変更者（created_by など）として記録する
+CREATE TABLE IF NOT EXISTS users (
+    id SERIAL PRIMARY KEY,
+    username VARCHAR(32) NOT NULL CONSTRAINT users_username UNIQUE,
+    password_hash TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 発行したリフレッシュトークン（id はトークンの jti）。使うたびに失効させて新しいものに置き換える
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id VARCHAR(64) PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS refresh_tokens_user_id_idx ON refresh_tokens (user_id);
+
+-- 作成した人と最後に更新した人のユーザー名（認証なしで変更した場合は NULL）
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS created_by VARCHAR(255);
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS updated_by VARCHAR(255);
+ALTER TABLE labels ADD COLUMN IF NOT EXISTS created_by VARCHAR(255);
+ALTER TABLE labels ADD COLUMN IF NOT EXISTS updated_by VARCHAR(255);
@@ -0,0 +1,10 @@
+-- タスクの担当者。タスクを完全に削除した場合やユーザーを削除した場合は担当も削除する
+CREATE TABLE IF NOT EXISTS task_assignees (
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, user_id)
+);
+
+-- 担当者からたどる場合（assignee=me や GET /me/tasks）に使う
+CREATE INDEX IF NOT EXISTS task_assignees_user_id_idx ON task_assignees (user_id);
@@ -0,0 +1,8 @@
+DELETE FROM audit_log WHERE entity_type = 'project';
+ALTER TABLE audit_log DROP CONSTRAINT IF EXISTS audit_log_entity_type_check;
+ALTER TABLE audit_log ADD CONSTRAINT audit_log_entity_type_check CHECK (entity_type IN ('task', 'label'));
+DROP INDEX IF EXISTS labels_project_id_idx;
+DROP INDEX IF EXISTS tasks_project_id_idx;
+ALTER TABLE labels DROP COLUMN IF EXISTS project_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS project_id;
+DROP TABLE IF EXISTS projects;
@@ -0,0 +1,28 @@
+-- プロジェクト。タスクとラベルはいずれかのプロジェクトに属し、アーカイブしたプロジェクトは読み取り専用になる
+CREATE TABLE IF NOT EXISTS projects (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL CONSTRAINT projects_name UNIQUE,
+    description TEXT,
+    archived_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    updated_by VARCHAR(255),
+    version INTEGER NOT NULL DEFAULT 1
+);
+
+-- 既存のタスクとラベルを移す既定のプロジェクト（プロジェクトを指定しない POST /tasks, POST /labels もここに作成する）
+INSERT INTO projects (id, name, description) VALUES (1, 'Default', '既定のプロジェクト')
+ON CONFLICT (id) DO NOTHING;
+SELECT setval(pg_get_serial_sequence('projects', 'id'), (SELECT MAX(id) FROM projects));
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS project_id INTEGER NOT NULL DEFAULT 1 REFERENCES projects(id);
+ALTER TABLE labels ADD COLUMN IF NOT EXISTS project_id INTEGER NOT NULL DEFAULT 1 REFERENCES projects(id);
+
+-- プロジェクトごとの一覧（GET /projects/{pid}/tasks など）で使う
+CREATE INDEX IF NOT EXISTS tasks_project_id_idx ON tasks (project_id);
+CREATE INDEX IF NOT EXISTS labels_project_id_idx ON labels (project_id);
+
+-- プロジェクトの変更も履歴に記録する
+ALTER TABLE audit_log DROP CONSTRAINT IF EXISTS audit_log_entity_type_check;
+ALTER TABLE audit_log ADD CONSTRAINT audit_log_entity_type_check CHECK (entity_type IN ('task', 'label', 'project'));
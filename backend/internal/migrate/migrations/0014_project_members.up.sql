@@ -0,0 +1,18 @@
+-- プロジェクトのメンバーと役割（viewer は閲覧、editor はさらにタスクとラベルの変更、admin はさらにプロジェクトの管理ができる）
+CREATE TABLE IF NOT EXISTS project_members (
+    project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CONSTRAINT project_members_role_check CHECK (role IN ('viewer', 'editor', 'admin')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (project_id, user_id)
+);
+
+-- ユーザーからたどる場合（一覧の絞り込みなど）に使う
+CREATE INDEX IF NOT EXISTS project_members_user_id_idx ON project_members (user_id);
+
+-- 既存のユーザーがタスクを続けて扱えるよう、既定のプロジェクトの editor にする
+-- admin は自動では与えず、サーバーの設定（auth.admin_username）で指定したユーザーだけが起動時に admin になる
+INSERT INTO project_members (project_id, user_id, role)
+SELECT 1, u.id, 'editor' FROM users u
+ON CONFLICT DO NOTHING;
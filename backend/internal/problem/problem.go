@@ -0,0 +1,122 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/yuchi1128/task-management-system/backend/internal/requestid"
+)
+
+// ContentType はRFC 7807のエラーレスポンスのメディアタイプです
+const ContentType = "application/problem+json"
+
+// StatusClientClosedRequest はクライアントがレスポンスを待たずに切断したことを表す非標準のステータスコードです
+const StatusClientClosedRequest = 499
+
+// エラーの種類を表す機械可読なコード
+const (
+	CodeValidationFailed     = "validation_failed"
+	CodeInvalidBody          = "invalid_body"
+	CodeInvalidParameter     = "invalid_parameter"
+	CodeNotFound             = "not_found"
+	CodeUnauthorized         = "unauthorized"
+	CodeForbidden            = "forbidden"
+	CodeMethodNotAllowed     = "method_not_allowed"
+	CodeConflict             = "conflict"
+	CodePreconditionFailed   = "precondition_failed"
+	CodeInvalidReference     = "invalid_reference"
+	CodeConstraintViolation  = "constraint_violation"
+	CodePayloadTooLarge      = "payload_too_large"
+	CodeUnsupportedMediaType = "unsupported_media_type"
+	CodeRequestCanceled      = "request_canceled"
+	CodeTimeout              = "timeout"
+	CodeInternal             = "internal_error"
+)
+
+// Problem はRFC 7807形式のエラーレスポンスです
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	// Permission は権限が不足していた場合（403）の詳細です
+	Permission *Permission `json:"permission,omitempty"`
+}
+
+// FieldError は検証に失敗した1つの項目を表します
+type FieldError struct {
+	Field    string `json:"field"`
+	Location string `json:"location"`
+	Message  string `json:"message"`
+}
+
+// Permission は不足していた権限と、それを持つ役割を表します
+type Permission struct {
+	Required  string `json:"required"`
+	ProjectID int    `json:"project_id"`
+	// Role はプロジェクトでのユーザーの役割です
+	Role      string   `json:"role"`
+	GrantedTo []string `json:"granted_to"`
+}
+
+func (p *Problem) Error() string {
+	if p.Detail != "" {
+		return p.Code + ": " + p.Detail
+	}
+	return p.Code
+}
+
+// New はステータスコードとエラーコードからProblemを作成します
+func New(status int, code, detail string) *Problem {
+	title := http.StatusText(status)
+	if status == StatusClientClosedRequest {
+		title = "Client Closed Request"
+	}
+	return &Problem{
+		Type:   "/problems/" + code,
+		Title:  title,
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// Validation は項目ごとの検証エラーを含む400のProblemを作成します
+func Validation(errors []FieldError) *Problem {
+	p := New(http.StatusBadRequest, CodeValidationFailed, "Request validation failed")
+	p.Errors = errors
+	return p
+}
+
+// Write はProblemをレスポンスとして書き込みます
+// instance と request_id はリクエストから補完します
+func Write(w http.ResponseWriter, r *http.Request, p *Problem) {
+	if p.Instance == "" {
+		p.Instance = r.URL.Path
+	}
+	if p.RequestID == "" {
+		p.RequestID = requestid.FromContext(r.Context())
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+// NotFoundHandler はルートが存在しない場合のハンドラーです
+func NotFoundHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Write(w, r, New(http.StatusNotFound, CodeNotFound, "Resource not found"))
+	})
+}
+
+// MethodNotAllowedHandler はメソッドが許可されていない場合のハンドラーです
+func MethodNotAllowedHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Write(w, r, New(http.StatusMethodNotAllowed, CodeMethodNotAllowed, "Method not allowed"))
+	})
+}
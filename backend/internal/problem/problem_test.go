@@ -0,0 +1,66 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/yuchi1128/task-management-system/backend/internal/requestid"
+)
+
+func TestWrite(t *testing.T) {
+	handler := requestid.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Write(w, r, Validation([]FieldError{{Field: "name", Location: "body", Message: "must not be empty"}}))
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/tasks", nil)
+	req.Header.Set(requestid.Header, "req-1")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != ContentType {
+		t.Errorf("Content-Type = %q, want %q", got, ContentType)
+	}
+	var p Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := Problem{
+		Type:      "/problems/validation_failed",
+		Title:     "Bad Request",
+		Status:    http.StatusBadRequest,
+		Detail:    "Request validation failed",
+		Instance:  "/tasks",
+		Code:      CodeValidationFailed,
+		RequestID: "req-1",
+	}
+	if len(p.Errors) != 1 || p.Errors[0].Field != "name" {
+		t.Errorf("errors = %+v, want the name error", p.Errors)
+	}
+	p.Errors = nil
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("problem = %+v, want %+v", p, want)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		status int
+		code   string
+		title  string
+	}{
+		{http.StatusNotFound, CodeNotFound, "Not Found"},
+		{http.StatusPreconditionFailed, CodePreconditionFailed, "Precondition Failed"},
+		{StatusClientClosedRequest, CodeRequestCanceled, "Client Closed Request"},
+	}
+	for _, tt := range tests {
+		p := New(tt.status, tt.code, "detail")
+		if p.Title != tt.title || p.Type != "/problems/"+tt.code || p.Status != tt.status {
+			t.Errorf("New(%d, %q) = %+v", tt.status, tt.code, p)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+// Package recurrence は iCalendar（RFC 5545）の RRULE を解釈し、繰り返しの日時を計算します
+//
+// 対応するのはタスクの繰り返しに必要な範囲で、FREQ は DAILY, WEEKLY, MONTHLY, YEARLY、
+// 絞り込みは BYDAY, BYMONTHDAY, BYMONTH です（BYSETPOS や時刻単位の繰り返しには対応しません）
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency は繰り返しの単位です
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// ErrInvalidRule は RRULE を解釈できない場合に返されます
+var ErrInvalidRule = errors.New("recurrence: invalid rule")
+
+// Weekday は BYDAY の曜日です
+// N は月内（YEARLY では BYMONTH の月内）の何番目かで、負の値は末尾から数え、0は毎週を表します
+type Weekday struct {
+	Day time.Weekday
+	N   int
+}
+
+// Rule は解釈済みの RRULE です
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	// Count は DTSTART から数えた繰り返しの回数です（0は制限なし）
+	Count int
+	// Until は最後の繰り返しの日時です（nil は制限なし）
+	// UntilDate が true の場合は日付だけが指定されており、その日のうちは繰り返します
+	Until      *time.Time
+	UntilDate  bool
+	ByDay      []Weekday
+	ByMonthDay []int
+	ByMonth    []time.Month
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// UNTIL の形式
+const (
+	untilUTC   = "20060102T150405Z"
+	untilLocal = "20060102T150405"
+	untilDate  = "20060102"
+)
+
+// Parse は "FREQ=WEEKLY;BYDAY=MO,WE" 形式の RRULE を解釈します（先頭の "RRULE:" は省略できます）
+// 日付だけでない UNTIL で末尾に Z がないものは loc の時刻として扱います
+func Parse(s string, loc *time.Location) (Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return Rule{}, invalid("rule is empty")
+	}
+
+	r := Rule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if !ok || value == "" {
+			return Rule{}, invalid("malformed part %q", part)
+		}
+		if seen[name] {
+			return Rule{}, invalid("%s is specified more than once", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			r.Freq = Frequency(value)
+			switch r.Freq {
+			case Daily, Weekly, Monthly, Yearly:
+			default:
+				err = invalid("unsupported FREQ %q", value)
+			}
+		case "INTERVAL":
+			r.Interval, err = positive(name, value)
+		case "COUNT":
+			r.Count, err = positive(name, value)
+		case "UNTIL":
+			err = r.parseUntil(value, loc)
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseInts(name, value, -31, 31)
+		case "BYMONTH":
+			var months []int
+			months, err = parseInts(name, value, 1, 12)
+			for _, m := range months {
+				r.ByMonth = append(r.ByMonth, time.Month(m))
+			}
+		case "WKST":
+			// 週の始まりは月曜日だけに対応する
+			if value != "MO" {
+				err = invalid("only WKST=MO is supported")
+			}
+		default:
+			err = invalid("unsupported part %s", name)
+		}
+		if err != nil {
+			return Rule{}, err
+		}
+	}
+
+	if r.Freq == "" {
+		return Rule{}, invalid("FREQ is required")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return Rule{}, invalid("COUNT and UNTIL cannot be used together")
+	}
+	for _, d := range r.ByDay {
+		if d.N != 0 && r.Freq != Monthly && r.Freq != Yearly {
+			return Rule{}, invalid("BYDAY with an ordinal is only allowed in MONTHLY and YEARLY rules")
+		}
+	}
+	if r.Freq == Yearly && len(r.ByDay) > 0 && len(r.ByMonth) == 0 {
+		return Rule{}, invalid("BYDAY in YEARLY rules requires BYMONTH")
+	}
+	if r.Freq == Weekly && len(r.ByMonthDay) > 0 {
+		return Rule{}, invalid("BYMONTHDAY is not allowed in WEEKLY rules")
+	}
+	return r, nil
+}
+
+// String は RRULE を決まった順序の文字列にします
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		if r.UntilDate {
+			parts = append(parts, "UNTIL="+r.Until.Format(untilDate))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilUTC))
+		}
+	}
+	if len(r.ByMonth) > 0 {
+		months := make([]string, len(r.ByMonth))
+		for i, m := range r.ByMonth {
+			months[i] = strconv.Itoa(int(m))
+		}
+		parts = append(parts, "BYMONTH="+strings.Join(months, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, d := range r.ByMonthDay {
+			days[i] = strconv.Itoa(d)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			days[i] = strings.ToUpper(d.Day.String()[:2])
+			if d.N != 0 {
+				days[i] = strconv.Itoa(d.N) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	return strings.Join(parts, ";")
+}
+
+func (r *Rule) parseUntil(value string, loc *time.Location) error {
+	var until time.Time
+	var err error
+	switch len(value) {
+	case len(untilUTC):
+		until, err = time.Parse(untilUTC, value)
+	case len(untilLocal):
+		until, err = time.ParseInLocation(untilLocal, value, loc)
+	case len(untilDate):
+		until, err = time.ParseInLocation(untilDate, value, loc)
+		r.UntilDate = true
+	default:
+		err = errors.New("unknown format")
+	}
+	if err != nil {
+		return invalid("UNTIL %q must be a date (YYYYMMDD) or date-time (YYYYMMDDTHHMMSSZ)", value)
+	}
+	r.Until = &until
+	return nil
+}
+
+func parseByDay(value string) ([]Weekday, error) {
+	var days []Weekday
+	for _, v := range strings.Split(value, ",") {
+		if len(v) < 2 {
+			return nil, invalid("invalid BYDAY %q", v)
+		}
+		day, ok := weekdayNames[v[len(v)-2:]]
+		if !ok {
+			return nil, invalid("invalid BYDAY %q", v)
+		}
+		wd := Weekday{Day: day}
+		if ordinal := v[:len(v)-2]; ordinal != "" {
+			n, err := strconv.Atoi(ordinal)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, invalid("invalid BYDAY %q", v)
+			}
+			wd.N = n
+		}
+		days = append(days, wd)
+	}
+	return days, nil
+}
+
+func parseInts(name, value string, min, max int) ([]int, error) {
+	var values []int
+	for _, v := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(v)
+		if err != nil || n == 0 || n < min || n > max {
+			return nil, invalid("invalid %s %q", name, v)
+		}
+		values = append(values, n)
+	}
+	return values, nil
+}
+
+func positive(name, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, invalid("%s must be a positive integer", name)
+	}
+	return n, nil
+}
+
+func invalid(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidRule, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,67 @@
+package recurrence
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	tests := []struct {
+		rule string
+		want string
+	}{
+		{"FREQ=DAILY", "FREQ=DAILY"},
+		{"RRULE:freq=weekly;byday=mo,we;interval=2", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE"},
+		{"FREQ=MONTHLY;BYDAY=-1FR;COUNT=3", "FREQ=MONTHLY;COUNT=3;BYDAY=-1FR"},
+		{"FREQ=MONTHLY;BYMONTHDAY=1,-1;WKST=MO", "FREQ=MONTHLY;BYMONTHDAY=1,-1"},
+		{"FREQ=YEARLY;BYMONTH=11;BYDAY=4TH", "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH"},
+		// Z のない UNTIL は指定したタイムゾーンの時刻として UTC に変換する
+		{"FREQ=DAILY;UNTIL=20240131T090000", "FREQ=DAILY;UNTIL=20240131T000000Z"},
+		{"FREQ=DAILY;UNTIL=20240131T090000Z", "FREQ=DAILY;UNTIL=20240131T090000Z"},
+		{"FREQ=DAILY;UNTIL=20240131", "FREQ=DAILY;UNTIL=20240131"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			r, err := Parse(tt.rule, tokyo)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.rule, err)
+			}
+			if got := r.String(); got != tt.want {
+				t.Errorf("Parse(%q).String() = %q, want %q", tt.rule, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=x",
+		"FREQ=DAILY;COUNT=3;UNTIL=20240131",
+		"FREQ=DAILY;UNTIL=2024-01-31",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=MONTHLY;BYDAY=6MO",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=YEARLY;BYMONTH=13",
+		"FREQ=YEARLY;BYDAY=MO",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=DAILY;WKST=SU",
+		"FREQ=DAILY;BYSETPOS=1",
+		"FREQ=DAILY;COUNT",
+	}
+	for _, rule := range tests {
+		t.Run(rule, func(t *testing.T) {
+			if _, err := Parse(rule, time.UTC); !errors.Is(err, ErrInvalidRule) {
+				t.Errorf("Parse(%q) = %v, want ErrInvalidRule", rule, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package recurrence
+
+import (
+	"slices"
+	"sort"
+	"time"
+)
+
+// maxPeriods は一致する日が存在しない規則（2月30日など）でも計算が終わるよう、調べる期間の数の上限です
+const maxPeriods = 100000
+
+// Schedule は RRULE を最初の繰り返しの日時（DTSTART）とタイムゾーンに結び付けたものです
+// 繰り返しの時刻は Location での壁時計の時刻を保つため、夏時間の切り替えをまたいでも同じ時刻になります
+type Schedule struct {
+	Rule     Rule
+	Start    time.Time
+	Location *time.Location
+}
+
+// After は after より後の繰り返しの日時を古い順に最大 n 件返します
+// RFC 5545 と同じく、Start は規則に一致しなくても最初の繰り返しとして COUNT に数えます
+func (s Schedule) After(after time.Time, n int) []time.Time {
+	loc := s.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	start := s.Start.In(loc)
+
+	var result []time.Time
+	emit := func(t time.Time) bool {
+		if t.After(after) {
+			result = append(result, t)
+		}
+		return len(result) < n
+	}
+	if n < 1 || s.ended(start, 1) || !emit(start) {
+		return result
+	}
+
+	count := 1
+	for k := 0; k < maxPeriods; k++ {
+		for _, t := range s.period(start, k) {
+			if !t.After(start) {
+				continue
+			}
+			count++
+			if s.ended(t, count) || !emit(t) {
+				return result
+			}
+		}
+	}
+	return result
+}
+
+// ended は count 番目の繰り返しである t が COUNT と UNTIL の範囲を超えたかを判定します
+func (s Schedule) ended(t time.Time, count int) bool {
+	r := s.Rule
+	if r.Count > 0 && count > r.Count {
+		return true
+	}
+	if r.Until == nil {
+		return false
+	}
+	if r.UntilDate {
+		// 日付だけの UNTIL はその日の終わりまでを含む
+		y, m, d := r.Until.Date()
+		return !t.Before(time.Date(y, m, d+1, 0, 0, 0, 0, t.Location()))
+	}
+	return t.After(*r.Until)
+}
+
+// period は Start から数えて k 番目の期間（FREQ と INTERVAL で決まる日・週・月・年）に含まれる候補を古い順に返します
+func (s Schedule) period(start time.Time, k int) []time.Time {
+	r := s.Rule
+	step := k * r.Interval
+	y, m, d := start.Date()
+	at := func(y int, m time.Month, d int) time.Time {
+		hour, min, sec := start.Clock()
+		return time.Date(y, m, d, hour, min, sec, 0, start.Location())
+	}
+
+	var result []time.Time
+	switch r.Freq {
+	case Daily:
+		t := at(y, m, d+step)
+		ty, tm, td := t.Date()
+		if r.matchesMonth(tm) && r.matchesMonthDay(ty, tm, td) && r.matchesWeekday(t.Weekday()) {
+			result = append(result, t)
+		}
+	case Weekly:
+		// 週は月曜日から始まる
+		monday := d - int(start.Weekday()+6)%7 + step*7
+		days := []time.Weekday{start.Weekday()}
+		if len(r.ByDay) > 0 {
+			days = nil
+			for _, wd := range r.ByDay {
+				days = append(days, wd.Day)
+			}
+		}
+		for _, day := range days {
+			t := at(y, m, monday+int(day+6)%7)
+			if r.matchesMonth(t.Month()) {
+				result = append(result, t)
+			}
+		}
+	case Monthly:
+		months := int(m) - 1 + step
+		py, pm := y+months/12, time.Month(months%12+1)
+		if r.matchesMonth(pm) {
+			for _, day := range r.monthDays(py, pm, d) {
+				result = append(result, at(py, pm, day))
+			}
+		}
+	case Yearly:
+		months := []time.Month{m}
+		if len(r.ByMonth) > 0 {
+			months = r.ByMonth
+		}
+		for _, pm := range months {
+			for _, day := range r.monthDays(y+step, pm, d) {
+				result = append(result, at(y+step, pm, day))
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+	return slices.CompactFunc(result, func(a, b time.Time) bool { return a.Equal(b) })
+}
+
+// monthDays は月の中で BYMONTHDAY と BYDAY に一致する日を返します
+// どちらも指定されていない場合は DTSTART と同じ日（その日がない月は含めない）です
+func (r Rule) monthDays(y int, m time.Month, startDay int) []int {
+	n := daysIn(y, m)
+	if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		if startDay > n {
+			return nil
+		}
+		return []int{startDay}
+	}
+
+	var days []int
+	for d := 1; d <= n; d++ {
+		if len(r.ByMonthDay) > 0 && !r.matchesMonthDay(y, m, d) {
+			continue
+		}
+		if len(r.ByDay) > 0 && !r.matchesMonthWeekday(y, m, d) {
+			continue
+		}
+		days = append(days, d)
+	}
+	return days
+}
+
+// matchesMonthWeekday は BYDAY（序数付きの場合は月内の何番目か）に一致するかを判定します
+func (r Rule) matchesMonthWeekday(y int, m time.Month, d int) bool {
+	weekday := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Weekday()
+	nth := (d-1)/7 + 1
+	nthFromEnd := -((daysIn(y, m)-d)/7 + 1)
+	for _, wd := range r.ByDay {
+		if wd.Day == weekday && (wd.N == 0 || wd.N == nth || wd.N == nthFromEnd) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matchesWeekday(day time.Weekday) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, wd := range r.ByDay {
+		if wd.Day == day {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matchesMonth(m time.Month) bool {
+	return len(r.ByMonth) == 0 || slices.Contains(r.ByMonth, m)
+}
+
+// matchesMonthDay は BYMONTHDAY（負の値は月末から数える）に一致するかを判定します
+func (r Rule) matchesMonthDay(y int, m time.Month, d int) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	n := daysIn(y, m)
+	for _, md := range r.ByMonthDay {
+		if md == d || n+md+1 == d {
+			return true
+		}
+	}
+	return false
+}
+
+// daysIn は月の日数を返します
+func daysIn(y int, m time.Month) int {
+	return time.Date(y, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,114 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleAfter(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 9, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		after time.Time
+		n     int
+		want  []time.Time
+	}{
+		{
+			name: "daily", rule: "FREQ=DAILY", start: day(2024, 1, 1), after: day(2024, 1, 3), n: 2,
+			want: []time.Time{day(2024, 1, 4), day(2024, 1, 5)},
+		},
+		{
+			// 日付だけの UNTIL はその日を含む
+			name: "interval and until date", rule: "FREQ=DAILY;INTERVAL=2;UNTIL=20240107", start: day(2024, 1, 1), n: 10,
+			want: []time.Time{day(2024, 1, 1), day(2024, 1, 3), day(2024, 1, 5), day(2024, 1, 7)},
+		},
+		{
+			name: "weekly by day with count", rule: "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4", start: day(2024, 1, 1), n: 10,
+			want: []time.Time{day(2024, 1, 1), day(2024, 1, 3), day(2024, 1, 8), day(2024, 1, 10)},
+		},
+		{
+			// 規則に一致しない DTSTART も1回目に数える
+			name: "start outside rule", rule: "FREQ=WEEKLY;BYDAY=MO;COUNT=3", start: day(2024, 1, 3), n: 10,
+			want: []time.Time{day(2024, 1, 3), day(2024, 1, 8), day(2024, 1, 15)},
+		},
+		{
+			// 31日がない月は飛ばす
+			name: "monthly on the 31st", rule: "FREQ=MONTHLY", start: day(2024, 1, 31), n: 4,
+			want: []time.Time{day(2024, 1, 31), day(2024, 3, 31), day(2024, 5, 31), day(2024, 7, 31)},
+		},
+		{
+			name: "last day of month", rule: "FREQ=MONTHLY;BYMONTHDAY=-1", start: day(2024, 1, 31), n: 4,
+			want: []time.Time{day(2024, 1, 31), day(2024, 2, 29), day(2024, 3, 31), day(2024, 4, 30)},
+		},
+		{
+			name: "last friday", rule: "FREQ=MONTHLY;BYDAY=-1FR", start: day(2024, 1, 26), n: 3,
+			want: []time.Time{day(2024, 1, 26), day(2024, 2, 23), day(2024, 3, 29)},
+		},
+		{
+			name: "leap day", rule: "FREQ=YEARLY", start: day(2024, 2, 29), n: 2,
+			want: []time.Time{day(2024, 2, 29), day(2028, 2, 29)},
+		},
+		{
+			name: "yearly by month and day", rule: "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH", start: day(2024, 11, 28), n: 2,
+			want: []time.Time{day(2024, 11, 28), day(2025, 11, 27)},
+		},
+		{
+			// 一致する日がない規則でも計算が終わる
+			name: "impossible rule", rule: "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30", start: day(2024, 1, 1), n: 2,
+			want: []time.Time{day(2024, 1, 1)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule, time.UTC)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.rule, err)
+			}
+			after := tt.after
+			if after.IsZero() {
+				after = tt.start.Add(-time.Second)
+			}
+			got := Schedule{Rule: rule, Start: tt.start}.After(after, tt.n)
+			if !equalTimes(got, tt.want) {
+				t.Errorf("After = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleKeepsWallClockAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database is not available: %v", err)
+	}
+	rule, err := Parse("FREQ=DAILY", loc)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	// 2024-03-10 に夏時間が始まっても、現地時刻の 9:00 を保つ
+	start := time.Date(2024, 3, 9, 9, 0, 0, 0, loc)
+	got := Schedule{Rule: rule, Start: start, Location: loc}.After(start, 2)
+	want := []time.Time{
+		time.Date(2024, 3, 10, 13, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 11, 13, 0, 0, 0, time.UTC),
+	}
+	if !equalTimes(got, want) {
+		t.Errorf("After = %v, want %v", got, want)
+	}
+}
+
+func equalTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// Header はリクエストIDを受け渡すHTTPヘッダーです
+const Header = "X-Request-ID"
+
+type contextKey struct{}
+
+// Middleware はリクエストごとにIDを割り当て、コンテキストとレスポンスヘッダーに設定します
+// クライアントがヘッダーでIDを指定した場合はそれを引き継ぎます
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(Header)
+		if id == "" || len(id) > 128 {
+			id = generate()
+		}
+		w.Header().Set(Header, id)
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+	})
+}
+
+// NewContext はリクエストIDを保持したコンテキストを返します
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext はコンテキストからリクエストIDを取り出します
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+func generate() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
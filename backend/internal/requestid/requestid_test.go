@@ -0,0 +1,44 @@
+package requestid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		generate bool
+	}{
+		{"generated", "", true},
+		{"propagated", "client-id", false},
+		{"too long", strings.Repeat("x", 129), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seen = FromContext(r.Context())
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(Header, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get(Header); got != seen {
+				t.Errorf("response header = %q, context = %q", got, seen)
+			}
+			if tt.generate && (seen == tt.header || len(seen) != 32) {
+				t.Errorf("request ID = %q, want a generated ID", seen)
+			}
+			if !tt.generate && seen != tt.header {
+				t.Errorf("request ID = %q, want %q", seen, tt.header)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+// Package search はタスクの全文検索で使う一致判定・スコア計算・ハイライトを提供します
+//
+// 類似度は PostgreSQL の pg_trgm の word_similarity と同じ手順で計算するため、
+// データベースを使わない MemoryStore でも PostgresStore と同じタスクを同じ順序で返せます
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// WordSimilarityThreshold は pg_trgm.word_similarity_threshold の既定値です
+// 部分一致しない語でも、これ以上似ていれば検索結果に含めます
+const WordSimilarityThreshold = 0.6
+
+// スコアの重み（名前に含まれる場合を説明に含まれる場合より優先する）
+const (
+	NameWeight        = 2
+	DescriptionWeight = 1
+)
+
+// Highlight の開始・終了タグ
+const (
+	MarkStart = "<mark>"
+	MarkEnd   = "</mark>"
+)
+
+// Matches はタスクが検索語に一致するかを判定します
+// 名前か説明に部分一致するか、トライグラムの類似度がしきい値以上であれば一致とみなします
+func Matches(query, name, description string) bool {
+	return Contains(name, query) || Contains(description, query) ||
+		WordSimilarity(query, Document(name, description)) >= WordSimilarityThreshold
+}
+
+// Rank は検索語に対するタスクの関連度を返します（大きいほど関連が高い）
+func Rank(query, name, description string) float64 {
+	rank := WordSimilarity(query, Document(name, description))
+	if Contains(name, query) {
+		rank += NameWeight
+	}
+	if Contains(description, query) {
+		rank += DescriptionWeight
+	}
+	return rank
+}
+
+// Document は検索対象の文字列です（索引に使う式と同じく名前と説明を空白でつなぐ）
+func Document(name, description string) string {
+	return name + " " + description
+}
+
+// Contains は大文字小文字を区別せずに部分一致を判定します
+func Contains(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// WordSimilarity は pg_trgm の word_similarity(query, text) と同じ値を返します
+//
+// text の連続した範囲（トライグラムの並びの区間）のうち、query と最も似ている範囲との類似度で、
+// 共通するトライグラムの数を、query と範囲のトライグラムの和集合の数で割ったものです。
+// 範囲の探し方も pg_trgm（trgm_op.c の iterate_word_similarity）と同じで、
+// query のトライグラムが現れるたびにそこを右端とし、左端を動かして最も類似度の高い範囲を選びます
+func WordSimilarity(query, text string) float64 {
+	inQuery := make(map[string]bool)
+	for _, tri := range trigrams(query) {
+		inQuery[tri] = true
+	}
+	if len(inQuery) == 0 {
+		return 0
+	}
+
+	// text のトライグラムを出現順に、重複を除いた番号の並びにする
+	ids := make(map[string]int)
+	var positions []int
+	var found []bool
+	for _, tri := range trigrams(text) {
+		id, ok := ids[tri]
+		if !ok {
+			id = len(ids)
+			ids[tri] = id
+			found = append(found, inQuery[tri])
+		}
+		positions = append(positions, id)
+	}
+
+	// lastPos はトライグラムが範囲の中で最後に現れた位置です（範囲にない場合は -1）
+	lastPos := make([]int, len(ids))
+	for i := range lastPos {
+		lastPos[i] = -1
+	}
+	queryLen := len(inQuery)
+	lower, rangeLen, count := -1, 0, 0
+	var best float32
+	for i, id := range positions {
+		if lower >= 0 || found[id] {
+			if lastPos[id] < 0 {
+				rangeLen++
+				if found[id] {
+					count++
+				}
+			}
+			lastPos[id] = i
+		}
+		if !found[id] {
+			continue
+		}
+
+		upper := i
+		if lower == -1 {
+			lower = i
+			rangeLen = 1
+		}
+		current := trigramSimilarity(count, queryLen, rangeLen)
+
+		// 左端を右に動かして、より類似度の高い範囲を探す
+		tmpCount, tmpLen, prevLower := count, rangeLen, lower
+		for tmpLower := lower; tmpLower <= upper; tmpLower++ {
+			if sml := trigramSimilarity(tmpCount, queryLen, tmpLen); sml > current {
+				current, rangeLen, lower, count = sml, tmpLen, tmpLower, tmpCount
+			}
+			if id := positions[tmpLower]; lastPos[id] == tmpLower {
+				tmpLen--
+				if found[id] {
+					tmpCount--
+				}
+			}
+		}
+		if current > best {
+			best = current
+		}
+		// 範囲から外れたトライグラムを忘れる
+		for tmpLower := prevLower; tmpLower < lower; tmpLower++ {
+			if id := positions[tmpLower]; lastPos[id] == tmpLower {
+				lastPos[id] = -1
+			}
+		}
+	}
+	return float64(best)
+}
+
+// trigramSimilarity は共通するトライグラムの数と、それぞれのトライグラムの数から類似度を計算します
+// pg_trgm と同じく単精度で計算し、しきい値との比較の結果をそろえます
+func trigramSimilarity(count, len1, len2 int) float32 {
+	return float32(count) / float32(len1+len2-count)
+}
+
+// trigrams は pg_trgm と同じ規則で文字列をトライグラムに分解し、出現順に返します（重複を含む）
+// 英数字（日本語の文字を含む）の並びを語とし、小文字にして前に空白2つ・後ろに空白1つを補います
+func trigrams(s string) []string {
+	var result []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			result = append(result, string(runes[i:i+3]))
+		}
+	}
+	return result
+}
+
+// Highlight は text に含まれる query を <mark> で囲んだ抜粋を返します
+// 抜粋は最初の一致の前後を含む最大 maxRunes 文字で、省略した部分は "…" で表します
+// text はHTMLエスケープされるため、結果はそのままHTMLに埋め込めます
+// 一致しない場合は text の先頭を返します
+func Highlight(text, query string, maxRunes int) string {
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	needle := []rune(strings.ToLower(query))
+	// 小文字化で文字数が変わる場合は位置を対応付けられないため、強調せずに抜粋だけ返す
+	if len(lower) != len(runes) || len(needle) == 0 {
+		return excerpt(runes, maxRunes)
+	}
+
+	var matches []int
+	for i := 0; i+len(needle) <= len(lower); {
+		if string(lower[i:i+len(needle)]) == string(needle) {
+			matches = append(matches, i)
+			i += len(needle)
+		} else {
+			i++
+		}
+	}
+	if len(matches) == 0 {
+		return excerpt(runes, maxRunes)
+	}
+
+	// 最初の一致が抜粋の中央付近に来るように開始位置を決める
+	start := matches[0] - (maxRunes-len(needle))/2
+	if start+maxRunes > len(runes) {
+		start = len(runes) - maxRunes
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + maxRunes
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	pos := start
+	for _, m := range matches {
+		if m < start || m+len(needle) > end {
+			continue
+		}
+		b.WriteString(html.EscapeString(string(runes[pos:m])))
+		b.WriteString(MarkStart)
+		b.WriteString(html.EscapeString(string(runes[m : m+len(needle)])))
+		b.WriteString(MarkEnd)
+		pos = m + len(needle)
+	}
+	b.WriteString(html.EscapeString(string(runes[pos:end])))
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+func excerpt(runes []rune, maxRunes int) string {
+	if len(runes) <= maxRunes {
+		return html.EscapeString(string(runes))
+	}
+	return html.EscapeString(string(runes[:maxRunes])) + "…"
+}
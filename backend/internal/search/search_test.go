@@ -0,0 +1,68 @@
+package search
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWordSimilarity(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		text  string
+		want  float64
+	}{
+		// PostgreSQL のドキュメントにある例
+		{"documented example", "word", "two words", 0.8},
+		{"same word", "word", "word", 1},
+		{"case insensitive", "Word", "WORD", 1},
+		{"no common trigram", "abc", "xyz", 0},
+		{"empty query", "", "word", 0},
+		{"empty text", "word", "", 0},
+		// 語が離れている場合は、間の語のトライグラムも範囲に含まれる
+		{"words apart", "abc def", "abc xyz def", 8.0 / 12},
+		{"words adjacent", "abc def", "xyz abc def", 1},
+		// 最も似ている範囲だけで計算する
+		{"best extent", "word", "sword foo word", 1},
+		{"japanese", "設計", "画面の 設計書", 2.0 / 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WordSimilarity(tt.query, tt.text)
+			if math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("WordSimilarity(%q, %q) = %v, want %v", tt.query, tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		taskName    string
+		description string
+		want        bool
+	}{
+		{"name contains", "report", "Write the Report", "", true},
+		{"description contains", "deploy", "release", "deploy to production", true},
+		{"below threshold", "reprot", "report review", "", false},
+		{"above threshold", "words", "two word", "", true},
+		{"unrelated", "invoice", "write the report", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Matches(tt.query, tt.taskName, tt.description); got != tt.want {
+				t.Errorf("Matches(%q, %q, %q) = %v, want %v", tt.query, tt.taskName, tt.description, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	name := Rank("report", "report", "")
+	description := Rank("report", "other", "report")
+	if name <= description {
+		t.Errorf("Rank for a name match = %v, want more than a description match %v", name, description)
+	}
+}
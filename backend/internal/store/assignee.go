@@ -0,0 +1,32 @@
+package store
+
+import (
+	"context"
+	"sort"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// AssigneeStore はタスクの担当者の永続化を抽象化します
+// 担当者はタスクの表現の一部として扱い、付け替えのたびにタスクの版を進めます
+type AssigneeStore interface {
+	// SetTaskAssignees はタスクの担当者を userIDs のユーザーに置き換え、更新後のタスクを返します
+	// 存在しないユーザーを指定した場合は ErrInvalidReference を返します
+	SetTaskAssignees(ctx context.Context, taskID int, userIDs []int, version int) (api.Task, error)
+}
+
+// taskAssigneeRow はタスクIDと担当者を1行で受け取るための構造体です
+type taskAssigneeRow struct {
+	TaskID int `db:"task_id"`
+	api.Assignee
+}
+
+// sortAssignees は担当者をユーザー名の順に並べます
+func sortAssignees(assignees []api.Assignee) {
+	sort.Slice(assignees, func(i, j int) bool {
+		if assignees[i].Username != assignees[j].Username {
+			return assignees[i].Username < assignees[j].Username
+		}
+		return assignees[i].Id < assignees[j].Id
+	})
+}
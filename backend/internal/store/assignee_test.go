@@ -0,0 +1,82 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+func TestMemoryStoreSetTaskAssignees(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{})
+	bob, err := s.CreateUser(ctx, "bob", "hash")
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	alice, err := s.CreateUser(ctx, "alice", "hash")
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	assigned := newTestTask(t, s, taskInput("assigned", "Middle", "NotStarted"))
+	unassigned := newTestTask(t, s, taskInput("unassigned", "Middle", "NotStarted"))
+
+	// 担当者はユーザー名の順で、重複したIDは1つにまとめる
+	task, err := s.SetTaskAssignees(ctx, assigned, []int{bob.ID, alice.ID, bob.ID}, 1)
+	if err != nil {
+		t.Fatalf("SetTaskAssignees: %v", err)
+	}
+	if fmt.Sprint(task.Assignees) != fmt.Sprint([]api.Assignee{{Id: alice.ID, Username: "alice"}, {Id: bob.ID, Username: "bob"}}) {
+		t.Errorf("assignees = %+v, want alice and bob", task.Assignees)
+	}
+	if *task.Version != 2 {
+		t.Errorf("version = %d, want 2", *task.Version)
+	}
+
+	if _, err := s.SetTaskAssignees(ctx, assigned, []int{alice.ID}, 1); !errors.Is(err, ErrVersionMismatch) {
+		t.Errorf("SetTaskAssignees with a stale version error = %v, want ErrVersionMismatch", err)
+	}
+	_, err = s.SetTaskAssignees(ctx, assigned, []int{alice.ID, 999}, AnyVersion)
+	var constraint *ConstraintError
+	if !errors.As(err, &constraint) || !errors.Is(err, ErrInvalidReference) || constraint.Field != "user_ids" {
+		t.Errorf("SetTaskAssignees with a missing user error = %v, want invalid reference on user_ids", err)
+	}
+	if got := getTestTask(t, s, assigned).Assignees; len(got) != 2 {
+		t.Errorf("assignees after rejected update = %+v, want unchanged", got)
+	}
+
+	yes, no := true, false
+	tests := []struct {
+		name   string
+		filter TaskFilter
+		want   []int
+	}{
+		{"assignee", TaskFilter{Assignees: []string{"alice"}}, []int{assigned}},
+		{"any of assignees", TaskFilter{Assignees: []string{"carol", "bob"}}, []int{assigned}},
+		{"unassigned", TaskFilter{Unassigned: &yes}, []int{unassigned}},
+		{"assigned", TaskFilter{Unassigned: &no}, []int{assigned}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := s.ListTasks(ctx, TaskQuery{Filter: tt.filter})
+			if err != nil {
+				t.Fatalf("ListTasks: %v", err)
+			}
+			var got []int
+			for _, task := range page.Tasks {
+				got = append(got, *task.Id)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("tasks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// 空にすると担当者がいなくなる
+	task, err = s.SetTaskAssignees(ctx, assigned, nil, AnyVersion)
+	if err != nil || len(task.Assignees) != 0 {
+		t.Errorf("SetTaskAssignees(nil) = %+v, %v, want no assignees", task.Assignees, err)
+	}
+}
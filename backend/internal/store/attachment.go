@@ -0,0 +1,53 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// AttachmentStore はタスクの添付ファイルのメタデータの永続化を抽象化します
+// 本体は blob.Store に保存し、ここでは本体のキー（BlobKey）だけを保持します
+// 添付ファイルはタスクと一緒にゴミ箱に入り、タスクを完全に削除するとメタデータも削除されます
+// （本体は参照されなくなった後で blob.Sweeper が削除します）
+type AttachmentStore interface {
+	// ListAttachments はタスクの添付ファイルを古い順に返します
+	ListAttachments(ctx context.Context, taskID int) ([]api.Attachment, error)
+	// GetAttachment は添付ファイルのメタデータを返します
+	GetAttachment(ctx context.Context, taskID, id int) (AttachmentEntity, error)
+	// CreateAttachment はコンテキストの変更者をアップロードした人として、保存済みの本体のメタデータを追加します
+	CreateAttachment(ctx context.Context, e AttachmentEntity) (api.Attachment, error)
+	// DeleteAttachment はメタデータを削除し、削除したメタデータを返します（本体は呼び出し側で削除します）
+	DeleteAttachment(ctx context.Context, taskID, id int) (AttachmentEntity, error)
+	// UnreferencedBlobs は keys のうち、どの添付ファイルからも参照されていない本体のキーを返します
+	UnreferencedBlobs(ctx context.Context, keys []string) ([]string, error)
+}
+
+// AttachmentEntity はデータベースの添付ファイルを表すエンティティです
+type AttachmentEntity struct {
+	ID          int    `db:"id"`
+	TaskID      int    `db:"task_id"`
+	Filename    string `db:"filename"`
+	ContentType string `db:"content_type"`
+	Size        int64  `db:"size"`
+	// Checksum は本体の SHA-256 を16進数で表したものです
+	Checksum   string    `db:"checksum"`
+	BlobKey    string    `db:"blob_key"`
+	UploadedBy *string   `db:"uploaded_by"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// ToAPIAttachment はAttachmentEntityをapi.Attachmentに変換します
+func (e AttachmentEntity) ToAPIAttachment() api.Attachment {
+	return api.Attachment{
+		Id:          e.ID,
+		TaskId:      e.TaskID,
+		Filename:    e.Filename,
+		ContentType: e.ContentType,
+		Size:        e.Size,
+		Checksum:    e.Checksum,
+		UploadedBy:  e.UploadedBy,
+		CreatedAt:   e.CreatedAt,
+	}
+}
@@ -0,0 +1,243 @@
+package store
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/requestid"
+)
+
+type actorKey struct{}
+
+// WithActor は変更履歴に記録する変更者を設定したコンテキストを返します
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// コンテキストから変更者を取り出す（設定されていない場合はnil）
+func actorFromContext(ctx context.Context) *string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return optionalString(actor)
+}
+
+// コンテキストからリクエストIDを取り出す（設定されていない場合はnil）
+func requestIDFromContext(ctx context.Context) *string {
+	return optionalString(requestid.FromContext(ctx))
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// AuditQuery は変更履歴の取得条件です
+// 空のフィールドは条件に含めず、指定した条件はすべてANDで結合します
+type AuditQuery struct {
+	// EntityType, EntityID は変更の対象です（EntityID が0の場合はすべて）
+	EntityType string
+	EntityID   int
+	// Actions はいずれかの操作に一致する履歴に絞り込みます
+	Actions   []string
+	Actor     string
+	CreatedAt TimeRange
+	Page      int
+	Limit     int
+	// Member を指定した場合は、そのユーザーがメンバーとして参加しているプロジェクトと、そのタスク・ラベルの履歴に絞り込みます
+	// 完全に削除したタスクやラベルの履歴は含みません
+	Member *MemberScope
+}
+
+// AuditPage は変更履歴の1ページ分の結果です（新しい順）
+type AuditPage struct {
+	Entries []api.AuditEntry
+	// Total はページングに関係なく条件に一致する履歴の総数です
+	Total int
+}
+
+// PageSize はページサイズを既定値と上限の範囲に収めて返します
+func (q AuditQuery) PageSize() int {
+	return clampPageSize(q.Limit)
+}
+
+func (q AuditQuery) offset() int {
+	if q.Page < 1 {
+		return 0
+	}
+	return (q.Page - 1) * q.PageSize()
+}
+
+// 変更履歴が条件に一致するかを判定（PostgresStoreの条件と同じ意味）
+func (q AuditQuery) matches(e api.AuditEntry) bool {
+	if q.EntityType != "" && string(e.EntityType) != q.EntityType {
+		return false
+	}
+	if q.EntityID != 0 && e.EntityId != q.EntityID {
+		return false
+	}
+	if len(q.Actions) > 0 && !containsString(q.Actions, string(e.Action)) {
+		return false
+	}
+	if q.Actor != "" && (e.Actor == nil || *e.Actor != q.Actor) {
+		return false
+	}
+	return q.CreatedAt.contains(&e.CreatedAt)
+}
+
+// auditRecord は書き込みと同じトランザクションで記録する1件の変更です
+type auditRecord struct {
+	entityType api.AuditEntryEntityType
+	entityID   int
+	action     api.AuditEntryAction
+	changes    map[string]api.AuditChange
+}
+
+// 値が変わらなかった更新は記録しない
+func (r auditRecord) empty() bool {
+	return r.action == api.Update && len(r.changes) == 0
+}
+
+// タスクの変更を記録する（作成では before、完全な削除では after が nil）
+func taskChange(action api.AuditEntryAction, before, after *TaskEntity) auditRecord {
+	r := auditRecord{entityType: api.AuditEntryEntityTypeTask, action: action}
+	if before != nil {
+		r.entityID = before.ID
+	} else {
+		r.entityID = after.ID
+	}
+	r.changes = diffFields(taskAuditFields(before), taskAuditFields(after))
+	return r
+}
+
+// タスクのラベルの付け替えを記録する（ラベルIDは昇順）
+func taskLabelsChange(taskID int, before, after []int) auditRecord {
+	r := auditRecord{entityType: api.AuditEntryEntityTypeTask, entityID: taskID, action: api.Update}
+	r.changes = diffFields(map[string]interface{}{"label_ids": before}, map[string]interface{}{"label_ids": after})
+	return r
+}
+
+// タスクの依存先の付け替えを記録する（ID は昇順）
+func taskDependenciesChange(taskID int, before, after []int) auditRecord {
+	r := auditRecord{entityType: api.AuditEntryEntityTypeTask, entityID: taskID, action: api.Update}
+	r.changes = diffFields(map[string]interface{}{"depends_on": before}, map[string]interface{}{"depends_on": after})
+	return r
+}
+
+// タスクの担当者の付け替えを記録する（ユーザーID は昇順）
+func taskAssigneesChange(taskID int, before, after []int) auditRecord {
+	r := auditRecord{entityType: api.AuditEntryEntityTypeTask, entityID: taskID, action: api.Update}
+	r.changes = diffFields(map[string]interface{}{"assignee_ids": before}, map[string]interface{}{"assignee_ids": after})
+	return r
+}
+
+// ラベルの変更を記録する（作成では before、削除では after が nil）
+func labelChange(action api.AuditEntryAction, before, after *api.Label) auditRecord {
+	r := auditRecord{entityType: api.AuditEntryEntityTypeLabel, action: action}
+	if before != nil {
+		r.entityID = before.ID
+	} else {
+		r.entityID = after.ID
+	}
+	r.changes = diffFields(labelAuditFields(before), labelAuditFields(after))
+	return r
+}
+
+// プロジェクトの変更を記録する（作成では before が nil）
+func projectChange(action api.AuditEntryAction, before, after *api.Project) auditRecord {
+	r := auditRecord{entityType: api.AuditEntryEntityTypeProject, action: action, entityID: after.Id}
+	r.changes = diffFields(projectAuditFields(before), projectAuditFields(after))
+	return r
+}
+
+// 履歴に記録するタスクの項目（日時はUTCのRFC 3339で記録する）
+func taskAuditFields(e *TaskEntity) map[string]interface{} {
+	if e == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"name":        e.Name,
+		"description": stringOrNil(e.Description),
+		"start_date":  timeOrNil(e.StartDate),
+		"end_date":    timeOrNil(e.EndDate),
+		"priority":    e.Priority,
+		"status":      e.Status,
+		"parent_id":   intOrNil(e.ParentID),
+		"project_id":  e.ProjectID,
+		"deleted_at":  timeOrNil(e.DeletedAt),
+
+		"recurrence_rule":     stringOrNil(e.RecurrenceRule),
+		"recurrence_timezone": stringOrNil(e.RecurrenceTimezone),
+		"series_id":           intOrNil(e.SeriesID),
+	}
+}
+
+// 履歴に記録するラベルの項目
+func labelAuditFields(l *api.Label) map[string]interface{} {
+	if l == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"name":       l.Name,
+		"color":      l.Color,
+		"project_id": l.ProjectId,
+	}
+}
+
+// 履歴に記録するプロジェクトの項目
+func projectAuditFields(p *api.Project) map[string]interface{} {
+	if p == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"name":        p.Name,
+		"description": stringOrNil(p.Description),
+		"archived_at": timeOrNil(p.ArchivedAt),
+	}
+}
+
+// 値が異なる項目だけを変更前後の組にする（どちらも null の項目は含めない）
+func diffFields(before, after map[string]interface{}) map[string]api.AuditChange {
+	changes := make(map[string]api.AuditChange)
+	for _, fields := range []map[string]interface{}{before, after} {
+		for name := range fields {
+			oldValue, newValue := before[name], after[name]
+			if _, done := changes[name]; done || reflect.DeepEqual(oldValue, newValue) {
+				continue
+			}
+			changes[name] = api.AuditChange{Old: oldValue, New: newValue}
+		}
+	}
+	return changes
+}
+
+func stringOrNil(s *string) interface{} {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+func intOrNil(i *int) interface{} {
+	if i == nil {
+		return nil
+	}
+	return *i
+}
+
+func timeOrNil(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// 重複を除いて昇順に並べたラベルIDを返す（空の場合も空の配列として記録する）
+func sortedIDs(ids []int) []int {
+	sorted := append([]int{}, uniqueInts(ids)...)
+	sort.Ints(sorted)
+	return sorted
+}
@@ -0,0 +1,114 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/requestid"
+)
+
+func TestDiffFields(t *testing.T) {
+	before := map[string]interface{}{"name": "a", "status": "NotStarted", "description": nil, "label_ids": []int{1}}
+	after := map[string]interface{}{"name": "a", "status": "Completed", "description": nil, "label_ids": []int{1, 2}}
+	want := map[string]api.AuditChange{
+		"status":    {Old: "NotStarted", New: "Completed"},
+		"label_ids": {Old: []int{1}, New: []int{1, 2}},
+	}
+	if got := diffFields(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffFields = %+v, want %+v", got, want)
+	}
+
+	// 作成では null でない項目だけを記録する
+	created := diffFields(nil, map[string]interface{}{"name": "a", "description": nil})
+	if want := map[string]api.AuditChange{"name": {Old: nil, New: "a"}}; !reflect.DeepEqual(created, want) {
+		t.Errorf("diffFields(nil, ...) = %+v, want %+v", created, want)
+	}
+}
+
+func TestMemoryStoreTaskHistory(t *testing.T) {
+	ctx := requestid.NewContext(WithActor(context.Background(), "alice"), "req-1")
+	s := NewMemoryStore(Options{})
+	id, err := s.CreateTask(ctx, DefaultProjectID, taskInput("report", "Middle", "NotStarted"))
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	status := "Completed"
+	if _, err := s.PatchTask(ctx, id, TaskPatch{Status: &status}, AnyVersion); err != nil {
+		t.Fatalf("PatchTask: %v", err)
+	}
+	// 値の変わらない更新は記録しない
+	if _, err := s.PatchTask(ctx, id, TaskPatch{Status: &status}, AnyVersion); err != nil {
+		t.Fatalf("PatchTask: %v", err)
+	}
+	if err := s.SetTaskLabels(ctx, id, []int{2, 1}, AnyVersion); err != nil {
+		t.Fatalf("SetTaskLabels: %v", err)
+	}
+	if err := s.DeleteTask(ctx, id, AnyVersion); err != nil {
+		t.Fatalf("DeleteTask: %v", err)
+	}
+
+	page, err := s.TaskHistory(ctx, id, AuditQuery{})
+	if err != nil {
+		t.Fatalf("TaskHistory: %v", err)
+	}
+	var got []string
+	for _, e := range page.Entries {
+		fields := make([]string, 0, len(e.Changes))
+		for name := range e.Changes {
+			fields = append(fields, name)
+		}
+		sort.Strings(fields)
+		got = append(got, fmt.Sprintf("%s%v", e.Action, fields))
+		if e.Actor == nil || *e.Actor != "alice" || e.RequestId == nil || *e.RequestId != "req-1" {
+			t.Errorf("%s entry: actor = %v, request id = %v", e.Action, e.Actor, e.RequestId)
+		}
+	}
+	// 新しい順に並ぶ
+	want := "[delete[deleted_at] update[label_ids] update[status] create[name priority project_id status]]"
+	if fmt.Sprint(got) != want || page.Total != 4 {
+		t.Errorf("history = %v (total %d), want %s", got, page.Total, want)
+	}
+	if change := page.Entries[1].Changes["label_ids"]; !reflect.DeepEqual(change.New, []int{1, 2}) {
+		t.Errorf("label_ids change = %+v, want new [1 2]", change)
+	}
+}
+
+func TestMemoryStoreListAudit(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{})
+	taskID := newTestTask(t, s, taskInput("report", "Middle", "NotStarted"))
+	labelID, err := s.CreateLabel(WithActor(ctx, "bob"), DefaultProjectID, api.LabelInput{Name: "bug", Color: "#FF0000"})
+	if err != nil {
+		t.Fatalf("CreateLabel: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		query AuditQuery
+		want  string
+	}{
+		{"all", AuditQuery{}, fmt.Sprintf("[label/%d task/%d]", labelID, taskID)},
+		{"entity type", AuditQuery{EntityType: "task"}, fmt.Sprintf("[task/%d]", taskID)},
+		{"actor", AuditQuery{Actor: "bob"}, fmt.Sprintf("[label/%d]", labelID)},
+		{"action", AuditQuery{Actions: []string{"delete"}}, "[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := s.ListAudit(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("ListAudit: %v", err)
+			}
+			got := []string{}
+			for _, e := range page.Entries {
+				got = append(got, fmt.Sprintf("%s/%d", e.EntityType, e.EntityId))
+			}
+			if fmt.Sprint(got) != tt.want {
+				t.Errorf("entries = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
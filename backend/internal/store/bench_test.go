@@ -0,0 +1,102 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/migrate"
+)
+
+// benchDSNEnv は PostgresStore のベンチマークに使うデータベースの接続文字列を指定する環境変数です
+// 設定しない場合、PostgresStore のベンチマークはスキップします
+// ベンチマークはタスクを追加するため、使い捨てのデータベースを指定してください
+const benchDSNEnv = "STORE_BENCH_DSN"
+
+// ベンチマークで作成するタスクの数
+const benchTaskCount = 5000
+
+// ベンチマークで試すページサイズと、カーソルより前にあるタスクの数
+var (
+	benchPageSizes    = []int{20, 100, 1000}
+	benchCursorDepths = []int{0, 1000, 4000}
+)
+
+func BenchmarkListTasks(b *testing.B) {
+	b.Run("memory", func(b *testing.B) {
+		benchmarkListTasks(b, NewMemoryStore(Options{}))
+	})
+	b.Run("postgres", func(b *testing.B) {
+		dsn := os.Getenv(benchDSNEnv)
+		if dsn == "" {
+			b.Skipf("%s is not set", benchDSNEnv)
+		}
+		db, err := sqlx.Connect("postgres", dsn)
+		if err != nil {
+			b.Fatalf("connect: %v", err)
+		}
+		b.Cleanup(func() { db.Close() })
+		migrator, err := migrate.New(db)
+		if err != nil {
+			b.Fatalf("migrate: %v", err)
+		}
+		if err := migrator.Up(context.Background()); err != nil {
+			b.Fatalf("migrate: %v", err)
+		}
+		benchmarkListTasks(b, NewPostgresStore(db, Options{}))
+	})
+}
+
+// benchmarkListTasks は実行ごとに異なる名前のタスクを作成し、ページサイズとカーソルの位置ごとに一覧の取得を計測します
+func benchmarkListTasks(b *testing.B, s TaskStore) {
+	ctx := context.Background()
+	prefix := fmt.Sprintf("bench-%d", time.Now().UnixNano())
+	priorities := []api.TaskInputPriority{api.TaskInputPriorityHigh, api.TaskInputPriorityMiddle, api.TaskInputPriorityLow}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < benchTaskCount; i++ {
+		// 並び順のキーが重なるよう、終了日は100件ごとに同じ日にする
+		end := start.AddDate(0, 0, i%100)
+		if _, err := s.CreateTask(ctx, DefaultProjectID, api.TaskInput{
+			Name:     fmt.Sprintf("%s task %d", prefix, i),
+			Priority: priorities[i%len(priorities)],
+			Status:   api.TaskInputStatusNotStarted,
+			EndDate:  &end,
+		}); err != nil {
+			b.Fatalf("CreateTask: %v", err)
+		}
+	}
+	// 以前の実行で作成したタスクを含めないよう、名前で絞り込む
+	filter := TaskFilter{Name: prefix}
+
+	for _, limit := range benchPageSizes {
+		for _, depth := range benchCursorDepths {
+			// depth 件のタスクを読み飛ばしたカーソルを用意する
+			cursor := ""
+			for read := 0; read < depth; read += limit {
+				page, err := s.ListTasks(ctx, TaskQuery{Filter: filter, Limit: limit, Cursor: cursor})
+				if err != nil {
+					b.Fatalf("ListTasks: %v", err)
+				}
+				cursor = page.NextCursor
+			}
+
+			b.Run(fmt.Sprintf("limit=%d/depth=%d", limit, depth), func(b *testing.B) {
+				query := TaskQuery{Filter: filter, Limit: limit, Cursor: cursor}
+				for i := 0; i < b.N; i++ {
+					page, err := s.ListTasks(ctx, query)
+					if err != nil {
+						b.Fatalf("ListTasks: %v", err)
+					}
+					if len(page.Tasks) != limit {
+						b.Fatalf("ListTasks returned %d tasks, want %d", len(page.Tasks), limit)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package store
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// 一括操作の種類
+const (
+	BulkUpdateStatus   = "update_status"
+	BulkUpdatePriority = "update_priority"
+	BulkAddLabels      = "add_labels"
+	BulkRemoveLabels   = "remove_labels"
+	BulkShiftDates     = "shift_dates"
+	BulkDelete         = "delete"
+)
+
+// MaxBulkTasks は1回の一括操作で対象にできるタスクの上限です
+const MaxBulkTasks = 1000
+
+// BulkOperation はタスクの一括操作です
+// 対象は IDs と Filter のどちらか一方で指定します（Filter の並び順と関係なくIDの順に処理します）
+type BulkOperation struct {
+	Action string
+	IDs    []int
+	Filter *TaskFilter
+	// Status, Priority, LabelIDs, ShiftDays は Action に応じた操作の値です
+	Status    string
+	Priority  string
+	LabelIDs  []int
+	ShiftDays int
+	// DryRun が true の場合は各タスクの結果を返すだけで、変更を確定しません
+	DryRun bool
+}
+
+// BulkItemResult は一括操作のタスク1件分の結果です
+// 失敗したタスクの変更だけを取り消し、他のタスクの変更は確定します
+type BulkItemResult struct {
+	ID int
+	// Task は操作後のタスクです（失敗した場合とゴミ箱に移動した場合は nil）
+	Task *api.Task
+	Err  error
+}
+
+// 対象のタスクIDを重複を除いて返す
+func (op BulkOperation) targetIDs() []int {
+	return uniqueInts(op.IDs)
+}
+
+// 状態・優先度の変更と日付の移動を部分更新として表す
+func (op BulkOperation) patch(e TaskEntity) TaskPatch {
+	var patch TaskPatch
+	switch op.Action {
+	case BulkUpdateStatus:
+		patch.Status = &op.Status
+	case BulkUpdatePriority:
+		patch.Priority = &op.Priority
+	case BulkShiftDates:
+		// 未設定の日付はそのままにする
+		shift := func(t *time.Time) Nullable[time.Time] {
+			if t == nil {
+				return Nullable[time.Time]{}
+			}
+			shifted := t.AddDate(0, 0, op.ShiftDays)
+			return Nullable[time.Time]{Set: true, Value: &shifted}
+		}
+		patch.StartDate = shift(e.StartDate)
+		patch.EndDate = shift(e.EndDate)
+	}
+	return patch
+}
+
+// ラベルの追加・削除後のラベルIDを昇順で返す
+func (op BulkOperation) labels(current []int) []int {
+	var labels []int
+	switch op.Action {
+	case BulkAddLabels:
+		labels = append(append(labels, current...), op.LabelIDs...)
+	case BulkRemoveLabels:
+		for _, id := range current {
+			if !slices.Contains(op.LabelIDs, id) {
+				labels = append(labels, id)
+			}
+		}
+	}
+	return sortedIDs(labels)
+}
+
+// 絞り込み条件に一致するタスクが上限を超えた場合のエラー
+func tooManyBulkTasks() error {
+	return &ConstraintError{
+		Kind:       ErrConstraint,
+		Field:      "filter",
+		Constraint: "bulk_max_tasks",
+		Err:        fmt.Errorf("filter matches more than %d tasks", MaxBulkTasks),
+	}
+}
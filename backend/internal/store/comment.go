@@ -0,0 +1,140 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/markdown"
+)
+
+// MaxCommentLength はコメントの本文の長さの上限（文字数）です
+const MaxCommentLength = 10000
+
+// CommentQuery はコメント一覧のページングの指定です
+type CommentQuery struct {
+	Page  int
+	Limit int
+}
+
+// CommentPage はコメント一覧の1ページ分の結果です（古い順）
+type CommentPage struct {
+	Comments []api.Comment
+	// Total はページングに関係なくタスクに付いたコメントの総数です
+	Total int
+}
+
+// PageSize はページサイズを既定値と上限の範囲に収めて返します
+func (q CommentQuery) PageSize() int {
+	return clampPageSize(q.Limit)
+}
+
+func (q CommentQuery) offset() int {
+	if q.Page < 1 {
+		return 0
+	}
+	return (q.Page - 1) * q.PageSize()
+}
+
+// CommentStore はタスクのコメントの永続化を抽象化します
+// コメントはタスクと一緒にゴミ箱に入り、タスクを完全に削除すると一緒に削除されます
+// ゴミ箱のタスクのコメントは、どのメソッドからも存在しないものとして扱います
+// コメント数はタスクの表現の一部なので、追加と削除ではタスクの版を進めます
+type CommentStore interface {
+	// ListComments はタスクのコメントを古い順に返します
+	ListComments(ctx context.Context, taskID int, query CommentQuery) (CommentPage, error)
+	// CreateComment はコンテキストの変更者を投稿者としてコメントを追加します
+	CreateComment(ctx context.Context, taskID int, body string) (api.Comment, error)
+	// UpdateComment はコメントの本文を書き換え、変更前の本文を編集履歴に残します
+	// 投稿者が記録されたコメントを投稿者以外が変更しようとした場合は ErrForbidden を返します
+	UpdateComment(ctx context.Context, taskID, id int, body string, version int) (api.Comment, error)
+	// DeleteComment はコメントを編集履歴ごと削除します（投稿者の確認は UpdateComment と同じ）
+	DeleteComment(ctx context.Context, taskID, id int, version int) error
+	// CommentHistory はコメントの過去の本文を新しい順に返します
+	CommentHistory(ctx context.Context, taskID, id int) ([]api.CommentRevision, error)
+}
+
+// CommentEntity はデータベースのコメントを表すエンティティです
+type CommentEntity struct {
+	ID     int     `db:"id"`
+	TaskID int     `db:"task_id"`
+	Author *string `db:"author"`
+	Body   string  `db:"body"`
+	// EditedAt は最後に本文を編集した日時です（編集していなければnil）
+	EditedAt  *time.Time `db:"edited_at"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+	Version   int        `db:"version"`
+}
+
+// ToAPIComment はCommentEntityをapi.Commentに変換します
+func (e CommentEntity) ToAPIComment() api.Comment {
+	return api.Comment{
+		Id:        e.ID,
+		TaskId:    e.TaskID,
+		Author:    e.Author,
+		Body:      e.Body,
+		EditedAt:  e.EditedAt,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+		Version:   e.Version,
+	}
+}
+
+// commentRevision は編集前のコメントの本文です
+type commentRevision struct {
+	CommentID int    `db:"comment_id"`
+	Version   int    `db:"version"`
+	Body      string `db:"body"`
+	// CreatedAt はこの本文を書いた日時です
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (r commentRevision) toAPICommentRevision() api.CommentRevision {
+	return api.CommentRevision{Version: r.Version, Body: r.Body, CreatedAt: r.CreatedAt}
+}
+
+// revisionOf は編集前のコメントを編集履歴の1件にします
+func revisionOf(e CommentEntity) commentRevision {
+	written := e.CreatedAt
+	if e.EditedAt != nil {
+		written = *e.EditedAt
+	}
+	return commentRevision{CommentID: e.ID, Version: e.Version, Body: e.Body, CreatedAt: written}
+}
+
+// commentBody は Markdown の本文を無害化し、空でなく長すぎないことを確認します
+func commentBody(body string) (string, error) {
+	body = markdown.Sanitize(body)
+	if body == "" {
+		return "", invalidComment("body must not be empty")
+	}
+	if n := utf8.RuneCountInString(body); n > MaxCommentLength {
+		return "", invalidComment(fmt.Sprintf("body must be at most %d characters (got %d)", MaxCommentLength, n))
+	}
+	return body, nil
+}
+
+// checkAuthor はコンテキストの変更者がコメントの投稿者かどうかを確認します
+// 投稿者が記録されていないコメント（認証なしで投稿したもの）は誰でも変更できます
+func checkAuthor(ctx context.Context, e CommentEntity) error {
+	if e.Author == nil {
+		return nil
+	}
+	if actor := actorFromContext(ctx); actor == nil || *actor != *e.Author {
+		return fmt.Errorf("%w: comment %d can only be changed by its author", ErrForbidden, e.ID)
+	}
+	return nil
+}
+
+func invalidComment(message string) error {
+	return &ConstraintError{
+		Kind:       ErrConstraint,
+		Field:      "body",
+		Constraint: "task_comments_body",
+		Err:        errors.New(message),
+	}
+}
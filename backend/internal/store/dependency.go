@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// DependencyStore はタスクの依存関係の永続化を抽象化します
+// 依存関係はタスクの表現の一部として扱い、追加・削除のたびにタスクの版を進めます
+type DependencyStore interface {
+	// AddTaskDependency は taskID のタスクが dependsOnID のタスクの完了を待つようにし、更新後のタスクを返します
+	// 依存関係が循環する場合は ErrConstraint、既に存在する場合は ErrConflict を返します
+	AddTaskDependency(ctx context.Context, taskID, dependsOnID int, version int) (api.Task, error)
+	// RemoveTaskDependency は依存関係を削除します。存在しない場合は ErrNotFound を返します
+	RemoveTaskDependency(ctx context.Context, taskID, dependsOnID int, version int) error
+}
+
+// dependency はタスクが依存するタスクとその状態です
+type dependency struct {
+	TaskID      int    `db:"task_id"`
+	DependsOnID int    `db:"depends_on_id"`
+	Status      string `db:"status"`
+}
+
+// setDependencies は依存するタスク（ゴミ箱のものを除く）から depends_on, blocked_by, blocked を設定します
+func setDependencies(task *api.Task, deps []dependency) {
+	task.DependsOn = nil
+	task.BlockedBy = nil
+	for _, d := range deps {
+		task.DependsOn = append(task.DependsOn, d.DependsOnID)
+		if d.Status != string(api.TaskStatusCompleted) {
+			task.BlockedBy = append(task.BlockedBy, d.DependsOnID)
+		}
+	}
+	blocked := len(task.BlockedBy) > 0
+	task.Blocked = &blocked
+}
+
+// startsWork は状態が InProgress に変わるかどうか
+func startsWork(before, after TaskEntity) bool {
+	return after.Status == string(api.TaskStatusInProgress) && before.Status != after.Status
+}
+
+// 完了していない依存先があるタスクを開始しようとした場合のエラー
+func blockedTask(taskID int, blockedBy []int) error {
+	return fmt.Errorf("%w: task %d is blocked by tasks %v", ErrTaskBlocked, taskID, blockedBy)
+}
+
+// 依存先に指定したタスクが存在しないかゴミ箱にある場合のエラー
+func invalidDependency(dependsOnID int) error {
+	return &ConstraintError{
+		Kind:       ErrInvalidReference,
+		Field:      "depends_on_id",
+		Constraint: "task_dependencies_depends_on_id_fkey",
+		Err:        fmt.Errorf("task %d does not exist", dependsOnID),
+	}
+}
+
+// 依存関係が循環する場合のエラー
+func dependencyCycle(taskID, dependsOnID int) error {
+	return &ConstraintError{
+		Kind:       ErrConstraint,
+		Field:      "depends_on_id",
+		Constraint: "task_dependencies_cycle",
+		Err:        fmt.Errorf("task %d already depends on task %d directly or indirectly", dependsOnID, taskID),
+	}
+}
+
+// 同じ依存関係が既にある場合のエラー
+func duplicateDependency(taskID, dependsOnID int) error {
+	return &ConstraintError{
+		Kind:       ErrConflict,
+		Field:      "depends_on_id",
+		Constraint: "task_dependencies_pkey",
+		Err:        fmt.Errorf("task %d already depends on task %d", taskID, dependsOnID),
+	}
+}
+
+// changesBlocking は before から after への変更で、このタスクに依存するタスクの depends_on, blocked_by, blocked が変わりうるかどうか
+// before が nil の場合は作成、after が nil の場合は完全な削除を表します
+func changesBlocking(before, after *TaskEntity) bool {
+	active := func(e *TaskEntity) bool {
+		return e != nil && e.DeletedAt == nil
+	}
+	if active(before) != active(after) {
+		return true
+	}
+	return active(before) && before.Status != after.Status
+}
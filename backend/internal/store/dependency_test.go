@@ -0,0 +1,85 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+func TestMemoryStoreDependencyCycle(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{})
+	a := newTestTask(t, s, taskInput("a", "Middle", "NotStarted"))
+	b := newTestTask(t, s, taskInput("b", "Middle", "NotStarted"))
+	c := newTestTask(t, s, taskInput("c", "Middle", "NotStarted"))
+	// c は b を、b は a を待つ
+	for _, dep := range [][2]int{{b, a}, {c, b}} {
+		if _, err := s.AddTaskDependency(ctx, dep[0], dep[1], AnyVersion); err != nil {
+			t.Fatalf("AddTaskDependency(%d, %d): %v", dep[0], dep[1], err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		task, dep int
+		want      error
+	}{
+		{"itself", a, a, ErrConstraint},
+		{"direct cycle", a, b, ErrConstraint},
+		{"indirect cycle", a, c, ErrConstraint},
+		{"duplicate", b, a, ErrConflict},
+		{"missing task", a, 999, ErrInvalidReference},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.AddTaskDependency(ctx, tt.task, tt.dep, AnyVersion)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("AddTaskDependency(%d, %d) = %v, want %v", tt.task, tt.dep, err, tt.want)
+			}
+		})
+	}
+	// 循環しない依存関係は追加できる
+	if _, err := s.AddTaskDependency(ctx, c, a, AnyVersion); err != nil {
+		t.Errorf("AddTaskDependency(c, a): %v", err)
+	}
+	if err := s.RemoveTaskDependency(ctx, a, b, AnyVersion); !errors.Is(err, ErrNotFound) {
+		t.Errorf("RemoveTaskDependency of a missing dependency = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryStoreBlockedTask(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{EnforceDependencies: true})
+	design := newTestTask(t, s, taskInput("design", "Middle", "NotStarted"))
+	review := newTestTask(t, s, taskInput("review", "Middle", "NotStarted"))
+	build := newTestTask(t, s, taskInput("build", "Middle", "NotStarted"))
+	for _, dep := range []int{design, review} {
+		if _, err := s.AddTaskDependency(ctx, build, dep, AnyVersion); err != nil {
+			t.Fatalf("AddTaskDependency: %v", err)
+		}
+	}
+
+	task := getTestTask(t, s, build)
+	if !*task.Blocked || fmt.Sprint(task.DependsOn) != fmt.Sprint([]int{design, review}) || len(task.BlockedBy) != 2 {
+		t.Fatalf("task = blocked %v, depends on %v, blocked by %v", *task.Blocked, task.DependsOn, task.BlockedBy)
+	}
+	inProgress := string(api.TaskStatusInProgress)
+	if _, err := s.PatchTask(ctx, build, TaskPatch{Status: &inProgress}, AnyVersion); !errors.Is(err, ErrTaskBlocked) {
+		t.Fatalf("PatchTask to InProgress while blocked = %v, want ErrTaskBlocked", err)
+	}
+
+	// 依存先を完了するかゴミ箱に移動すると、待つ必要がなくなる
+	setTestStatus(t, s, design, api.TaskStatusCompleted)
+	if err := s.DeleteTask(ctx, review, AnyVersion); err != nil {
+		t.Fatalf("DeleteTask: %v", err)
+	}
+	if task := getTestTask(t, s, build); *task.Blocked || len(task.BlockedBy) != 0 {
+		t.Fatalf("task = blocked %v, blocked by %v, want unblocked", *task.Blocked, task.BlockedBy)
+	}
+	if _, err := s.PatchTask(ctx, build, TaskPatch{Status: &inProgress}, AnyVersion); err != nil {
+		t.Errorf("PatchTask to InProgress: %v", err)
+	}
+}
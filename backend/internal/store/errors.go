@@ -0,0 +1,60 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ストアが返すエラーの分類
+// 呼び出し側は errors.Is で判定します
+var (
+	// ErrNotFound は対象のレコードが存在しない場合に返されます
+	ErrNotFound = errors.New("store: record not found")
+	// ErrConflict は一意制約に違反した場合に返されます
+	ErrConflict = errors.New("store: conflicting record")
+	// ErrInvalidReference は存在しないレコードを参照しようとした場合に返されます
+	ErrInvalidReference = errors.New("store: referenced record does not exist")
+	// ErrConstraint はCHECK制約やNOT NULL制約などに違反した場合に返されます
+	ErrConstraint = errors.New("store: constraint violated")
+	// ErrVersionMismatch は指定した版が現在の版と一致しない場合に返されます
+	ErrVersionMismatch = errors.New("store: version mismatch")
+	// ErrHasSubtasks は削除の方針が restrict のときに、サブタスクを持つタスクを削除しようとした場合に返されます
+	ErrHasSubtasks = errors.New("store: task has subtasks")
+	// ErrTaskBlocked は依存関係を強制する設定のときに、完了していない依存先があるタスクを開始しようとした場合に返されます
+	ErrTaskBlocked = errors.New("store: task is blocked by incomplete dependencies")
+	// ErrForbidden は変更者に操作の権限がない場合に返されます（他人のコメントの編集など）
+	ErrForbidden = errors.New("store: forbidden")
+	// ErrInvalidCursor はページングのカーソルが不正な場合に返されます
+	ErrInvalidCursor = errors.New("store: invalid cursor")
+	// ErrInvalidSort は並び順の指定が不正な場合に返されます
+	ErrInvalidSort = errors.New("store: invalid sort")
+)
+
+// ConstraintError はデータベースの制約違反の詳細を保持します
+type ConstraintError struct {
+	// Kind は ErrConflict, ErrInvalidReference, ErrConstraint のいずれかです
+	Kind error
+	// Field は違反の原因となったリクエスト上の項目名です（不明な場合は空）
+	Field string
+	// Constraint は違反した制約の名前です
+	Constraint string
+	Err        error
+}
+
+func (e *ConstraintError) Error() string {
+	msg := e.Kind.Error()
+	if e.Constraint != "" {
+		msg += fmt.Sprintf(" (%s)", e.Constraint)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *ConstraintError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
@@ -0,0 +1,119 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// DeletePolicy はサブタスクを持つタスクをゴミ箱に移動したときの、サブタスクの扱いです
+type DeletePolicy string
+
+const (
+	// DeleteOrphan はサブタスクを最上位のタスクにします
+	DeleteOrphan DeletePolicy = "orphan"
+	// DeleteCascade はサブタスクも（孫以下も含めて）ゴミ箱に移動します
+	// 親を元に戻すと、一緒にゴミ箱に移動したサブタスクも元に戻ります
+	DeleteCascade DeletePolicy = "cascade"
+	// DeleteRestrict はサブタスクを持つタスクの削除を ErrHasSubtasks で拒否します
+	DeleteRestrict DeletePolicy = "restrict"
+)
+
+// Options はストアの動作の設定です
+type Options struct {
+	// DeletePolicy が空の場合は DeleteOrphan として扱います
+	DeletePolicy DeletePolicy
+	// EnforceDependencies が true の場合、完了していない依存先があるタスクを InProgress にすると ErrTaskBlocked を返します
+	EnforceDependencies bool
+}
+
+func (o Options) deletePolicy() DeletePolicy {
+	if o.DeletePolicy == "" {
+		return DeleteOrphan
+	}
+	return o.DeletePolicy
+}
+
+// 親に指定したタスクが存在しないかゴミ箱にある場合のエラー
+func invalidParent(parentID int) error {
+	return &ConstraintError{
+		Kind:       ErrInvalidReference,
+		Field:      "parent_id",
+		Constraint: "tasks_parent_id_fkey",
+		Err:        fmt.Errorf("parent task %d does not exist", parentID),
+	}
+}
+
+// 自分自身や自分のサブタスクを親にしようとした場合のエラー
+func parentCycle(taskID, parentID int) error {
+	return &ConstraintError{
+		Kind:       ErrConstraint,
+		Field:      "parent_id",
+		Constraint: "tasks_parent_cycle",
+		Err:        fmt.Errorf("task %d cannot be a subtask of itself or its subtask %d", taskID, parentID),
+	}
+}
+
+// 親が変わるかどうか
+func parentChanged(before, after TaskEntity) bool {
+	if before.ParentID == nil || after.ParentID == nil {
+		return before.ParentID != after.ParentID
+	}
+	return *before.ParentID != *after.ParentID
+}
+
+// 状態ごとのサブタスクの件数から集計を作成する（サブタスクがない場合はnil）
+func subtaskRollup(counts map[string]int) *api.SubtaskRollup {
+	r := api.SubtaskRollup{
+		NotStarted: counts[string(api.TaskStatusNotStarted)],
+		InProgress: counts[string(api.TaskStatusInProgress)],
+		Completed:  counts[string(api.TaskStatusCompleted)],
+	}
+	r.Total = r.NotStarted + r.InProgress + r.Completed
+	if r.Total == 0 {
+		return nil
+	}
+	r.CompletionPercentage = r.Completed * 100 / r.Total
+	return &r
+}
+
+// 親子関係のタスクを root を頂点とするツリーにする
+// tasks は root の子孫（ゴミ箱のものを除く）で、兄弟は tasks の順に並びます
+func buildTree(root api.Task, tasks []api.Task) api.Task {
+	children := make(map[int][]api.Task)
+	for _, task := range tasks {
+		if task.ParentId != nil {
+			children[*task.ParentId] = append(children[*task.ParentId], task)
+		}
+	}
+	var attach func(task api.Task) api.Task
+	attach = func(task api.Task) api.Task {
+		for _, child := range children[*task.Id] {
+			task.Children = append(task.Children, attach(child))
+		}
+		return task
+	}
+	return attach(root)
+}
+
+// rollupParents は before から after への変更でサブタスクの集計が変わる親タスクのIDを返します
+// before が nil の場合は作成、after が nil の場合は完全な削除を表します
+func rollupParents(before, after *TaskEntity) []int {
+	counted := func(e *TaskEntity) bool {
+		return e != nil && e.DeletedAt == nil && e.ParentID != nil
+	}
+	if counted(before) && counted(after) && *before.ParentID == *after.ParentID {
+		if before.Status == after.Status {
+			return nil
+		}
+		return []int{*after.ParentID}
+	}
+	var ids []int
+	if counted(before) {
+		ids = append(ids, *before.ParentID)
+	}
+	if counted(after) {
+		ids = append(ids, *after.ParentID)
+	}
+	return ids
+}
@@ -0,0 +1,113 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+func TestSubtaskRollup(t *testing.T) {
+	if r := subtaskRollup(nil); r != nil {
+		t.Errorf("subtaskRollup(nil) = %+v, want nil", r)
+	}
+	r := subtaskRollup(map[string]int{"NotStarted": 1, "InProgress": 1, "Completed": 1})
+	want := api.SubtaskRollup{Total: 3, NotStarted: 1, InProgress: 1, Completed: 1, CompletionPercentage: 33}
+	if r == nil || *r != want {
+		t.Errorf("subtaskRollup = %+v, want %+v", r, want)
+	}
+}
+
+func TestMemoryStoreParentCycle(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{})
+	root := newTestTask(t, s, taskInput("root", "Middle", "NotStarted"))
+	child := newTestSubtask(t, s, "child", root)
+	grandchild := newTestSubtask(t, s, "grandchild", child)
+
+	tests := []struct {
+		name   string
+		id     int
+		parent int
+		want   error
+	}{
+		{"itself", root, root, ErrConstraint},
+		{"its grandchild", root, grandchild, ErrConstraint},
+		{"missing parent", child, 999, ErrInvalidReference},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.PatchTask(ctx, tt.id, TaskPatch{ParentID: Nullable[int]{Set: true, Value: &tt.parent}}, AnyVersion)
+			var constraintErr *ConstraintError
+			if !errors.Is(err, tt.want) || !errors.As(err, &constraintErr) || constraintErr.Field != "parent_id" {
+				t.Errorf("PatchTask = %v, want %v on parent_id", err, tt.want)
+			}
+		})
+	}
+	// 親を外して最上位のタスクにできる
+	if _, err := s.PatchTask(ctx, grandchild, TaskPatch{ParentID: Nullable[int]{Set: true}}, AnyVersion); err != nil {
+		t.Errorf("PatchTask to remove the parent: %v", err)
+	}
+}
+
+func TestMemoryStoreDeletePolicy(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		policy      DeletePolicy
+		wantErr     error
+		childExists bool
+		childParent bool
+	}{
+		{"", nil, true, false},
+		{DeleteOrphan, nil, true, false},
+		{DeleteCascade, nil, false, false},
+		{DeleteRestrict, ErrHasSubtasks, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			s := NewMemoryStore(Options{DeletePolicy: tt.policy})
+			parent := newTestTask(t, s, taskInput("parent", "Middle", "NotStarted"))
+			child := newTestSubtask(t, s, "child", parent)
+			newTestSubtask(t, s, "grandchild", child)
+
+			if err := s.DeleteTask(ctx, parent, AnyVersion); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DeleteTask = %v, want %v", err, tt.wantErr)
+			}
+			task, err := s.GetTask(ctx, child)
+			if exists := err == nil; exists != tt.childExists {
+				t.Fatalf("GetTask(child) = %v, want exists %v", err, tt.childExists)
+			}
+			if err == nil && (task.ParentId != nil) != tt.childParent {
+				t.Errorf("child parent_id = %v, want set %v", task.ParentId, tt.childParent)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreTaskTree(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{})
+	root := newTestTask(t, s, taskInput("root", "Middle", "NotStarted"))
+	child := newTestSubtask(t, s, "child", root)
+	newTestSubtask(t, s, "grandchild", child)
+	trashed := newTestSubtask(t, s, "trashed", root)
+	if err := s.DeleteTask(ctx, trashed, AnyVersion); err != nil {
+		t.Fatalf("DeleteTask: %v", err)
+	}
+
+	tree, err := s.TaskTree(ctx, root)
+	if err != nil {
+		t.Fatalf("TaskTree: %v", err)
+	}
+	// ゴミ箱のサブタスクは含まない
+	if len(tree.Children) != 1 || *tree.Children[0].Name != "child" {
+		t.Fatalf("root children = %+v, want only child", tree.Children)
+	}
+	if grand := tree.Children[0].Children; len(grand) != 1 || *grand[0].Name != "grandchild" {
+		t.Errorf("child children = %+v, want grandchild", grand)
+	}
+	if _, err := s.TaskTree(ctx, 999); !errors.Is(err, ErrNotFound) {
+		t.Errorf("TaskTree(999) = %v, want ErrNotFound", err)
+	}
+}
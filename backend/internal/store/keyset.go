@@ -0,0 +1,216 @@
+package store
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// sortKind はソートキーの値の型です
+type sortKind int
+
+const (
+	kindString sortKind = iota
+	kindTime
+	kindInt
+	kindFloat
+)
+
+// sortKey はタスク一覧の並び順を構成する1つのキーです
+// NULLは昇順・降順のどちらでも末尾に並べます
+type sortKey struct {
+	name string
+	// column はORDER BY句と比較に使うSQL式です
+	column string
+	kind   sortKind
+	desc   bool
+	// reversed は指定の向きを反転するキーです（関連度のように大きい順が自然なキー）
+	reversed bool
+	// value はエンティティからキーの値を取り出します（NULLの場合はnil）
+	value func(e TaskEntity) interface{}
+}
+
+// cursor はキーセットページングの位置を表します
+// クライアントには base64 でエンコードした不透明な文字列として渡します
+type cursor struct {
+	// Order はカーソルを発行したときの並び順です
+	Order string `json:"o"`
+	// Values は直前のページの最後の行のソートキーの値です
+	Values []json.RawMessage `json:"v"`
+}
+
+// orderSignature は並び順を識別する文字列を返します
+func orderSignature(order []sortKey) string {
+	names := make([]string, len(order))
+	for i, key := range order {
+		names[i] = key.name
+		if key.desc {
+			names[i] = "-" + key.name
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// encodeCursor はエンティティの位置を指すカーソルを作成します
+func encodeCursor(order []sortKey, e TaskEntity) string {
+	c := cursor{Order: orderSignature(order)}
+	for _, key := range order {
+		raw, _ := json.Marshal(key.value(e))
+		c.Values = append(c.Values, raw)
+	}
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor はカーソルを検証し、ソートキーの値を取り出します
+func decodeCursor(order []sortKey, s string) ([]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if c.Order != orderSignature(order) || len(c.Values) != len(order) {
+		return nil, fmt.Errorf("%w: cursor was issued for a different sort order", ErrInvalidCursor)
+	}
+
+	values := make([]interface{}, len(order))
+	for i, key := range order {
+		if string(c.Values[i]) == "null" {
+			continue
+		}
+		var err error
+		switch key.kind {
+		case kindString:
+			var v string
+			err = json.Unmarshal(c.Values[i], &v)
+			values[i] = v
+		case kindTime:
+			var v time.Time
+			err = json.Unmarshal(c.Values[i], &v)
+			values[i] = v
+		case kindInt:
+			var v int
+			err = json.Unmarshal(c.Values[i], &v)
+			values[i] = v
+		case kindFloat:
+			var v float64
+			err = json.Unmarshal(c.Values[i], &v)
+			values[i] = v
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+		}
+	}
+	return values, nil
+}
+
+// orderByClause は並び順をORDER BY句に変換します
+func orderByClause(order []sortKey) string {
+	parts := make([]string, len(order))
+	for i, key := range order {
+		dir := "ASC"
+		if key.desc {
+			dir = "DESC"
+		}
+		parts[i] = fmt.Sprintf("%s %s NULLS LAST", key.column, dir)
+	}
+	return " ORDER BY " + strings.Join(parts, ", ")
+}
+
+// keysetCondition はカーソルより後ろの行に絞り込む条件を返します
+// placeholder は引数を登録してプレースホルダーを返す関数で、SQL上の出現順に呼び出します
+//
+// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ... の形に展開し、
+// NULLを末尾に並べる規則に合わせて IS NULL を組み合わせます
+func keysetCondition(order []sortKey, values []interface{}, placeholder func(arg interface{}) string) string {
+	var disjuncts []string
+	for i, key := range order {
+		if values[i] == nil {
+			// NULLより後ろには同じキーの値がないため、次のキーで比較する
+			continue
+		}
+		var conds []string
+		for j := 0; j < i; j++ {
+			conds = append(conds, equalsCondition(order[j], values[j], placeholder))
+		}
+		op := ">"
+		if key.desc {
+			op = "<"
+		}
+		conds = append(conds, fmt.Sprintf("(%s %s %s OR %s IS NULL)", key.column, op, placeholder(values[i]), key.column))
+		disjuncts = append(disjuncts, "("+strings.Join(conds, " AND ")+")")
+	}
+	if len(disjuncts) == 0 {
+		return "FALSE"
+	}
+	return "(" + strings.Join(disjuncts, " OR ") + ")"
+}
+
+// equalsCondition はキーがカーソルの値と等しい条件を返します
+func equalsCondition(key sortKey, v interface{}, placeholder func(arg interface{}) string) string {
+	if v == nil {
+		return key.column + " IS NULL"
+	}
+	return fmt.Sprintf("%s = %s", key.column, placeholder(v))
+}
+
+// compareEntities は並び順に従ってエンティティを比較します
+func compareEntities(order []sortKey, a, b TaskEntity) int {
+	for _, key := range order {
+		if c := compareValues(key, key.value(a), key.value(b)); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareToCursor はエンティティがカーソルの位置より後ろなら正の値を返します
+func compareToCursor(order []sortKey, e TaskEntity, values []interface{}) int {
+	for i, key := range order {
+		if c := compareValues(key, key.value(e), values[i]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareValues はキーの向きとNULLを末尾に並べる規則に従って値を比較します
+func compareValues(key sortKey, a, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+
+	var c int
+	switch key.kind {
+	case kindString:
+		c = strings.Compare(a.(string), b.(string))
+	case kindTime:
+		c = a.(time.Time).Compare(b.(time.Time))
+	case kindInt:
+		c = a.(int) - b.(int)
+	case kindFloat:
+		c = cmp.Compare(a.(float64), b.(float64))
+	}
+	if key.desc {
+		return -c
+	}
+	return c
+}
+
+func timeValue(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
@@ -0,0 +1,150 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// newPagingStore は優先度が同じ n 件のタスクを作成したストアを返します（IDの順に並ぶ）
+func newPagingStore(t *testing.T, n int) *MemoryStore {
+	t.Helper()
+	s := NewMemoryStore(Options{})
+	for i := 0; i < n; i++ {
+		newTestTask(t, s, taskInput(fmt.Sprintf("task %02d", i), "Middle", "NotStarted"))
+	}
+	return s
+}
+
+func taskNames(tasks []api.Task) []string {
+	names := make([]string, len(tasks))
+	for i, task := range tasks {
+		names[i] = *task.Name
+	}
+	return names
+}
+
+func TestTaskQueryPageSize(t *testing.T) {
+	tests := []struct {
+		limit int
+		want  int
+	}{
+		{0, DefaultPageSize},
+		{-1, DefaultPageSize},
+		{20, 20},
+		{MaxPageSize, MaxPageSize},
+		{MaxPageSize + 1, MaxPageSize},
+	}
+	for _, tt := range tests {
+		if got := (TaskQuery{Limit: tt.limit}).PageSize(); got != tt.want {
+			t.Errorf("PageSize() with Limit %d = %d, want %d", tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestMemoryStoreListTasksPages(t *testing.T) {
+	ctx := context.Background()
+	s := newPagingStore(t, 7)
+
+	tests := []struct {
+		page int
+		want []string
+	}{
+		{1, []string{"task 00", "task 01", "task 02"}},
+		{3, []string{"task 06"}},
+		{4, nil},
+	}
+	for _, tt := range tests {
+		page, err := s.ListTasks(ctx, TaskQuery{Page: tt.page, Limit: 3})
+		if err != nil {
+			t.Fatalf("ListTasks(page %d): %v", tt.page, err)
+		}
+		// 総数はページに関係なく条件に一致する件数
+		if page.Total != 7 {
+			t.Errorf("page %d: Total = %d, want 7", tt.page, page.Total)
+		}
+		if got := taskNames(page.Tasks); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("page %d: tasks = %v, want %v", tt.page, got, tt.want)
+		}
+	}
+}
+
+func TestMemoryStoreListTasksCursor(t *testing.T) {
+	ctx := context.Background()
+	s := newPagingStore(t, 5)
+	query := TaskQuery{Limit: 2}
+
+	first, err := s.ListTasks(ctx, query)
+	if err != nil {
+		t.Fatalf("ListTasks: %v", err)
+	}
+	if first.NextCursor == "" {
+		t.Fatal("NextCursor is empty on the first page")
+	}
+	// 読み終えた位置より前に並ぶタスクを追加しても、次のページはずれない
+	newTestTask(t, s, taskInput("task 00a", "High", "NotStarted"))
+
+	var names []string
+	cursor := first.NextCursor
+	for cursor != "" {
+		query.Cursor = cursor
+		page, err := s.ListTasks(ctx, query)
+		if err != nil {
+			t.Fatalf("ListTasks(cursor): %v", err)
+		}
+		names = append(names, taskNames(page.Tasks)...)
+		cursor = page.NextCursor
+	}
+	if want := []string{"task 02", "task 03", "task 04"}; fmt.Sprint(names) != fmt.Sprint(want) {
+		t.Errorf("tasks after the first page = %v, want %v", names, want)
+	}
+}
+
+func TestMemoryStoreListTasksInvalidCursor(t *testing.T) {
+	ctx := context.Background()
+	s := newPagingStore(t, 3)
+	page, err := s.ListTasks(ctx, TaskQuery{Sort: "name", Limit: 1})
+	if err != nil {
+		t.Fatalf("ListTasks: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		sort   string
+		cursor string
+	}{
+		{"not base64", "name", "!!!"},
+		{"not json", "name", "bm90IGpzb24"},
+		{"different sort", "-name", page.NextCursor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.ListTasks(ctx, TaskQuery{Sort: tt.sort, Limit: 1, Cursor: tt.cursor})
+			if !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("ListTasks = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+func TestKeysetCondition(t *testing.T) {
+	order, err := parseSort("end_date,name", TaskFilter{})
+	if err != nil {
+		t.Fatalf("parseSort: %v", err)
+	}
+	n := 0
+	placeholder := func(arg interface{}) string {
+		n++
+		return fmt.Sprintf("$%d", n)
+	}
+
+	// 終了日がNULLの行の後ろでは、終了日がNULLの行だけを名前とIDで比べる
+	got := keysetCondition(order, []interface{}{nil, "b", 3}, placeholder)
+	want := "((end_date IS NULL AND (name > $1 OR name IS NULL)) OR (end_date IS NULL AND name = $2 AND (id > $3 OR id IS NULL)))"
+	if got != want {
+		t.Errorf("keysetCondition =\n%s\nwant\n%s", got, want)
+	}
+}
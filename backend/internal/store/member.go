@@ -0,0 +1,70 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// ErrLastAdmin はプロジェクトの最後の admin の役割を変更したり、メンバーから外したりしようとした場合に返されます
+var ErrLastAdmin = errors.New("store: project must keep at least one admin")
+
+// MemberStore はプロジェクトのメンバーと役割の永続化を抽象化します
+// プロジェクトを作成したユーザーは admin になります。誰でも登録できるため、登録したユーザーはどのプロジェクトにも参加せず、
+// 既定のプロジェクトの最初の admin は起動時の設定で指定します
+type MemberStore interface {
+	// ListProjectMembers はプロジェクトのメンバーをユーザー名の順に返します
+	ListProjectMembers(ctx context.Context, projectID int) ([]api.ProjectMember, error)
+	// ProjectRoles はユーザーが参加しているプロジェクトのIDごとの役割を返します
+	ProjectRoles(ctx context.Context, userID int) (map[int]api.ProjectRole, error)
+	// SetProjectMember はユーザーをプロジェクトのメンバーにするか、役割を変更します
+	// プロジェクトかユーザーが存在しない場合は ErrNotFound、最後の admin の役割を変更する場合は ErrLastAdmin を返します
+	SetProjectMember(ctx context.Context, projectID, userID int, role api.ProjectRole) (api.ProjectMember, error)
+	// RemoveProjectMember はユーザーをプロジェクトのメンバーから外します
+	// メンバーでない場合は ErrNotFound、最後の admin を外す場合は ErrLastAdmin を返します
+	RemoveProjectMember(ctx context.Context, projectID, userID int) error
+	// TaskProjectID はタスク（ゴミ箱のものを含む）が属するプロジェクトのIDを返します
+	TaskProjectID(ctx context.Context, taskID int) (int, error)
+}
+
+// MemberScope は一覧をユーザーがメンバーとして参加しているプロジェクトのものに絞り込む条件です
+type MemberScope struct {
+	UserID int
+	// Roles はいずれかの役割で参加しているプロジェクトに絞り込みます
+	Roles []api.ProjectRole
+}
+
+// 役割を文字列の配列として返す（SQLの引数に使う）
+func (m MemberScope) roleNames() []string {
+	names := make([]string, len(m.Roles))
+	for i, role := range m.Roles {
+		names[i] = string(role)
+	}
+	return names
+}
+
+// 役割が Roles のいずれかに一致するかを判定
+func (m MemberScope) allows(role api.ProjectRole) bool {
+	for _, r := range m.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// プロジェクトのメンバーの変更を記録する（参加では before、外した場合は after が nil）
+func projectMemberChange(projectID int, username string, before, after *api.ProjectRole) auditRecord {
+	field := "members." + username
+	r := auditRecord{entityType: api.AuditEntryEntityTypeProject, entityID: projectID, action: api.Update}
+	r.changes = diffFields(map[string]interface{}{field: roleOrNil(before)}, map[string]interface{}{field: roleOrNil(after)})
+	return r
+}
+
+func roleOrNil(role *api.ProjectRole) interface{} {
+	if role == nil {
+		return nil
+	}
+	return string(*role)
+}
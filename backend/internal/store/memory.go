@@ -0,0 +1,846 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/search"
+)
+
+// MemoryStore はプロセス内のメモリに保持するTaskStoreとLabelStoreの実装です
+// テストやデータベースを用意しないローカルデモで使用します
+type MemoryStore struct {
+	mu         sync.RWMutex
+	tasks      map[int]TaskEntity
+	labels     map[int]api.Label
+	taskLabels map[int]map[int]bool
+	projects   map[int]api.Project
+	// members はプロジェクトIDごとの、ユーザーIDごとのメンバーです
+	members map[int]map[int]api.ProjectMember
+	// taskAssignees はタスクIDごとの担当者のユーザーIDの集合です
+	taskAssignees map[int]map[int]bool
+	// dependencies はタスクIDごとの依存先のタスクIDの集合です
+	dependencies map[int]map[int]bool
+	comments     map[int]CommentEntity
+	// commentRevisions はコメントIDごとの編集前の本文です（古い順）
+	commentRevisions map[int][]commentRevision
+	attachments      map[int]AttachmentEntity
+	users            map[int]UserEntity
+	// refreshTokens はトークンの jti ごとのリフレッシュトークンの記録です
+	refreshTokens    map[string]RefreshTokenEntity
+	nextTaskID       int
+	nextLabelID      int
+	nextProjectID    int
+	nextCommentID    int
+	nextAttachmentID int
+	nextUserID       int
+	audit            []api.AuditEntry
+	nextAuditID      int64
+	opts             Options
+}
+
+// defaultLabels はマイグレーション（0001_init.up.sql）で作成するラベルです
+var defaultLabels = []api.LabelInput{
+	{Name: "重要", Color: "#FF5252"},
+	{Name: "仕事", Color: "#4CAF50"},
+	{Name: "個人", Color: "#2196F3"},
+	{Name: "勉強", Color: "#9C27B0"},
+	{Name: "家庭", Color: "#FF9800"},
+}
+
+func NewMemoryStore(opts Options) *MemoryStore {
+	// マイグレーションと同じく既定のプロジェクトを用意する
+	now := time.Now()
+	description := "既定のプロジェクト"
+	defaultProject := api.Project{Id: DefaultProjectID, Name: "Default", Description: &description, CreatedAt: now, UpdatedAt: now, Version: 1}
+	// マイグレーションと同じく既定のプロジェクトに既定のラベルを用意する（変更履歴は記録しない）
+	labels := make(map[int]api.Label, len(defaultLabels))
+	for i, input := range defaultLabels {
+		id := i + 1
+		labels[id] = api.Label{ID: id, Name: input.Name, Color: input.Color, ProjectId: DefaultProjectID, CreatedAt: now, UpdatedAt: now, Version: 1}
+	}
+
+	return &MemoryStore{
+		opts:             opts,
+		tasks:            make(map[int]TaskEntity),
+		labels:           labels,
+		taskLabels:       make(map[int]map[int]bool),
+		projects:         map[int]api.Project{DefaultProjectID: defaultProject},
+		members:          map[int]map[int]api.ProjectMember{DefaultProjectID: {}},
+		taskAssignees:    make(map[int]map[int]bool),
+		dependencies:     make(map[int]map[int]bool),
+		comments:         make(map[int]CommentEntity),
+		commentRevisions: make(map[int][]commentRevision),
+		attachments:      make(map[int]AttachmentEntity),
+		users:            make(map[int]UserEntity),
+		refreshTokens:    make(map[string]RefreshTokenEntity),
+		nextTaskID:       1,
+		nextLabelID:      len(defaultLabels) + 1,
+		nextProjectID:    DefaultProjectID + 1,
+		nextCommentID:    1,
+		nextAttachmentID: 1,
+		nextUserID:       1,
+		nextAuditID:      1,
+	}
+}
+
+// タスク一覧を取得
+func (s *MemoryStore) ListTasks(ctx context.Context, query TaskQuery) (TaskPage, error) {
+	order, err := parseSort(query.Sort, query.Filter)
+	if err != nil {
+		return TaskPage{}, err
+	}
+	var after []interface{}
+	if query.Cursor != "" {
+		if after, err = decodeCursor(order, query.Cursor); err != nil {
+			return TaskPage{}, err
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var entities []TaskEntity
+	for _, e := range s.tasks {
+		if s.matches(query.Filter, e) {
+			if query.Filter.Query != "" {
+				e.SearchRank = search.Rank(query.Filter.Query, e.Name, stringValue(e.Description))
+			}
+			entities = append(entities, e)
+		}
+	}
+	sort.Slice(entities, func(i, j int) bool {
+		return compareEntities(order, entities[i], entities[j]) < 0
+	})
+
+	page := TaskPage{Total: len(entities)}
+	if after != nil {
+		i := sort.Search(len(entities), func(i int) bool {
+			return compareToCursor(order, entities[i], after) > 0
+		})
+		entities = entities[i:]
+	}
+	if offset := query.offset(); offset < len(entities) {
+		entities = entities[offset:]
+	} else {
+		entities = nil
+	}
+	if limit := query.PageSize(); len(entities) > limit {
+		entities = entities[:limit]
+		page.NextCursor = encodeCursor(order, entities[limit-1])
+	}
+
+	for _, e := range entities {
+		task := s.apiTask(e)
+		if query.Filter.Query != "" {
+			task.Search = searchMatch(query.Filter.Query, e)
+		}
+		page.Tasks = append(page.Tasks, task)
+	}
+	return page, nil
+}
+
+// IDのタスクをラベル付きで取得
+func (s *MemoryStore) GetTask(ctx context.Context, id int) (api.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.activeTask(id)
+	if !ok {
+		return api.Task{}, ErrNotFound
+	}
+	return s.apiTask(e), nil
+}
+
+// タスクをサブタスクのツリー付きで取得
+func (s *MemoryStore) TaskTree(ctx context.Context, id int) (api.Task, error) {
+	// 兄弟は一覧の既定の並び順にする
+	order, err := parseSort("", TaskFilter{})
+	if err != nil {
+		return api.Task{}, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	root, ok := s.activeTask(id)
+	if !ok {
+		return api.Task{}, ErrNotFound
+	}
+	entities := s.descendants(id, isActive)
+	sort.Slice(entities, func(i, j int) bool {
+		return compareEntities(order, entities[i], entities[j]) < 0
+	})
+	descendants := make([]api.Task, len(entities))
+	for i, e := range entities {
+		descendants[i] = s.apiTask(e)
+	}
+	return buildTree(s.apiTask(root), descendants), nil
+}
+
+// タスクを作成
+func (s *MemoryStore) CreateTask(ctx context.Context, projectID int, input api.TaskInput) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := taskEntityFromInput(input)
+	var err error
+	if e.ProjectID, err = s.newTaskProject(projectID, e.ParentID); err != nil {
+		return 0, err
+	}
+	if err := s.checkProject(e.ProjectID); err != nil {
+		return 0, err
+	}
+	if err := s.checkParent(s.nextTaskID, e.ProjectID, e.ParentID); err != nil {
+		return 0, err
+	}
+	if err := normalizeRecurrence(nil, &e); err != nil {
+		return 0, err
+	}
+	return s.insertTask(ctx, e).ID, nil
+}
+
+// タスクを追加し、変更履歴を記録する（呼び出し側でロックを取得すること）
+func (s *MemoryStore) insertTask(ctx context.Context, e TaskEntity) TaskEntity {
+	now := time.Now()
+	e.ID = s.nextTaskID
+	e.CreatedAt = now
+	e.UpdatedAt = now
+	e.CreatedBy = actorFromContext(ctx)
+	e.UpdatedBy = e.CreatedBy
+	e.Version = 1
+	s.tasks[e.ID] = e
+	s.nextTaskID++
+	s.record(ctx, taskChange(api.Create, nil, &e))
+	s.touchRelated(nil, &e)
+	return e
+}
+
+// 指定したIDのタスクを更新
+func (s *MemoryStore) UpdateTask(ctx context.Context, id int, input api.TaskInput, version int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.writableTask(id)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(current.Version, version); err != nil {
+		return err
+	}
+	_, err = s.writeTask(ctx, current, current.withInput(input))
+	return err
+}
+
+// 指定したIDのタスクを部分更新
+func (s *MemoryStore) PatchTask(ctx context.Context, id int, patch TaskPatch, version int) (api.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.patchTask(ctx, id, patch, version)
+}
+
+// 呼び出し側でロックを取得すること
+func (s *MemoryStore) patchTask(ctx context.Context, id int, patch TaskPatch, version int) (api.Task, error) {
+	before, err := s.writableTask(id)
+	if err != nil {
+		return api.Task{}, err
+	}
+	if err := checkVersion(before.Version, version); err != nil {
+		return api.Task{}, err
+	}
+	e := before
+	patch.apply(&e)
+	if err := checkDateOrder(e); err != nil {
+		return api.Task{}, err
+	}
+	e, err = s.writeTask(ctx, before, e)
+	if err != nil {
+		return api.Task{}, err
+	}
+	return s.apiTask(e), nil
+}
+
+// タスクを e の内容で更新し、変更履歴を記録する（呼び出し側でロックを取得すること）
+// 検証はすべて書き込みの前に行うため、エラーの場合は何も変更しません
+func (s *MemoryStore) writeTask(ctx context.Context, before, e TaskEntity) (TaskEntity, error) {
+	if parentChanged(before, e) {
+		if err := s.checkParent(e.ID, e.ProjectID, e.ParentID); err != nil {
+			return TaskEntity{}, err
+		}
+	}
+	if s.opts.EnforceDependencies && startsWork(before, e) {
+		if err := s.checkUnblocked(e.ID); err != nil {
+			return TaskEntity{}, err
+		}
+	}
+	if err := normalizeRecurrence(&before, &e); err != nil {
+		return TaskEntity{}, err
+	}
+	// 繰り返しのタスクを完了した場合は、規則を次のタスクに引き継ぐ
+	var next *TaskEntity
+	if completesOccurrence(before, e) {
+		var err error
+		if next, err = handOver(&e); err != nil {
+			return TaskEntity{}, err
+		}
+	}
+
+	e.UpdatedAt = time.Now()
+	e.UpdatedBy = actorFromContext(ctx)
+	e.Version = before.Version + 1
+	s.tasks[e.ID] = e
+	s.record(ctx, taskChange(api.Update, &before, &e))
+	s.touchRelated(&before, &e)
+	if next != nil {
+		s.createOccurrence(ctx, e.ID, *next)
+	}
+	return e, nil
+}
+
+// 繰り返しの次のタスクを作成し、完了したタスク（previousID）の担当者とラベルを引き継ぐ（呼び出し側でロックを取得すること）
+func (s *MemoryStore) createOccurrence(ctx context.Context, previousID int, next TaskEntity) {
+	created := s.insertTask(ctx, next)
+	if userIDs := s.assigneeIDsOf(previousID); len(userIDs) > 0 {
+		s.taskAssignees[created.ID] = s.userSet(userIDs)
+		s.record(ctx, taskAssigneesChange(created.ID, []int{}, userIDs))
+	}
+	labelIDs := s.labelIDsOf(previousID)
+	if len(labelIDs) == 0 {
+		return
+	}
+	set := make(map[int]bool, len(labelIDs))
+	for _, labelID := range labelIDs {
+		set[labelID] = true
+	}
+	s.taskLabels[created.ID] = set
+	s.record(ctx, taskLabelsChange(created.ID, []int{}, labelIDs))
+}
+
+// タスクをゴミ箱に移動（ラベルの関連付けは復元できるよう残す）
+func (s *MemoryStore) DeleteTask(ctx context.Context, id int, version int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.trashTask(ctx, id, version)
+}
+
+// 呼び出し側でロックを取得すること
+func (s *MemoryStore) trashTask(ctx context.Context, id int, version int) error {
+	before, err := s.writableTask(id)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(before.Version, version); err != nil {
+		return err
+	}
+	now := time.Now()
+	switch s.opts.deletePolicy() {
+	case DeleteRestrict:
+		if len(s.children(id, isActive)) > 0 {
+			return ErrHasSubtasks
+		}
+	case DeleteCascade:
+		// 孫以下も含めて同じ時刻でゴミ箱に移動し、親を元に戻すときに一緒に戻せるようにする
+		s.updateEach(ctx, s.descendants(id, isActive), api.Delete, func(e *TaskEntity) {
+			e.DeletedAt = &now
+		})
+	default:
+		s.updateEach(ctx, s.children(id, isActive), api.Update, func(e *TaskEntity) {
+			e.ParentID = nil
+			e.UpdatedAt = now
+			e.UpdatedBy = actorFromContext(ctx)
+		})
+	}
+
+	e := before
+	e.DeletedAt = &now
+	e.Version++
+	s.tasks[id] = e
+	s.record(ctx, taskChange(api.Delete, &before, &e))
+	s.touchRelated(&before, &e)
+	return nil
+}
+
+// ゴミ箱のタスクを元に戻す
+// 一緒にゴミ箱に移動したサブタスクも元に戻し、親がゴミ箱にある場合は最上位のタスクとして戻します
+func (s *MemoryStore) RestoreTask(ctx context.Context, id int, version int) (api.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before, ok := s.tasks[id]
+	if !ok || before.DeletedAt == nil {
+		return api.Task{}, ErrNotFound
+	}
+	if err := s.checkProject(before.ProjectID); err != nil {
+		return api.Task{}, err
+	}
+	if err := checkVersion(before.Version, version); err != nil {
+		return api.Task{}, err
+	}
+	e := before
+	e.DeletedAt = nil
+	if e.ParentID != nil {
+		if _, ok := s.activeTask(*e.ParentID); !ok {
+			e.ParentID = nil
+		}
+	}
+	e.Version++
+	s.tasks[id] = e
+	s.record(ctx, taskChange(api.Restore, &before, &e))
+	s.touchRelated(&before, &e)
+
+	// 同じ時刻にゴミ箱に移動したサブタスクは、親と一緒に移動したものとして元に戻す
+	s.updateEach(ctx, s.descendants(id, func(c TaskEntity) bool {
+		return c.DeletedAt != nil && c.DeletedAt.Equal(*before.DeletedAt)
+	}), api.Restore, func(c *TaskEntity) {
+		c.DeletedAt = nil
+	})
+	// サブタスクを元に戻すと集計が変わり版が進むため、最新の状態を返す
+	return s.apiTask(s.tasks[id]), nil
+}
+
+// ゴミ箱に移動してから一定期間が過ぎたタスクを完全に削除
+func (s *MemoryStore) PurgeTasks(ctx context.Context, deletedBefore time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 履歴がIDの順に並ぶよう、削除するタスクをIDの順に処理する
+	var purged []int
+	for id, e := range s.tasks {
+		if e.DeletedAt != nil && e.DeletedAt.Before(deletedBefore) {
+			purged = append(purged, id)
+		}
+	}
+	sort.Ints(purged)
+	for _, id := range purged {
+		e := s.tasks[id]
+		delete(s.tasks, id)
+		delete(s.taskLabels, id)
+		delete(s.taskAssignees, id)
+		s.record(ctx, taskChange(api.Purge, &e, nil))
+	}
+	// 外部キー制約の ON DELETE CASCADE と同じく、削除したタスクとの依存関係を削除する
+	for _, id := range purged {
+		delete(s.dependencies, id)
+		for _, deps := range s.dependencies {
+			delete(deps, id)
+		}
+	}
+	// 外部キー制約の ON DELETE CASCADE と同じく、削除したタスクのコメントを削除する
+	for id, c := range s.comments {
+		if _, ok := s.tasks[c.TaskID]; !ok {
+			delete(s.comments, id)
+			delete(s.commentRevisions, id)
+		}
+	}
+	// 同じく添付ファイルのメタデータを削除する（本体は blob.Sweeper が削除する）
+	for id, a := range s.attachments {
+		if _, ok := s.tasks[a.TaskID]; !ok {
+			delete(s.attachments, id)
+		}
+	}
+	// 外部キー制約の ON DELETE SET NULL と同じく、残ったサブタスクの親と、残った繰り返しのタスクの最初のタスクを外す
+	for id, e := range s.tasks {
+		changed := false
+		if e.ParentID != nil {
+			if _, ok := s.tasks[*e.ParentID]; !ok {
+				e.ParentID = nil
+				changed = true
+			}
+		}
+		if e.SeriesID != nil {
+			if _, ok := s.tasks[*e.SeriesID]; !ok {
+				e.SeriesID = nil
+				changed = true
+			}
+		}
+		if changed {
+			s.tasks[id] = e
+		}
+	}
+	return len(purged), nil
+}
+
+// サブタスクの集計は親タスクの、依存先の状態は依存元のタスクの表現の一部なので、
+// before から after への変更でそれらが変わるタスクの版を進める
+// それらのタスク自体を編集したわけではないため、UpdatedAt と UpdatedBy は変えない（呼び出し側でロックを取得すること）
+func (s *MemoryStore) touchRelated(before, after *TaskEntity) {
+	ids := rollupParents(before, after)
+	if changesBlocking(before, after) {
+		id := after
+		if id == nil {
+			id = before
+		}
+		for taskID, deps := range s.dependencies {
+			if deps[id.ID] {
+				ids = append(ids, taskID)
+			}
+		}
+	}
+	s.bumpVersions(ids)
+}
+
+// ゴミ箱にないタスクの版だけを進める（呼び出し側でロックを取得すること）
+func (s *MemoryStore) bumpVersions(ids []int) {
+	for _, id := range uniqueInts(ids) {
+		if e, ok := s.activeTask(id); ok {
+			e.Version++
+			s.tasks[id] = e
+		}
+	}
+}
+
+// ゴミ箱にないタスクを返す（呼び出し側でロックを取得すること）
+func (s *MemoryStore) activeTask(id int) (TaskEntity, bool) {
+	e, ok := s.tasks[id]
+	if !ok || e.DeletedAt != nil {
+		return TaskEntity{}, false
+	}
+	return e, true
+}
+
+// 変更できるタスク（ゴミ箱になく、アーカイブしたプロジェクトに属さない）を返す（呼び出し側でロックを取得すること）
+func (s *MemoryStore) writableTask(id int) (TaskEntity, error) {
+	e, ok := s.activeTask(id)
+	if !ok {
+		return TaskEntity{}, ErrNotFound
+	}
+	return e, s.checkProject(e.ProjectID)
+}
+
+// ラベル一覧を取得
+func (s *MemoryStore) ListLabels(ctx context.Context, projectID int) ([]api.Label, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var labels []api.Label
+	for _, l := range s.labels {
+		if projectID == 0 || l.ProjectId == projectID {
+			labels = append(labels, l)
+		}
+	}
+	sortLabels(labels)
+	return labels, nil
+}
+
+// IDのラベルを取得
+func (s *MemoryStore) GetLabel(ctx context.Context, id int) (api.Label, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	l, ok := s.labels[id]
+	if !ok {
+		return api.Label{}, ErrNotFound
+	}
+	return l, nil
+}
+
+// 新しいラベルを作成
+func (s *MemoryStore) CreateLabel(ctx context.Context, projectID int, input api.LabelInput) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkProject(projectID); err != nil {
+		return 0, err
+	}
+	return s.insertLabel(ctx, projectID, input).ID, nil
+}
+
+// ラベルを追加し、変更履歴を記録する（呼び出し側でロックを取得すること）
+func (s *MemoryStore) insertLabel(ctx context.Context, projectID int, input api.LabelInput) api.Label {
+	now := time.Now()
+	l := api.Label{
+		ID:        s.nextLabelID,
+		Name:      input.Name,
+		Color:     input.Color,
+		ProjectId: projectID,
+		CreatedAt: now,
+		UpdatedAt: now,
+		CreatedBy: actorFromContext(ctx),
+		UpdatedBy: actorFromContext(ctx),
+		Version:   1,
+	}
+	s.labels[l.ID] = l
+	s.nextLabelID++
+	s.record(ctx, labelChange(api.Create, nil, &l))
+	return l
+}
+
+// 指定したIDのラベルを更新
+func (s *MemoryStore) UpdateLabel(ctx context.Context, id int, input api.LabelInput, version int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before, ok := s.labels[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if err := s.checkProject(before.ProjectId); err != nil {
+		return err
+	}
+	if err := checkVersion(before.Version, version); err != nil {
+		return err
+	}
+	l := before
+	l.Name = input.Name
+	l.Color = input.Color
+	l.UpdatedAt = time.Now()
+	l.UpdatedBy = actorFromContext(ctx)
+	l.Version++
+	s.labels[id] = l
+	s.touchLabeledTasks(id)
+	s.record(ctx, labelChange(api.Update, &before, &l))
+	return nil
+}
+
+// 指定したIDのラベルを削除
+func (s *MemoryStore) DeleteLabel(ctx context.Context, id int, version int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l, ok := s.labels[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if err := s.checkProject(l.ProjectId); err != nil {
+		return err
+	}
+	if err := checkVersion(l.Version, version); err != nil {
+		return err
+	}
+	s.touchLabeledTasks(id)
+	delete(s.labels, id)
+	s.record(ctx, labelChange(api.Delete, &l, nil))
+
+	// ラベルを外されたタスクにも、ラベルの付け替えとして履歴を残す
+	var taskIDs []int
+	for taskID, set := range s.taskLabels {
+		if set[id] {
+			taskIDs = append(taskIDs, taskID)
+		}
+	}
+	sort.Ints(taskIDs)
+	for _, taskID := range taskIDs {
+		before := s.labelIDsOf(taskID)
+		delete(s.taskLabels[taskID], id)
+		s.record(ctx, taskLabelsChange(taskID, before, s.labelIDsOf(taskID)))
+	}
+	return nil
+}
+
+// ラベルはタスクの表現の一部なので、ラベルが付いているタスクの版を進める（呼び出し側でロックを取得すること）
+func (s *MemoryStore) touchLabeledTasks(labelID int) {
+	for taskID, set := range s.taskLabels {
+		if e, ok := s.tasks[taskID]; ok && set[labelID] {
+			e.Version++
+			s.tasks[taskID] = e
+		}
+	}
+}
+
+// タスクに関連付けられたラベルを置き換える
+func (s *MemoryStore) SetTaskLabels(ctx context.Context, taskID int, labelIDs []int, version int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.setTaskLabels(ctx, taskID, labelIDs, version)
+}
+
+// 呼び出し側でロックを取得すること
+func (s *MemoryStore) setTaskLabels(ctx context.Context, taskID int, labelIDs []int, version int) error {
+	e, err := s.writableTask(taskID)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(e.Version, version); err != nil {
+		return err
+	}
+	// 外部キー制約と同じく存在しないラベルは拒否し、タスクと異なるプロジェクトのラベルも拒否する
+	set := make(map[int]bool, len(labelIDs))
+	for _, labelID := range labelIDs {
+		l, ok := s.labels[labelID]
+		if !ok {
+			return &ConstraintError{
+				Kind:       ErrInvalidReference,
+				Field:      "label_ids",
+				Constraint: "task_labels_label_id_fkey",
+				Err:        fmt.Errorf("label %d does not exist", labelID),
+			}
+		}
+		if l.ProjectId != e.ProjectID {
+			return foreignLabel(labelID)
+		}
+		set[labelID] = true
+	}
+	before := s.labelIDsOf(taskID)
+	s.taskLabels[taskID] = set
+	s.record(ctx, taskLabelsChange(taskID, before, s.labelIDsOf(taskID)))
+
+	// ラベルはタスクの表現の一部なので、タスクの版を進める
+	e.UpdatedAt = time.Now()
+	e.UpdatedBy = actorFromContext(ctx)
+	e.Version++
+	s.tasks[taskID] = e
+	return nil
+}
+
+// タスクに関連付けられたラベルを名前順で返す（呼び出し側でロックを取得すること）
+func (s *MemoryStore) labelsOf(taskID int) []api.Label {
+	var labels []api.Label
+	for labelID := range s.taskLabels[taskID] {
+		if l, ok := s.labels[labelID]; ok {
+			labels = append(labels, l)
+		}
+	}
+	sortLabels(labels)
+	return labels
+}
+
+// タスクに関連付けられたラベルIDを昇順で返す（呼び出し側でロックを取得すること）
+func (s *MemoryStore) labelIDsOf(taskID int) []int {
+	var ids []int
+	for labelID := range s.taskLabels[taskID] {
+		ids = append(ids, labelID)
+	}
+	return sortedIDs(ids)
+}
+
+func taskEntityFromInput(input api.TaskInput) TaskEntity {
+	return TaskEntity{
+		Name:        input.Name,
+		Description: input.Description,
+		StartDate:   input.StartDate,
+		EndDate:     input.EndDate,
+		Priority:    string(input.Priority),
+		Status:      string(input.Status),
+		ParentID:    input.ParentId,
+
+		RecurrenceRule:     input.RecurrenceRule,
+		RecurrenceTimezone: input.RecurrenceTimezone,
+	}
+}
+
+// withInput は更新リクエストの内容で置き換えたエンティティを返します（IDや版などはそのまま）
+func (e TaskEntity) withInput(input api.TaskInput) TaskEntity {
+	in := taskEntityFromInput(input)
+	e.Name, e.Description, e.StartDate, e.EndDate = in.Name, in.Description, in.StartDate, in.EndDate
+	e.Priority, e.Status, e.ParentID = in.Priority, in.Status, in.ParentID
+	e.RecurrenceRule, e.RecurrenceTimezone = in.RecurrenceRule, in.RecurrenceTimezone
+	return e
+}
+
+// 絞り込み条件に一致するかを判定（PostgresStoreの条件と同じ意味、呼び出し側でロックを取得すること）
+func (s *MemoryStore) matches(f TaskFilter, e TaskEntity) bool {
+	if (e.DeletedAt != nil) != f.Trashed {
+		return false
+	}
+	if f.ParentID != nil && (e.ParentID == nil || *e.ParentID != *f.ParentID) {
+		return false
+	}
+	if f.ProjectID != nil {
+		if e.ProjectID != *f.ProjectID {
+			return false
+		}
+	} else if !f.IncludeArchived && s.projects[e.ProjectID].ArchivedAt != nil {
+		return false
+	}
+	if f.Member != nil && !s.isMember(*f.Member, e.ProjectID) {
+		return false
+	}
+	if len(f.Statuses) > 0 && !containsString(f.Statuses, e.Status) {
+		return false
+	}
+	if len(f.Priorities) > 0 && !containsString(f.Priorities, e.Priority) {
+		return false
+	}
+	if f.Query != "" && !search.Matches(f.Query, e.Name, stringValue(e.Description)) {
+		return false
+	}
+	if f.Name != "" && !containsFold(e.Name, f.Name) {
+		return false
+	}
+	if f.Description != "" && (e.Description == nil || !containsFold(*e.Description, f.Description)) {
+		return false
+	}
+	labels := s.taskLabels[e.ID]
+	if ids := uniqueInts(f.LabelIDs); len(ids) > 0 {
+		matched := 0
+		for _, id := range ids {
+			if labels[id] {
+				matched++
+			}
+		}
+		if matched == 0 || (f.LabelMatch == LabelMatchAll && matched < len(ids)) {
+			return false
+		}
+	}
+	if f.HasLabels != nil && (len(labels) > 0) != *f.HasLabels {
+		return false
+	}
+	assignees := s.taskAssignees[e.ID]
+	if len(f.Assignees) > 0 {
+		matched := false
+		for userID := range assignees {
+			if u, ok := s.users[userID]; ok && containsString(f.Assignees, u.Username) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if f.Unassigned != nil && (len(assignees) == 0) != *f.Unassigned {
+		return false
+	}
+	if !f.StartDate.contains(e.StartDate) || !f.EndDate.contains(e.EndDate) ||
+		!f.CreatedAt.contains(&e.CreatedAt) || !f.UpdatedAt.contains(&e.UpdatedAt) {
+		return false
+	}
+	if f.Overdue != nil {
+		overdue := e.EndDate != nil && e.EndDate.Before(f.now()) && e.Status != "Completed"
+		if overdue != *f.Overdue {
+			return false
+		}
+	}
+	return true
+}
+
+// contains は日時が範囲に含まれるかを判定します（範囲を指定した場合、NULLは含まれません）
+func (r TimeRange) contains(t *time.Time) bool {
+	if r.From == nil && r.To == nil {
+		return true
+	}
+	if t == nil {
+		return false
+	}
+	return (r.From == nil || !t.Before(*r.From)) && (r.To == nil || !t.After(*r.To))
+}
+
+func containsString(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+func sortLabels(labels []api.Label) {
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].Name != labels[j].Name {
+			return labels[i].Name < labels[j].Name
+		}
+		return labels[i].ID < labels[j].ID
+	})
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
@@ -0,0 +1,78 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// タスクの担当者を置き換える
+func (s *MemoryStore) SetTaskAssignees(ctx context.Context, taskID int, userIDs []int, version int) (api.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, err := s.writableTask(taskID)
+	if err != nil {
+		return api.Task{}, err
+	}
+	if err := checkVersion(e.Version, version); err != nil {
+		return api.Task{}, err
+	}
+	// 外部キー制約と同じく、存在しないユーザーは拒否する
+	for _, userID := range userIDs {
+		if _, ok := s.users[userID]; !ok {
+			return api.Task{}, invalidAssignee(userID)
+		}
+	}
+
+	before := s.assigneeIDsOf(taskID)
+	s.taskAssignees[taskID] = s.userSet(userIDs)
+	s.record(ctx, taskAssigneesChange(taskID, before, s.assigneeIDsOf(taskID)))
+
+	// 担当者はタスクの表現の一部なので、タスクの版を進める
+	e.UpdatedAt = time.Now()
+	e.UpdatedBy = actorFromContext(ctx)
+	e.Version++
+	s.tasks[taskID] = e
+	return s.apiTask(e), nil
+}
+
+// タスクの担当者をユーザー名の順で返す（呼び出し側でロックを取得すること）
+func (s *MemoryStore) assigneesOf(taskID int) []api.Assignee {
+	var assignees []api.Assignee
+	for userID := range s.taskAssignees[taskID] {
+		if u, ok := s.users[userID]; ok {
+			assignees = append(assignees, api.Assignee{Id: u.ID, Username: u.Username})
+		}
+	}
+	sortAssignees(assignees)
+	return assignees
+}
+
+// タスクの担当者のユーザーIDを昇順で返す（呼び出し側でロックを取得すること）
+func (s *MemoryStore) assigneeIDsOf(taskID int) []int {
+	var ids []int
+	for userID := range s.taskAssignees[taskID] {
+		ids = append(ids, userID)
+	}
+	return sortedIDs(ids)
+}
+
+func (s *MemoryStore) userSet(userIDs []int) map[int]bool {
+	set := make(map[int]bool, len(userIDs))
+	for _, userID := range userIDs {
+		set[userID] = true
+	}
+	return set
+}
+
+func invalidAssignee(userID int) error {
+	return &ConstraintError{
+		Kind:       ErrInvalidReference,
+		Field:      "user_ids",
+		Constraint: "task_assignees_user_id_fkey",
+		Err:        fmt.Errorf("user %d does not exist", userID),
+	}
+}
@@ -0,0 +1,109 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// タスクの添付ファイルを古い順に取得
+func (s *MemoryStore) ListAttachments(ctx context.Context, taskID int) ([]api.Attachment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.activeTask(taskID); !ok {
+		return nil, ErrNotFound
+	}
+	var attachments []AttachmentEntity
+	for _, a := range s.attachments {
+		if a.TaskID == taskID {
+			attachments = append(attachments, a)
+		}
+	}
+	sort.Slice(attachments, func(i, j int) bool { return attachments[i].ID < attachments[j].ID })
+
+	result := make([]api.Attachment, len(attachments))
+	for i, a := range attachments {
+		result[i] = a.ToAPIAttachment()
+	}
+	return result, nil
+}
+
+// 添付ファイルのメタデータを取得
+func (s *MemoryStore) GetAttachment(ctx context.Context, taskID, id int) (AttachmentEntity, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	a, ok := s.attachment(taskID, id)
+	if !ok {
+		return AttachmentEntity{}, ErrNotFound
+	}
+	return a, nil
+}
+
+// 添付ファイルのメタデータを追加
+func (s *MemoryStore) CreateAttachment(ctx context.Context, e AttachmentEntity) (api.Attachment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.writableTask(e.TaskID); err != nil {
+		return api.Attachment{}, err
+	}
+	// データベースの一意制約と同じく、同じ本体を二重に参照させない
+	for _, a := range s.attachments {
+		if a.BlobKey == e.BlobKey {
+			return api.Attachment{}, ErrConflict
+		}
+	}
+	e.ID = s.nextAttachmentID
+	e.UploadedBy = actorFromContext(ctx)
+	e.CreatedAt = time.Now()
+	s.attachments[e.ID] = e
+	s.nextAttachmentID++
+	return e.ToAPIAttachment(), nil
+}
+
+// 添付ファイルのメタデータを削除
+func (s *MemoryStore) DeleteAttachment(ctx context.Context, taskID, id int) (AttachmentEntity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.attachment(taskID, id)
+	if !ok {
+		return AttachmentEntity{}, ErrNotFound
+	}
+	delete(s.attachments, id)
+	return a, nil
+}
+
+// どの添付ファイルからも参照されていない本体のキーを返す
+func (s *MemoryStore) UnreferencedBlobs(ctx context.Context, keys []string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	referenced := make(map[string]bool, len(s.attachments))
+	for _, a := range s.attachments {
+		referenced[a.BlobKey] = true
+	}
+	var result []string
+	for _, key := range keys {
+		if !referenced[key] {
+			result = append(result, key)
+		}
+	}
+	return result, nil
+}
+
+// ゴミ箱にないタスクの添付ファイルを返す（呼び出し側でロックを取得すること）
+func (s *MemoryStore) attachment(taskID, id int) (AttachmentEntity, bool) {
+	a, ok := s.attachments[id]
+	if !ok || a.TaskID != taskID {
+		return AttachmentEntity{}, false
+	}
+	if _, ok := s.activeTask(taskID); !ok {
+		return AttachmentEntity{}, false
+	}
+	return a, true
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// 変更履歴を記録（呼び出し側で書き込みのロックを取得すること）
+func (s *MemoryStore) record(ctx context.Context, r auditRecord) {
+	if r.empty() {
+		return
+	}
+	s.audit = append(s.audit, api.AuditEntry{
+		Id:         s.nextAuditID,
+		EntityType: r.entityType,
+		EntityId:   r.entityID,
+		Action:     r.action,
+		Actor:      actorFromContext(ctx),
+		RequestId:  requestIDFromContext(ctx),
+		Changes:    r.changes,
+		CreatedAt:  time.Now(),
+	})
+	s.nextAuditID++
+}
+
+// 変更履歴を新しい順に取得
+func (s *MemoryStore) ListAudit(ctx context.Context, query AuditQuery) (AuditPage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.listAudit(query), nil
+}
+
+// タスクの変更履歴を新しい順に取得
+func (s *MemoryStore) TaskHistory(ctx context.Context, taskID int, query AuditQuery) (AuditPage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	query.EntityType = string(api.AuditEntryEntityTypeTask)
+	query.EntityID = taskID
+	page := s.listAudit(query)
+	if _, ok := s.tasks[taskID]; !ok && page.Total == 0 {
+		return AuditPage{}, ErrNotFound
+	}
+	return page, nil
+}
+
+// 呼び出し側でロックを取得すること
+func (s *MemoryStore) listAudit(query AuditQuery) AuditPage {
+	var page AuditPage
+	offset, limit := query.offset(), query.PageSize()
+	// 記録した順に並んでいるので、末尾から読むと新しい順になる
+	for i := len(s.audit) - 1; i >= 0; i-- {
+		if !query.matches(s.audit[i]) || !s.auditVisible(query.Member, s.audit[i]) {
+			continue
+		}
+		if page.Total >= offset && len(page.Entries) < limit {
+			page.Entries = append(page.Entries, s.audit[i])
+		}
+		page.Total++
+	}
+	return page
+}
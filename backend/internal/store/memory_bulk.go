@@ -0,0 +1,80 @@
+package store
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sort"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// タスクを一括で操作
+// 各操作は検証してから書き込むので、失敗したタスクには変更が残りません
+func (s *MemoryStore) BulkTasks(ctx context.Context, op BulkOperation) ([]BulkItemResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := op.targetIDs()
+	if op.Filter != nil {
+		ids = nil
+		for id, e := range s.tasks {
+			if s.matches(*op.Filter, e) {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) > MaxBulkTasks {
+			return nil, tooManyBulkTasks()
+		}
+		sort.Ints(ids)
+	}
+
+	// ドライランでは処理後に処理前の状態に戻す（ラベルと担当者の集合は置き換えるだけなので浅いコピーでよい）
+	// 繰り返しのタスクを完了すると次のタスクが作られるため、タスクIDの採番も戻す
+	if op.DryRun {
+		tasks, taskLabels, nextTaskID := maps.Clone(s.tasks), maps.Clone(s.taskLabels), s.nextTaskID
+		taskAssignees := maps.Clone(s.taskAssignees)
+		auditLen, nextAuditID := len(s.audit), s.nextAuditID
+		defer func() {
+			s.tasks, s.taskLabels, s.nextTaskID = tasks, taskLabels, nextTaskID
+			s.taskAssignees = taskAssignees
+			s.audit, s.nextAuditID = s.audit[:auditLen], nextAuditID
+		}()
+	}
+
+	results := make([]BulkItemResult, 0, len(ids))
+	for _, id := range ids {
+		task, err := s.bulkItem(ctx, op, id)
+		results = append(results, BulkItemResult{ID: id, Task: task, Err: err})
+	}
+	return results, nil
+}
+
+// 一括操作をタスク1件に適用し、操作後のタスクを返す（呼び出し側でロックを取得すること）
+func (s *MemoryStore) bulkItem(ctx context.Context, op BulkOperation, id int) (*api.Task, error) {
+	e, err := s.writableTask(id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Action {
+	case BulkDelete:
+		return nil, s.trashTask(ctx, id, AnyVersion)
+	case BulkAddLabels, BulkRemoveLabels:
+		// ラベルが変わらないタスクは版を進めない
+		current := s.labelIDsOf(id)
+		if labels := op.labels(current); !slices.Equal(labels, current) {
+			if err := s.setTaskLabels(ctx, id, labels, AnyVersion); err != nil {
+				return nil, err
+			}
+		}
+		task := s.apiTask(s.tasks[id])
+		return &task, nil
+	default:
+		task, err := s.patchTask(ctx, id, op.patch(e), AnyVersion)
+		if err != nil {
+			return nil, err
+		}
+		return &task, nil
+	}
+}
@@ -0,0 +1,160 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// タスクのコメントを古い順に取得
+func (s *MemoryStore) ListComments(ctx context.Context, taskID int, query CommentQuery) (CommentPage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.activeTask(taskID); !ok {
+		return CommentPage{}, ErrNotFound
+	}
+	var comments []CommentEntity
+	for _, c := range s.comments {
+		if c.TaskID == taskID {
+			comments = append(comments, c)
+		}
+	}
+	sort.Slice(comments, func(i, j int) bool { return comments[i].ID < comments[j].ID })
+
+	page := CommentPage{Total: len(comments)}
+	offset, limit := query.offset(), query.PageSize()
+	for i := offset; i < len(comments) && i < offset+limit; i++ {
+		page.Comments = append(page.Comments, comments[i].ToAPIComment())
+	}
+	return page, nil
+}
+
+// コメントを追加
+func (s *MemoryStore) CreateComment(ctx context.Context, taskID int, body string) (api.Comment, error) {
+	body, err := commentBody(body)
+	if err != nil {
+		return api.Comment{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.writableTask(taskID); err != nil {
+		return api.Comment{}, err
+	}
+	now := time.Now()
+	c := CommentEntity{
+		ID:        s.nextCommentID,
+		TaskID:    taskID,
+		Author:    actorFromContext(ctx),
+		Body:      body,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Version:   1,
+	}
+	s.comments[c.ID] = c
+	s.nextCommentID++
+	// コメント数はタスクの表現の一部なので、タスクの版を進める
+	s.bumpVersions([]int{taskID})
+	return c.ToAPIComment(), nil
+}
+
+// コメントの本文を編集
+func (s *MemoryStore) UpdateComment(ctx context.Context, taskID, id int, body string, version int) (api.Comment, error) {
+	body, err := commentBody(body)
+	if err != nil {
+		return api.Comment{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before, err := s.ownComment(ctx, taskID, id, version)
+	if err != nil {
+		return api.Comment{}, err
+	}
+	// 本文が変わらない編集は履歴に残さない
+	if body == before.Body {
+		return before.ToAPIComment(), nil
+	}
+	now := time.Now()
+	c := before
+	c.Body = body
+	c.EditedAt = &now
+	c.UpdatedAt = now
+	c.Version++
+	s.comments[id] = c
+	s.commentRevisions[id] = append(s.commentRevisions[id], revisionOf(before))
+	return c.ToAPIComment(), nil
+}
+
+// コメントを削除
+func (s *MemoryStore) DeleteComment(ctx context.Context, taskID, id int, version int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.ownComment(ctx, taskID, id, version); err != nil {
+		return err
+	}
+	delete(s.comments, id)
+	delete(s.commentRevisions, id)
+	s.bumpVersions([]int{taskID})
+	return nil
+}
+
+// コメントの編集履歴を新しい順に取得
+func (s *MemoryStore) CommentHistory(ctx context.Context, taskID, id int) ([]api.CommentRevision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.comment(taskID, id); !ok {
+		return nil, ErrNotFound
+	}
+	revisions := s.commentRevisions[id]
+	result := make([]api.CommentRevision, 0, len(revisions))
+	for i := len(revisions) - 1; i >= 0; i-- {
+		result = append(result, revisions[i].toAPICommentRevision())
+	}
+	return result, nil
+}
+
+// ゴミ箱にないタスクのコメントを返す（呼び出し側でロックを取得すること）
+func (s *MemoryStore) comment(taskID, id int) (CommentEntity, bool) {
+	c, ok := s.comments[id]
+	if !ok || c.TaskID != taskID {
+		return CommentEntity{}, false
+	}
+	if _, ok := s.activeTask(taskID); !ok {
+		return CommentEntity{}, false
+	}
+	return c, true
+}
+
+// 変更するコメントを取得し、版と投稿者を確認する（呼び出し側でロックを取得すること）
+func (s *MemoryStore) ownComment(ctx context.Context, taskID, id int, version int) (CommentEntity, error) {
+	c, ok := s.comment(taskID, id)
+	if !ok {
+		return CommentEntity{}, ErrNotFound
+	}
+	if err := checkVersion(c.Version, version); err != nil {
+		return CommentEntity{}, err
+	}
+	if err := checkAuthor(ctx, c); err != nil {
+		return CommentEntity{}, err
+	}
+	return c, nil
+}
+
+// タスクのコメント数を返す（呼び出し側でロックを取得すること）
+func (s *MemoryStore) commentCount(taskID int) int {
+	n := 0
+	for _, c := range s.comments {
+		if c.TaskID == taskID {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,124 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// 依存関係を追加
+func (s *MemoryStore) AddTaskDependency(ctx context.Context, taskID, dependsOnID int, version int) (api.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, err := s.writableTask(taskID)
+	if err != nil {
+		return api.Task{}, err
+	}
+	if err := checkVersion(e.Version, version); err != nil {
+		return api.Task{}, err
+	}
+	if taskID == dependsOnID {
+		return api.Task{}, dependencyCycle(taskID, dependsOnID)
+	}
+	if _, ok := s.activeTask(dependsOnID); !ok {
+		return api.Task{}, invalidDependency(dependsOnID)
+	}
+	// 依存先の依存先をたどって自分自身が含まれていれば循環（ゴミ箱のタスクを経由するものも含む）
+	if s.dependsOn(dependsOnID, taskID) {
+		return api.Task{}, dependencyCycle(taskID, dependsOnID)
+	}
+	if s.dependencies[taskID][dependsOnID] {
+		return api.Task{}, duplicateDependency(taskID, dependsOnID)
+	}
+
+	before := s.dependencyIDsOf(taskID)
+	if s.dependencies[taskID] == nil {
+		s.dependencies[taskID] = make(map[int]bool)
+	}
+	s.dependencies[taskID][dependsOnID] = true
+	e = s.touchDependencies(ctx, e, before)
+	return s.apiTask(e), nil
+}
+
+// 依存関係を削除
+func (s *MemoryStore) RemoveTaskDependency(ctx context.Context, taskID, dependsOnID int, version int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, err := s.writableTask(taskID)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(e.Version, version); err != nil {
+		return err
+	}
+	if !s.dependencies[taskID][dependsOnID] {
+		return ErrNotFound
+	}
+
+	before := s.dependencyIDsOf(taskID)
+	delete(s.dependencies[taskID], dependsOnID)
+	s.touchDependencies(ctx, e, before)
+	return nil
+}
+
+// 依存関係はタスクの表現の一部なので、タスクの版を進めて変更履歴を記録する（呼び出し側でロックを取得すること）
+func (s *MemoryStore) touchDependencies(ctx context.Context, e TaskEntity, before []int) TaskEntity {
+	s.record(ctx, taskDependenciesChange(e.ID, before, s.dependencyIDsOf(e.ID)))
+	e.UpdatedAt = time.Now()
+	e.UpdatedBy = actorFromContext(ctx)
+	e.Version++
+	s.tasks[e.ID] = e
+	return e
+}
+
+// from のタスクが to のタスクに直接または間接的に依存しているか（呼び出し側でロックを取得すること）
+func (s *MemoryStore) dependsOn(from, to int) bool {
+	seen := map[int]bool{from: true}
+	queue := []int{from}
+	for len(queue) > 0 {
+		for id := range s.dependencies[queue[0]] {
+			if id == to {
+				return true
+			}
+			if !seen[id] {
+				seen[id] = true
+				queue = append(queue, id)
+			}
+		}
+		queue = queue[1:]
+	}
+	return false
+}
+
+// 完了していない依存先がないことを確認する（呼び出し側でロックを取得すること）
+func (s *MemoryStore) checkUnblocked(taskID int) error {
+	var task api.Task
+	setDependencies(&task, s.dependenciesOf(taskID))
+	if *task.Blocked {
+		return blockedTask(taskID, task.BlockedBy)
+	}
+	return nil
+}
+
+// タスクが依存するタスク（ゴミ箱のものを除く）を依存先のIDの順に返す（呼び出し側でロックを取得すること）
+func (s *MemoryStore) dependenciesOf(taskID int) []dependency {
+	var deps []dependency
+	for _, id := range s.dependencyIDsOf(taskID) {
+		if d, ok := s.activeTask(id); ok {
+			deps = append(deps, dependency{TaskID: taskID, DependsOnID: id, Status: d.Status})
+		}
+	}
+	return deps
+}
+
+// タスクが依存するタスクのIDを昇順で返す（ゴミ箱のタスクも含む、呼び出し側でロックを取得すること）
+func (s *MemoryStore) dependencyIDsOf(taskID int) []int {
+	var ids []int
+	for id := range s.dependencies[taskID] {
+		ids = append(ids, id)
+	}
+	return sortedIDs(ids)
+}
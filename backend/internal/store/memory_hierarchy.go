@@ -0,0 +1,98 @@
+package store
+
+import (
+	"context"
+	"sort"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// isActive はゴミ箱にないタスクかどうかを判定します
+func isActive(e TaskEntity) bool {
+	return e.DeletedAt == nil
+}
+
+// タスクをラベル、サブタスクの集計とコメント数付きで返す（呼び出し側でロックを取得すること）
+func (s *MemoryStore) apiTask(e TaskEntity) api.Task {
+	task := e.ToAPITask()
+	task.Labels = s.labelsOf(e.ID)
+	task.Assignees = s.assigneesOf(e.ID)
+
+	counts := make(map[string]int)
+	for _, c := range s.children(e.ID, isActive) {
+		counts[c.Status]++
+	}
+	task.Subtasks = subtaskRollup(counts)
+	setDependencies(&task, s.dependenciesOf(e.ID))
+	commentCount := s.commentCount(e.ID)
+	task.CommentCount = &commentCount
+	return task
+}
+
+// 直下のサブタスクのうち keep に一致するものをIDの順に返す（呼び出し側でロックを取得すること）
+func (s *MemoryStore) children(id int, keep func(TaskEntity) bool) []TaskEntity {
+	var children []TaskEntity
+	for _, e := range s.tasks {
+		if e.ParentID != nil && *e.ParentID == id && keep(e) {
+			children = append(children, e)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool { return children[i].ID < children[j].ID })
+	return children
+}
+
+// keep に一致するサブタスクをたどった子孫をIDの順に返す（呼び出し側でロックを取得すること）
+func (s *MemoryStore) descendants(id int, keep func(TaskEntity) bool) []TaskEntity {
+	var result []TaskEntity
+	seen := map[int]bool{id: true}
+	queue := []int{id}
+	for len(queue) > 0 {
+		for _, c := range s.children(queue[0], keep) {
+			if !seen[c.ID] {
+				seen[c.ID] = true
+				result = append(result, c)
+				queue = append(queue, c.ID)
+			}
+		}
+		queue = queue[1:]
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// 親に指定したタスクが同じプロジェクトに存在し、taskID 自身やその子孫でないことを確認する（呼び出し側でロックを取得すること）
+func (s *MemoryStore) checkParent(taskID int, projectID int, parentID *int) error {
+	if parentID == nil {
+		return nil
+	}
+	parent, ok := s.activeTask(*parentID)
+	if !ok {
+		return invalidParent(*parentID)
+	}
+	if parent.ProjectID != projectID {
+		return foreignParent(*parentID)
+	}
+	// 親の祖先をたどって自分自身が含まれていれば循環
+	seen := make(map[int]bool)
+	for id := parentID; id != nil && !seen[*id]; id = s.tasks[*id].ParentID {
+		if *id == taskID {
+			return parentCycle(taskID, *parentID)
+		}
+		seen[*id] = true
+	}
+	return nil
+}
+
+// タスクを1件ずつ update で更新し、変更履歴を action として記録する（呼び出し側でロックを取得すること）
+func (s *MemoryStore) updateEach(ctx context.Context, tasks []TaskEntity, action api.AuditEntryAction, update func(*TaskEntity)) {
+	for _, t := range tasks {
+		// 先に更新したタスクの集計の変更で版が進んでいることがあるため、最新の状態から更新する
+		before := s.tasks[t.ID]
+		e := before
+		update(&e)
+		e.Version++
+		s.tasks[e.ID] = e
+		s.record(ctx, taskChange(action, &before, &e))
+		s.touchRelated(&before, &e)
+	}
+}
@@ -0,0 +1,146 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// プロジェクトのメンバーをユーザー名の順に取得
+func (s *MemoryStore) ListProjectMembers(ctx context.Context, projectID int) ([]api.ProjectMember, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.projects[projectID]; !ok {
+		return nil, ErrNotFound
+	}
+	var members []api.ProjectMember
+	for _, m := range s.members[projectID] {
+		members = append(members, m)
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Username < members[j].Username })
+	return members, nil
+}
+
+// ユーザーが参加しているプロジェクトのIDごとの役割を取得
+func (s *MemoryStore) ProjectRoles(ctx context.Context, userID int) (map[int]api.ProjectRole, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	roles := make(map[int]api.ProjectRole)
+	for projectID, members := range s.members {
+		if m, ok := members[userID]; ok {
+			roles[projectID] = m.Role
+		}
+	}
+	return roles, nil
+}
+
+// ユーザーをプロジェクトのメンバーにするか、役割を変更する
+func (s *MemoryStore) SetProjectMember(ctx context.Context, projectID, userID int, role api.ProjectRole) (api.ProjectMember, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.projects[projectID]; !ok {
+		return api.ProjectMember{}, ErrNotFound
+	}
+	u, ok := s.users[userID]
+	if !ok {
+		return api.ProjectMember{}, ErrNotFound
+	}
+	before, ok := s.members[projectID][userID]
+	if !ok {
+		return s.addMember(ctx, projectID, u, role), nil
+	}
+	if before.Role == role {
+		return before, nil
+	}
+	if before.Role == api.ProjectRoleAdmin && s.adminCount(projectID) == 1 {
+		return api.ProjectMember{}, ErrLastAdmin
+	}
+	m := before
+	m.Role = role
+	m.UpdatedAt = time.Now()
+	s.members[projectID][userID] = m
+	s.record(ctx, projectMemberChange(projectID, m.Username, &before.Role, &m.Role))
+	return m, nil
+}
+
+// ユーザーをプロジェクトのメンバーから外す
+func (s *MemoryStore) RemoveProjectMember(ctx context.Context, projectID, userID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.members[projectID][userID]
+	if !ok {
+		return ErrNotFound
+	}
+	if m.Role == api.ProjectRoleAdmin && s.adminCount(projectID) == 1 {
+		return ErrLastAdmin
+	}
+	delete(s.members[projectID], userID)
+	s.record(ctx, projectMemberChange(projectID, m.Username, &m.Role, nil))
+	return nil
+}
+
+// タスクが属するプロジェクトのIDを取得
+func (s *MemoryStore) TaskProjectID(ctx context.Context, taskID int) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.tasks[taskID]
+	if !ok {
+		return 0, ErrNotFound
+	}
+	return e.ProjectID, nil
+}
+
+// ユーザーをプロジェクトのメンバーに追加し、変更履歴を記録する（呼び出し側でロックを取得すること）
+func (s *MemoryStore) addMember(ctx context.Context, projectID int, u UserEntity, role api.ProjectRole) api.ProjectMember {
+	now := time.Now()
+	m := api.ProjectMember{UserId: u.ID, Username: u.Username, Role: role, CreatedAt: now, UpdatedAt: now}
+	if s.members[projectID] == nil {
+		s.members[projectID] = make(map[int]api.ProjectMember)
+	}
+	s.members[projectID][u.ID] = m
+	s.record(ctx, projectMemberChange(projectID, u.Username, nil, &role))
+	return m
+}
+
+// プロジェクトの admin の数を返す（呼び出し側でロックを取得すること）
+func (s *MemoryStore) adminCount(projectID int) int {
+	count := 0
+	for _, m := range s.members[projectID] {
+		if m.Role == api.ProjectRoleAdmin {
+			count++
+		}
+	}
+	return count
+}
+
+// ユーザーが scope のいずれかの役割でプロジェクトに参加しているかを判定（呼び出し側でロックを取得すること）
+func (s *MemoryStore) isMember(scope MemberScope, projectID int) bool {
+	m, ok := s.members[projectID][scope.UserID]
+	return ok && scope.allows(m.Role)
+}
+
+// 変更履歴の対象を scope のユーザーが閲覧できるかを判定（呼び出し側でロックを取得すること）
+// 完全に削除したタスクやラベルはプロジェクトが分からないため、閲覧できないものとして扱う
+func (s *MemoryStore) auditVisible(scope *MemberScope, e api.AuditEntry) bool {
+	if scope == nil {
+		return true
+	}
+	switch e.EntityType {
+	case api.AuditEntryEntityTypeTask:
+		t, ok := s.tasks[e.EntityId]
+		return ok && s.isMember(*scope, t.ProjectID)
+	case api.AuditEntryEntityTypeLabel:
+		l, ok := s.labels[e.EntityId]
+		return ok && s.isMember(*scope, l.ProjectId)
+	case api.AuditEntryEntityTypeProject:
+		return s.isMember(*scope, e.EntityId)
+	}
+	return false
+}
@@ -0,0 +1,259 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// プロジェクト一覧を取得
+func (s *MemoryStore) ListProjects(ctx context.Context, archived bool) ([]api.Project, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var projects []api.Project
+	for _, p := range s.projects {
+		if (p.ArchivedAt != nil) == archived {
+			projects = append(projects, p)
+		}
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		if projects[i].Name != projects[j].Name {
+			return projects[i].Name < projects[j].Name
+		}
+		return projects[i].Id < projects[j].Id
+	})
+	return projects, nil
+}
+
+// IDのプロジェクトを取得
+func (s *MemoryStore) GetProject(ctx context.Context, id int) (api.Project, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.projects[id]
+	if !ok {
+		return api.Project{}, ErrNotFound
+	}
+	return p, nil
+}
+
+// プロジェクトを作成
+func (s *MemoryStore) CreateProject(ctx context.Context, input api.ProjectInput) (api.Project, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkProjectName(0, input.Name); err != nil {
+		return api.Project{}, err
+	}
+	now := time.Now()
+	p := api.Project{
+		Id:          s.nextProjectID,
+		Name:        input.Name,
+		Description: input.Description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		CreatedBy:   actorFromContext(ctx),
+		UpdatedBy:   actorFromContext(ctx),
+		Version:     1,
+	}
+	s.projects[p.Id] = p
+	s.nextProjectID++
+	s.record(ctx, projectChange(api.Create, nil, &p))
+
+	// 作成したユーザーを admin にする（認証なしで作成した場合はメンバーを追加しない）
+	if actor := actorFromContext(ctx); actor != nil {
+		for _, u := range s.users {
+			if u.Username == *actor {
+				s.addMember(ctx, p.Id, u, api.ProjectRoleAdmin)
+			}
+		}
+	}
+	return p, nil
+}
+
+// 指定したIDのプロジェクトを更新
+func (s *MemoryStore) UpdateProject(ctx context.Context, id int, input api.ProjectInput, version int) (api.Project, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before, ok := s.projects[id]
+	if !ok {
+		return api.Project{}, ErrNotFound
+	}
+	if err := checkVersion(before.Version, version); err != nil {
+		return api.Project{}, err
+	}
+	if err := checkWritable(before); err != nil {
+		return api.Project{}, err
+	}
+	if err := s.checkProjectName(id, input.Name); err != nil {
+		return api.Project{}, err
+	}
+	p := before
+	p.Name = input.Name
+	p.Description = input.Description
+	p.UpdatedAt = time.Now()
+	p.UpdatedBy = actorFromContext(ctx)
+	p.Version++
+	s.projects[id] = p
+	s.record(ctx, projectChange(api.Update, &before, &p))
+	return p, nil
+}
+
+// プロジェクトをアーカイブするか、アーカイブから戻す
+func (s *MemoryStore) SetProjectArchived(ctx context.Context, id int, archived bool, version int) (api.Project, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before, ok := s.projects[id]
+	if !ok {
+		return api.Project{}, ErrNotFound
+	}
+	if err := checkVersion(before.Version, version); err != nil {
+		return api.Project{}, err
+	}
+	if (before.ArchivedAt != nil) == archived {
+		return before, nil
+	}
+	now := time.Now()
+	p := before
+	p.ArchivedAt = nil
+	if archived {
+		p.ArchivedAt = &now
+	}
+	p.UpdatedAt = now
+	p.UpdatedBy = actorFromContext(ctx)
+	p.Version++
+	s.projects[id] = p
+	s.record(ctx, projectChange(api.Update, &before, &p))
+	return p, nil
+}
+
+// タスクをサブタスクごと別のプロジェクトに移動し、ラベルを移動先のラベルに付け替える
+func (s *MemoryStore) MoveTask(ctx context.Context, id int, move TaskMove, version int) (api.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	root, err := s.writableTask(id)
+	if err != nil {
+		return api.Task{}, err
+	}
+	if err := checkVersion(root.Version, version); err != nil {
+		return api.Task{}, err
+	}
+	if _, ok := s.projects[move.ProjectID]; !ok {
+		return api.Task{}, invalidProject(move.ProjectID)
+	}
+	if err := s.checkProject(move.ProjectID); err != nil {
+		return api.Task{}, err
+	}
+	if root.ProjectID == move.ProjectID {
+		return s.apiTask(root), nil
+	}
+
+	// 親子関係を変えずに移動するため、サブタスクはゴミ箱のものも含めて移動する
+	subtasks := s.descendants(id, func(TaskEntity) bool { return true })
+	taskIDs := []int{id}
+	for _, e := range subtasks {
+		taskIDs = append(taskIDs, e.ID)
+	}
+
+	var source, targets []api.Label
+	seen := make(map[int]bool)
+	for _, taskID := range taskIDs {
+		for _, l := range s.labelsOf(taskID) {
+			if !seen[l.ID] {
+				seen[l.ID] = true
+				source = append(source, l)
+			}
+		}
+	}
+	sortLabels(source)
+	for _, l := range s.labels {
+		if l.ProjectId == move.ProjectID {
+			targets = append(targets, l)
+		}
+	}
+	sortLabels(targets)
+	plan, err := planLabelRemap(move, source, targets)
+	if err != nil {
+		return api.Task{}, err
+	}
+	for _, l := range plan.create {
+		created := s.insertLabel(ctx, move.ProjectID, api.LabelInput{Name: l.Name, Color: l.Color})
+		plan.mapped[l.ID] = created.ID
+	}
+
+	// 親は移動しないため、移動するタスクは最上位のタスクにする
+	now := time.Now()
+	e := root
+	e.ProjectID = move.ProjectID
+	e.ParentID = nil
+	e.UpdatedAt = now
+	e.UpdatedBy = actorFromContext(ctx)
+	e.Version++
+	s.tasks[id] = e
+	s.record(ctx, taskChange(api.Update, &root, &e))
+	s.touchRelated(&root, &e)
+	s.updateEach(ctx, subtasks, api.Update, func(c *TaskEntity) {
+		c.ProjectID = move.ProjectID
+		c.UpdatedAt = now
+		c.UpdatedBy = actorFromContext(ctx)
+	})
+	for _, taskID := range taskIDs {
+		s.remapTaskLabels(ctx, taskID, plan)
+	}
+	return s.apiTask(s.tasks[id]), nil
+}
+
+// 移動したタスクのラベルを付け替え、変更履歴を記録する（呼び出し側でロックを取得すること）
+func (s *MemoryStore) remapTaskLabels(ctx context.Context, taskID int, plan labelRemap) {
+	before := s.labelIDsOf(taskID)
+	if len(before) == 0 {
+		return
+	}
+	after := plan.remap(before)
+	set := make(map[int]bool, len(after))
+	for _, labelID := range after {
+		set[labelID] = true
+	}
+	s.taskLabels[taskID] = set
+	s.record(ctx, taskLabelsChange(taskID, before, after))
+}
+
+// 作成先のプロジェクトを決める（0 の場合は親のプロジェクト、親がなければ既定のプロジェクト）（呼び出し側でロックを取得すること）
+func (s *MemoryStore) newTaskProject(projectID int, parentID *int) (int, error) {
+	if projectID != 0 {
+		return projectID, nil
+	}
+	if parentID == nil {
+		return DefaultProjectID, nil
+	}
+	parent, ok := s.activeTask(*parentID)
+	if !ok {
+		return 0, invalidParent(*parentID)
+	}
+	return parent.ProjectID, nil
+}
+
+// プロジェクトが存在し、アーカイブされていないことを確認する（呼び出し側でロックを取得すること）
+func (s *MemoryStore) checkProject(id int) error {
+	p, ok := s.projects[id]
+	if !ok {
+		return ErrNotFound
+	}
+	return checkWritable(p)
+}
+
+// 一意制約と同じく、id 以外に同じ名前のプロジェクトがないことを確認する（呼び出し側でロックを取得すること）
+func (s *MemoryStore) checkProjectName(id int, name string) error {
+	for _, p := range s.projects {
+		if p.Id != id && p.Name == name {
+			return duplicateProjectName(name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package store
+
+import (
+	"context"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// タスクの次の繰り返しを取得
+func (s *MemoryStore) PreviewRecurrence(ctx context.Context, id int, n int) (api.RecurrencePreview, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.activeTask(id)
+	if !ok {
+		return api.RecurrencePreview{}, ErrNotFound
+	}
+	return recurrencePreview(e, n)
+}
+
+// 繰り返しのタスクを次の繰り返しの日付に移す
+func (s *MemoryStore) SkipOccurrence(ctx context.Context, id int, version int) (api.Task, error) {
+	return s.updateRecurrence(ctx, id, version, skipOccurrence)
+}
+
+// タスクの繰り返しを終了する
+func (s *MemoryStore) EndRecurrence(ctx context.Context, id int, version int) (api.Task, error) {
+	return s.updateRecurrence(ctx, id, version, endRecurrence)
+}
+
+// タスクを change で変更し、更新後のタスクを返す
+func (s *MemoryStore) updateRecurrence(ctx context.Context, id int, version int, change func(TaskEntity) (TaskEntity, error)) (api.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before, err := s.writableTask(id)
+	if err != nil {
+		return api.Task{}, err
+	}
+	if err := checkVersion(before.Version, version); err != nil {
+		return api.Task{}, err
+	}
+	e, err := change(before)
+	if err != nil {
+		return api.Task{}, err
+	}
+	if e, err = s.writeTask(ctx, before, e); err != nil {
+		return api.Task{}, err
+	}
+	return s.apiTask(e), nil
+}
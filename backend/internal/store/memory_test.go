@@ -0,0 +1,456 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// newTestTask はタスクを作成してIDを返します
+func newTestTask(t *testing.T, s *MemoryStore, input api.TaskInput) int {
+	t.Helper()
+	id, err := s.CreateTask(context.Background(), DefaultProjectID, input)
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	return id
+}
+
+// getTestTask はタスクを取得します
+func getTestTask(t *testing.T, s *MemoryStore, id int) api.Task {
+	t.Helper()
+	task, err := s.GetTask(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetTask(%d): %v", id, err)
+	}
+	return task
+}
+
+// assertVersionBumped は fn の実行で、タスクの表現が変わるとともに版が進むことを確認します
+func assertVersionBumped(t *testing.T, s *MemoryStore, id int, fn func()) {
+	t.Helper()
+	before := getTestTask(t, s, id)
+	fn()
+	after := getTestTask(t, s, id)
+	if *after.Version <= *before.Version {
+		t.Errorf("task %d version = %d after change, want > %d", id, *after.Version, *before.Version)
+	}
+}
+
+// newTestSubtask は parentID のタスクのサブタスクを作成してIDを返します
+func newTestSubtask(t *testing.T, s *MemoryStore, name string, parentID int) int {
+	t.Helper()
+	input := taskInput(name, "Middle", "NotStarted")
+	input.ParentId = &parentID
+	return newTestTask(t, s, input)
+}
+
+// setTestStatus はタスクの状態を変更します
+func setTestStatus(t *testing.T, s *MemoryStore, id int, status api.TaskStatus) {
+	t.Helper()
+	value := string(status)
+	patch := TaskPatch{Status: &value}
+	if _, err := s.PatchTask(context.Background(), id, patch, AnyVersion); err != nil {
+		t.Fatalf("PatchTask(%d): %v", id, err)
+	}
+}
+
+// taskInput は名前と優先度、状態を指定したタスクの入力を返します
+func taskInput(name, priority, status string) api.TaskInput {
+	return api.TaskInput{Name: name, Priority: api.TaskInputPriority(priority), Status: api.TaskInputStatus(status)}
+}
+
+func TestMemoryStoreTaskCRUD(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{})
+	input := taskInput("write report", "High", "NotStarted")
+	description := "quarterly"
+	input.Description = &description
+	id := newTestTask(t, s, input)
+
+	task, err := s.GetTask(ctx, id)
+	if err != nil {
+		t.Fatalf("GetTask: %v", err)
+	}
+	if *task.Name != "write report" || *task.Description != description || *task.Priority != api.TaskPriorityHigh {
+		t.Fatalf("created task = %+v", task)
+	}
+
+	if err := s.UpdateTask(ctx, id, taskInput("write annual report", "Low", "InProgress"), AnyVersion); err != nil {
+		t.Fatalf("UpdateTask: %v", err)
+	}
+	updated, err := s.GetTask(ctx, id)
+	if err != nil {
+		t.Fatalf("GetTask: %v", err)
+	}
+	// 更新は全体の置き換えで、作成日時は変えない
+	if *updated.Name != "write annual report" || updated.Description != nil || *updated.Status != api.TaskStatusInProgress ||
+		!updated.CreatedAt.Equal(*task.CreatedAt) {
+		t.Fatalf("updated task = %+v", updated)
+	}
+
+	if err := s.DeleteTask(ctx, id, AnyVersion); err != nil {
+		t.Fatalf("DeleteTask: %v", err)
+	}
+	if _, err := s.GetTask(ctx, id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetTask after DeleteTask error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryStoreListTasks(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{})
+	day := func(d int) *time.Time {
+		t := time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	for _, tt := range []struct {
+		name, priority, status string
+		end                    *time.Time
+	}{
+		{"b report", "Middle", "NotStarted", day(3)},
+		{"a report", "Middle", "Completed", day(2)},
+		{"call", "High", "NotStarted", day(9)},
+		{"Report review", "Low", "NotStarted", day(1)},
+	} {
+		input := taskInput(tt.name, tt.priority, tt.status)
+		input.EndDate = tt.end
+		newTestTask(t, s, input)
+	}
+
+	tests := []struct {
+		name   string
+		filter TaskFilter
+		want   string
+	}{
+		// 既定では priority, end_date の順に並べる
+		{"all", TaskFilter{}, "[call a report b report Report review]"},
+		{"status", TaskFilter{Statuses: []string{"NotStarted"}}, "[call b report Report review]"},
+		{"name ignores case", TaskFilter{Name: "REPORT"}, "[a report b report Report review]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := s.ListTasks(ctx, TaskQuery{Filter: tt.filter})
+			if err != nil {
+				t.Fatalf("ListTasks: %v", err)
+			}
+			if got := fmt.Sprint(taskNames(page.Tasks)); got != tt.want {
+				t.Errorf("tasks = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreLabels(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{})
+	bug, err := s.CreateLabel(ctx, DefaultProjectID, api.LabelInput{Name: "bug", Color: "#FF0000"})
+	if err != nil {
+		t.Fatalf("CreateLabel: %v", err)
+	}
+	docs, err := s.CreateLabel(ctx, DefaultProjectID, api.LabelInput{Name: "docs", Color: "#0000FF"})
+	if err != nil {
+		t.Fatalf("CreateLabel: %v", err)
+	}
+	task := newTestTask(t, s, taskInput("task", "Middle", "NotStarted"))
+
+	if err := s.SetTaskLabels(ctx, task, []int{docs, bug}, AnyVersion); err != nil {
+		t.Fatalf("SetTaskLabels: %v", err)
+	}
+	// ラベルは名前順に返す
+	if got, _ := s.GetTask(ctx, task); len(got.Labels) != 2 || got.Labels[0].ID != bug || got.Labels[1].ID != docs {
+		t.Fatalf("labels = %+v, want bug and docs", got.Labels)
+	}
+
+	if err := s.UpdateLabel(ctx, bug, api.LabelInput{Name: "defect", Color: "#00FF00"}, AnyVersion); err != nil {
+		t.Fatalf("UpdateLabel: %v", err)
+	}
+	if l, err := s.GetLabel(ctx, bug); err != nil || l.Name != "defect" || l.Color != "#00FF00" {
+		t.Fatalf("GetLabel = %+v, %v, want the updated label", l, err)
+	}
+
+	// ラベルを削除するとタスクとの関連付けも外れる
+	if err := s.DeleteLabel(ctx, docs, AnyVersion); err != nil {
+		t.Fatalf("DeleteLabel: %v", err)
+	}
+	if got, _ := s.GetTask(ctx, task); len(got.Labels) != 1 || got.Labels[0].Name != "defect" {
+		t.Errorf("labels after deleting docs = %+v, want defect", got.Labels)
+	}
+	if labels, err := s.ListLabels(ctx, 0); err != nil || len(labels) != len(defaultLabels)+1 {
+		t.Errorf("ListLabels = %+v, %v, want the default labels and defect", labels, err)
+	}
+
+	// 外部キー制約と同じく、存在しないタスクやラベルは拒否する
+	if err := s.SetTaskLabels(ctx, task, []int{docs}, AnyVersion); !errors.Is(err, ErrInvalidReference) {
+		t.Errorf("SetTaskLabels with a deleted label error = %v, want ErrInvalidReference", err)
+	}
+	if err := s.SetTaskLabels(ctx, 999, []int{bug}, AnyVersion); !errors.Is(err, ErrNotFound) {
+		t.Errorf("SetTaskLabels for a missing task error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryStoreSeedsDefaultLabels(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{})
+	labels, err := s.ListLabels(ctx, 0)
+	if err != nil {
+		t.Fatalf("ListLabels: %v", err)
+	}
+	if len(labels) != len(defaultLabels) {
+		t.Fatalf("ListLabels returned %d labels, want %d", len(labels), len(defaultLabels))
+	}
+	names := make(map[string]bool)
+	for _, l := range labels {
+		names[l.Name] = true
+	}
+	for _, l := range defaultLabels {
+		if !names[l.Name] {
+			t.Errorf("default label %q is missing", l.Name)
+		}
+	}
+
+	// 新しいラベルのIDは既定のラベルと重ならない
+	id, err := s.CreateLabel(ctx, DefaultProjectID, api.LabelInput{Name: "bug", Color: "#000000"})
+	if err != nil {
+		t.Fatalf("CreateLabel: %v", err)
+	}
+	if id != len(defaultLabels)+1 {
+		t.Errorf("CreateLabel returned id %d, want %d", id, len(defaultLabels)+1)
+	}
+}
+
+func TestMemoryStoreLabelChangesBumpTaskVersion(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{})
+	task := newTestTask(t, s, taskInput("task", "Middle", "NotStarted"))
+	label, err := s.CreateLabel(ctx, DefaultProjectID, api.LabelInput{Name: "bug", Color: "#ff0000"})
+	if err != nil {
+		t.Fatalf("CreateLabel: %v", err)
+	}
+	// 重複したIDは1つにまとめる
+	if err := s.SetTaskLabels(ctx, task, []int{label, label}, AnyVersion); err != nil {
+		t.Fatalf("SetTaskLabels: %v", err)
+	}
+	if got := getTestTask(t, s, task).Labels; len(got) != 1 {
+		t.Fatalf("labels = %+v, want 1 label", got)
+	}
+
+	assertVersionBumped(t, s, task, func() {
+		if err := s.UpdateLabel(ctx, label, api.LabelInput{Name: "defect", Color: "#ff0000"}, AnyVersion); err != nil {
+			t.Fatalf("UpdateLabel: %v", err)
+		}
+	})
+	assertVersionBumped(t, s, task, func() {
+		if err := s.DeleteLabel(ctx, label, AnyVersion); err != nil {
+			t.Fatalf("DeleteLabel: %v", err)
+		}
+	})
+	if got := getTestTask(t, s, task).Labels; len(got) != 0 {
+		t.Fatalf("labels = %+v after deleting the label, want none", got)
+	}
+}
+
+func TestMemoryStorePurgeTasks(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{})
+	old := newTestTask(t, s, taskInput("old", "Middle", "NotStarted"))
+	recent := newTestTask(t, s, taskInput("recent", "Middle", "NotStarted"))
+	active := newTestTask(t, s, taskInput("active", "Middle", "NotStarted"))
+	if err := s.DeleteTask(ctx, old, AnyVersion); err != nil {
+		t.Fatalf("DeleteTask: %v", err)
+	}
+	// 削除日時が区切りの日時と重ならないよう間をあける
+	time.Sleep(time.Millisecond)
+	cutoff := time.Now()
+	time.Sleep(time.Millisecond)
+	if err := s.DeleteTask(ctx, recent, AnyVersion); err != nil {
+		t.Fatalf("DeleteTask: %v", err)
+	}
+
+	// 指定した日時より前にゴミ箱に移動したタスクだけを削除する
+	n, err := s.PurgeTasks(ctx, cutoff)
+	if err != nil || n != 1 {
+		t.Fatalf("PurgeTasks = %d, %v, want 1", n, err)
+	}
+	if _, err := s.RestoreTask(ctx, old, AnyVersion); !errors.Is(err, ErrNotFound) {
+		t.Errorf("RestoreTask of a purged task = %v, want ErrNotFound", err)
+	}
+	if _, err := s.RestoreTask(ctx, recent, AnyVersion); err != nil {
+		t.Errorf("RestoreTask of a recently trashed task: %v", err)
+	}
+	getTestTask(t, s, active)
+}
+
+func TestMemoryStoreSubtaskChangesBumpParentVersion(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{})
+	parent := newTestTask(t, s, taskInput("parent", "Middle", "NotStarted"))
+	other := newTestTask(t, s, taskInput("other", "Middle", "NotStarted"))
+
+	var child int
+	assertVersionBumped(t, s, parent, func() { child = newTestSubtask(t, s, "child", parent) })
+	assertVersionBumped(t, s, parent, func() { setTestStatus(t, s, child, api.TaskStatusCompleted) })
+	if got := getTestTask(t, s, parent).Subtasks; got == nil || got.Completed != 1 {
+		t.Fatalf("parent rollup = %+v, want 1 completed", got)
+	}
+
+	// 親の付け替えでは、元の親と新しい親の両方の集計が変わる
+	assertVersionBumped(t, s, parent, func() {
+		assertVersionBumped(t, s, other, func() {
+			if _, err := s.PatchTask(ctx, child, TaskPatch{ParentID: Nullable[int]{Set: true, Value: &other}}, AnyVersion); err != nil {
+				t.Fatalf("PatchTask: %v", err)
+			}
+		})
+	})
+
+	assertVersionBumped(t, s, other, func() {
+		if err := s.DeleteTask(ctx, child, AnyVersion); err != nil {
+			t.Fatalf("DeleteTask: %v", err)
+		}
+	})
+	assertVersionBumped(t, s, other, func() {
+		if _, err := s.RestoreTask(ctx, child, AnyVersion); err != nil {
+			t.Fatalf("RestoreTask: %v", err)
+		}
+	})
+
+	// 集計が変わらない変更では親の版を進めない
+	before := getTestTask(t, s, other)
+	name := "renamed"
+	if _, err := s.PatchTask(ctx, child, TaskPatch{Name: &name}, AnyVersion); err != nil {
+		t.Fatalf("PatchTask: %v", err)
+	}
+	if after := getTestTask(t, s, other); *after.Version != *before.Version {
+		t.Errorf("parent version = %d after renaming a subtask, want %d", *after.Version, *before.Version)
+	}
+}
+
+func TestMemoryStoreRestoreReturnsCurrentVersion(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{DeletePolicy: DeleteCascade})
+	parent := newTestTask(t, s, taskInput("parent", "Middle", "NotStarted"))
+	newTestSubtask(t, s, "child", parent)
+	if err := s.DeleteTask(ctx, parent, AnyVersion); err != nil {
+		t.Fatalf("DeleteTask: %v", err)
+	}
+	restored, err := s.RestoreTask(ctx, parent, AnyVersion)
+	if err != nil {
+		t.Fatalf("RestoreTask: %v", err)
+	}
+	if current := getTestTask(t, s, parent); *restored.Version != *current.Version {
+		t.Errorf("RestoreTask returned version %d, stored version is %d", *restored.Version, *current.Version)
+	}
+}
+
+func TestMemoryStoreBlockerChangesBumpDependentVersion(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{})
+	blocker := newTestTask(t, s, taskInput("blocker", "Middle", "NotStarted"))
+	dependent := newTestTask(t, s, taskInput("dependent", "Middle", "NotStarted"))
+	if _, err := s.AddTaskDependency(ctx, dependent, blocker, AnyVersion); err != nil {
+		t.Fatalf("AddTaskDependency: %v", err)
+	}
+	if task := getTestTask(t, s, dependent); !*task.Blocked {
+		t.Fatalf("dependent blocked = false, want true")
+	}
+
+	assertVersionBumped(t, s, dependent, func() { setTestStatus(t, s, blocker, api.TaskStatusCompleted) })
+	if task := getTestTask(t, s, dependent); *task.Blocked {
+		t.Fatalf("dependent blocked = true after completing the blocker, want false")
+	}
+	assertVersionBumped(t, s, dependent, func() {
+		if err := s.DeleteTask(ctx, blocker, AnyVersion); err != nil {
+			t.Fatalf("DeleteTask: %v", err)
+		}
+	})
+	assertVersionBumped(t, s, dependent, func() {
+		if _, err := s.RestoreTask(ctx, blocker, AnyVersion); err != nil {
+			t.Fatalf("RestoreTask: %v", err)
+		}
+	})
+}
+
+func TestMemoryStorePurgeClearsSeries(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{})
+	rule := "FREQ=DAILY"
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	input := taskInput("daily", "Middle", "NotStarted")
+	input.StartDate = &start
+	input.RecurrenceRule = &rule
+	first := newTestTask(t, s, input)
+	setTestStatus(t, s, first, api.TaskStatusCompleted)
+
+	page, err := s.ListTasks(ctx, TaskQuery{})
+	if err != nil {
+		t.Fatalf("ListTasks: %v", err)
+	}
+	next := 0
+	for _, task := range page.Tasks {
+		if task.SeriesId != nil && *task.SeriesId == first && *task.Id != first {
+			next = *task.Id
+		}
+	}
+	if next == 0 {
+		t.Fatalf("no next occurrence of task %d in %+v", first, page.Tasks)
+	}
+
+	// 外部キー制約の ON DELETE SET NULL と同じく、最初のタスクを完全に削除すると繰り返しのIDを外す
+	if err := s.DeleteTask(ctx, first, AnyVersion); err != nil {
+		t.Fatalf("DeleteTask: %v", err)
+	}
+	if n, err := s.PurgeTasks(ctx, time.Now().Add(time.Hour)); err != nil || n != 1 {
+		t.Fatalf("PurgeTasks = %d, %v, want 1", n, err)
+	}
+	if got := getTestTask(t, s, next).SeriesId; got != nil {
+		t.Errorf("series_id = %d after purging the first occurrence, want nil", *got)
+	}
+}
+
+func TestMemoryStoreCommentsBumpTaskVersion(t *testing.T) {
+	ctx := WithActor(context.Background(), "alice")
+	s := NewMemoryStore(Options{})
+	task := newTestTask(t, s, taskInput("task", "Middle", "NotStarted"))
+
+	var comment api.Comment
+	assertVersionBumped(t, s, task, func() {
+		var err error
+		if comment, err = s.CreateComment(ctx, task, "hello"); err != nil {
+			t.Fatalf("CreateComment: %v", err)
+		}
+	})
+	if got := *getTestTask(t, s, task).CommentCount; got != 1 {
+		t.Fatalf("comment_count = %d, want 1", got)
+	}
+	assertVersionBumped(t, s, task, func() {
+		if err := s.DeleteComment(ctx, task, comment.Id, AnyVersion); err != nil {
+			t.Fatalf("DeleteComment: %v", err)
+		}
+	})
+	if got := *getTestTask(t, s, task).CommentCount; got != 0 {
+		t.Fatalf("comment_count = %d, want 0", got)
+	}
+}
+
+func TestMemoryStoreNewUsersJoinNoProject(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{})
+	// 最初に登録したユーザーも admin にはならない
+	for _, username := range []string{"alice", "bob"} {
+		u, err := s.CreateUser(ctx, username, "hash")
+		if err != nil {
+			t.Fatalf("CreateUser: %v", err)
+		}
+		roles, err := s.ProjectRoles(ctx, u.ID)
+		if err != nil {
+			t.Fatalf("ProjectRoles: %v", err)
+		}
+		if len(roles) != 0 {
+			t.Errorf("%s joined projects %v, want no membership", username, roles)
+		}
+	}
+}
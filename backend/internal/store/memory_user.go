@@ -0,0 +1,120 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ユーザーを追加
+func (s *MemoryStore) CreateUser(ctx context.Context, username, passwordHash string) (UserEntity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, u := range s.users {
+		if u.Username == username {
+			return UserEntity{}, duplicateUsername(fmt.Errorf("username %q is already taken", username))
+		}
+	}
+	now := time.Now()
+	u := UserEntity{
+		ID:           s.nextUserID,
+		Username:     username,
+		PasswordHash: passwordHash,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	s.users[u.ID] = u
+	s.nextUserID++
+	return u, nil
+}
+
+// 指定したIDのユーザーを取得
+func (s *MemoryStore) GetUser(ctx context.Context, id int) (UserEntity, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u, ok := s.users[id]
+	if !ok {
+		return UserEntity{}, ErrNotFound
+	}
+	return u, nil
+}
+
+// ユーザー名でユーザーを取得
+func (s *MemoryStore) GetUserByUsername(ctx context.Context, username string) (UserEntity, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, u := range s.users {
+		if u.Username == username {
+			return u, nil
+		}
+	}
+	return UserEntity{}, ErrNotFound
+}
+
+// リフレッシュトークンを記録
+func (s *MemoryStore) CreateRefreshToken(ctx context.Context, token RefreshTokenEntity) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[token.UserID]; !ok {
+		return ErrInvalidReference
+	}
+	token.CreatedAt = time.Now()
+	s.refreshTokens[token.ID] = token
+	return nil
+}
+
+// リフレッシュトークンを新しいものに置き換える
+func (s *MemoryStore) RotateRefreshToken(ctx context.Context, id string, next RefreshTokenEntity) (UserEntity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	token, ok := s.refreshTokens[id]
+	if !ok || !token.ExpiresAt.After(now) {
+		return UserEntity{}, ErrNotFound
+	}
+	if token.RevokedAt != nil {
+		// 使い回しを検知したので、そのユーザーのトークンをすべて失効させる
+		for tokenID, t := range s.refreshTokens {
+			if t.UserID == token.UserID && t.RevokedAt == nil {
+				t.RevokedAt = &now
+				s.refreshTokens[tokenID] = t
+			}
+		}
+		return UserEntity{}, ErrTokenRevoked
+	}
+	u, ok := s.users[token.UserID]
+	if !ok {
+		return UserEntity{}, ErrNotFound
+	}
+
+	token.RevokedAt = &now
+	s.refreshTokens[id] = token
+	next.UserID = token.UserID
+	next.CreatedAt = now
+	s.refreshTokens[next.ID] = next
+	// 期限切れの記録を削除する
+	for tokenID, t := range s.refreshTokens {
+		if t.UserID == token.UserID && !t.ExpiresAt.After(now) {
+			delete(s.refreshTokens, tokenID)
+		}
+	}
+	return u, nil
+}
+
+// リフレッシュトークンを失効させる
+func (s *MemoryStore) RevokeRefreshToken(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if token, ok := s.refreshTokens[id]; ok && token.RevokedAt == nil {
+		now := time.Now()
+		token.RevokedAt = &now
+		s.refreshTokens[id] = token
+	}
+	return nil
+}
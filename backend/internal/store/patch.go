@@ -0,0 +1,74 @@
+package store
+
+import (
+	"fmt"
+	"time"
+)
+
+// Nullable は部分更新で「変更しない」「nullにする」「値を設定する」を区別するための型です
+type Nullable[T any] struct {
+	// Set はフィールドが指定されたかどうかです（false の場合は変更しません）
+	Set bool
+	// Value は設定する値です（nil の場合はnullにします）
+	Value *T
+}
+
+// TaskPatch はタスクの部分更新（JSON Merge Patch）の内容です
+// 必須項目はnullにできないため、nilの場合は変更しません
+type TaskPatch struct {
+	Name        *string
+	Description Nullable[string]
+	StartDate   Nullable[time.Time]
+	EndDate     Nullable[time.Time]
+	Priority    *string
+	Status      *string
+	ParentID    Nullable[int]
+	// RecurrenceRule を null にすると繰り返しを終了します
+	RecurrenceRule     Nullable[string]
+	RecurrenceTimezone Nullable[string]
+}
+
+// apply は指定されたフィールドだけをエンティティに反映します
+func (p TaskPatch) apply(e *TaskEntity) {
+	if p.Name != nil {
+		e.Name = *p.Name
+	}
+	if p.Description.Set {
+		e.Description = p.Description.Value
+	}
+	if p.StartDate.Set {
+		e.StartDate = p.StartDate.Value
+	}
+	if p.EndDate.Set {
+		e.EndDate = p.EndDate.Value
+	}
+	if p.Priority != nil {
+		e.Priority = *p.Priority
+	}
+	if p.Status != nil {
+		e.Status = *p.Status
+	}
+	if p.ParentID.Set {
+		e.ParentID = p.ParentID.Value
+	}
+	if p.RecurrenceRule.Set {
+		e.RecurrenceRule = p.RecurrenceRule.Value
+	}
+	if p.RecurrenceTimezone.Set {
+		e.RecurrenceTimezone = p.RecurrenceTimezone.Value
+	}
+}
+
+// checkDateOrder は部分更新の結果、終了日が開始日より前になっていないかを検証します
+// 片方だけを更新した場合はリクエストの検証では判定できないため、更新後の値で確認します
+func checkDateOrder(e TaskEntity) error {
+	if e.StartDate == nil || e.EndDate == nil || !e.EndDate.Before(*e.StartDate) {
+		return nil
+	}
+	return &ConstraintError{
+		Kind:       ErrConstraint,
+		Field:      "end_date",
+		Constraint: "tasks_date_order",
+		Err:        fmt.Errorf("end_date %s is before start_date %s", e.EndDate.Format(time.RFC3339), e.StartDate.Format(time.RFC3339)),
+	}
+}
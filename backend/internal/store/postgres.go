@@ -0,0 +1,963 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/search"
+)
+
+type TaskEntity struct {
+	ID          int        `db:"id"`
+	Name        string     `db:"name"`
+	Description *string    `db:"description"`
+	StartDate   *time.Time `db:"start_date"`
+	EndDate     *time.Time `db:"end_date"`
+	Priority    string     `db:"priority"`
+	Status      string     `db:"status"`
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at"`
+	// CreatedBy, UpdatedBy は作成した人と最後に更新した人のユーザー名です（認証なしの場合はnil）
+	CreatedBy *string `db:"created_by"`
+	UpdatedBy *string `db:"updated_by"`
+	// ParentID は親タスクのIDです（最上位のタスクではnil）
+	ParentID *int `db:"parent_id"`
+	// ProjectID はタスクが属するプロジェクトのIDです（サブタスクは親と同じプロジェクトに属します）
+	ProjectID int `db:"project_id"`
+	// RecurrenceRule は繰り返しの規則（RRULE）です。繰り返しの最新のタスクだけが持ちます
+	RecurrenceRule     *string `db:"recurrence_rule"`
+	RecurrenceTimezone *string `db:"recurrence_timezone"`
+	// RecurrenceStart は COUNT を数える起点となる最初の繰り返しの日時です
+	RecurrenceStart *time.Time `db:"recurrence_start"`
+	// SeriesID は繰り返しの最初のタスクのIDです（繰り返しでないタスクではnil）
+	SeriesID *int `db:"series_id"`
+	// Version は更新のたびに増える行の版です
+	Version int `db:"version"`
+	// DeletedAt はゴミ箱に移動した日時です（ゴミ箱にない場合はnil）
+	DeletedAt *time.Time `db:"deleted_at"`
+	// SearchRank は全文検索の関連度です（一覧取得時のみ設定されます）
+	SearchRank float64 `db:"search_rank"`
+}
+
+func (e TaskEntity) ToAPITask() api.Task {
+	id := int(e.ID)
+	return api.Task{
+		Id:          &id,
+		Name:        &e.Name,
+		Description: e.Description,
+		StartDate:   e.StartDate,
+		EndDate:     e.EndDate,
+		Priority:    (*api.TaskPriority)(&e.Priority),
+		Status:      (*api.TaskStatus)(&e.Status),
+		CreatedAt:   &e.CreatedAt,
+		UpdatedAt:   &e.UpdatedAt,
+		CreatedBy:   e.CreatedBy,
+		UpdatedBy:   e.UpdatedBy,
+		ParentId:    e.ParentID,
+		ProjectId:   &e.ProjectID,
+		Version:     &e.Version,
+		DeletedAt:   e.DeletedAt,
+
+		RecurrenceRule:     e.RecurrenceRule,
+		RecurrenceTimezone: e.RecurrenceTimezone,
+		SeriesId:           e.SeriesID,
+	}
+}
+
+// PostgresStore はPostgreSQLを使ったTaskStoreとLabelStoreの実装です
+type PostgresStore struct {
+	db   *sqlx.DB
+	opts Options
+}
+
+func NewPostgresStore(db *sqlx.DB, opts Options) *PostgresStore {
+	return &PostgresStore{db: db, opts: opts}
+}
+
+// タスク一覧を取得
+func (s *PostgresStore) ListTasks(ctx context.Context, query TaskQuery) (TaskPage, error) {
+	order, err := parseSort(query.Sort, query.Filter)
+	if err != nil {
+		return TaskPage{}, err
+	}
+	var after []interface{}
+	if query.Cursor != "" {
+		if after, err = decodeCursor(order, query.Cursor); err != nil {
+			return TaskPage{}, err
+		}
+	}
+
+	// ページングに関係なく条件に一致する総数を取得
+	var page TaskPage
+	count := &queryBuilder{}
+	taskConditions(count, query.Filter)
+	countQuery, countArgs := count.build("SELECT COUNT(*) FROM tasks" + count.whereClause())
+	if err := s.db.GetContext(ctx, &page.Total, countQuery, countArgs...); err != nil {
+		return TaskPage{}, classify(ctx, err)
+	}
+
+	// 関連度を列として持つ副問い合わせにして、並び替えとカーソルで列名を使えるようにする
+	b := &queryBuilder{}
+	rank := "0::float8"
+	if query.Filter.Query != "" {
+		rank = searchRankExpression(b, query.Filter.Query)
+	}
+	taskConditions(b, query.Filter)
+	sqlQuery := "SELECT * FROM (SELECT tasks.*, " + rank + " AS search_rank FROM tasks" + b.whereClause() + ") AS tasks"
+	if after != nil {
+		sqlQuery += " WHERE " + keysetCondition(order, after, b.arg)
+	}
+	// 次のページの有無を判定するため1件多く取得する
+	limit := query.PageSize()
+	sqlQuery += orderByClause(order) + " LIMIT " + b.arg(limit+1) + " OFFSET " + b.arg(query.offset())
+	sqlQuery, args := b.build(sqlQuery)
+
+	slog.Debug("Executing query", "query", sqlQuery, "args", args)
+
+	var taskEntities []TaskEntity
+	if err := s.db.SelectContext(ctx, &taskEntities, sqlQuery, args...); err != nil {
+		return TaskPage{}, classify(ctx, err)
+	}
+	if len(taskEntities) > limit {
+		taskEntities = taskEntities[:limit]
+		page.NextCursor = encodeCursor(order, taskEntities[limit-1])
+	}
+
+	if page.Tasks, err = loadTasks(ctx, s.db, taskEntities); err != nil {
+		return TaskPage{}, err
+	}
+	if query.Filter.Query != "" {
+		for i, entity := range taskEntities {
+			page.Tasks[i].Search = searchMatch(query.Filter.Query, entity)
+		}
+	}
+	return page, nil
+}
+
+// タスクのラベル、担当者、サブタスクの集計、依存関係、コメント数を、それぞれ1回のクエリでまとめて取得して付加する
+func loadTasks(ctx context.Context, q sqlx.QueryerContext, entities []TaskEntity) ([]api.Task, error) {
+	if len(entities) == 0 {
+		return nil, nil
+	}
+	ids := make([]int, len(entities))
+	for i, entity := range entities {
+		ids[i] = entity.ID
+	}
+	labels, err := labelsByTask(ctx, q, ids)
+	if err != nil {
+		return nil, classify(ctx, err)
+	}
+	assignees, err := assigneesByTask(ctx, q, ids)
+	if err != nil {
+		return nil, classify(ctx, err)
+	}
+	rollups, err := rollupsByTask(ctx, q, ids)
+	if err != nil {
+		return nil, classify(ctx, err)
+	}
+
+	dependencies, err := dependenciesByTask(ctx, q, ids)
+	if err != nil {
+		return nil, classify(ctx, err)
+	}
+	commentCounts, err := commentCountsByTask(ctx, q, ids)
+	if err != nil {
+		return nil, classify(ctx, err)
+	}
+
+	tasks := make([]api.Task, len(entities))
+	for i, entity := range entities {
+		tasks[i] = entity.ToAPITask()
+		tasks[i].Labels = labels[entity.ID]
+		tasks[i].Assignees = assignees[entity.ID]
+		tasks[i].Subtasks = rollups[entity.ID]
+		setDependencies(&tasks[i], dependencies[entity.ID])
+		commentCount := commentCounts[entity.ID]
+		tasks[i].CommentCount = &commentCount
+	}
+	return tasks, nil
+}
+
+// searchDocument は全文検索の対象となる式です（トライグラム索引の式と一致させること）
+const searchDocument = "(name || ' ' || COALESCE(description, ''))"
+
+// 全文検索の関連度の式（search.Rank と同じ計算）
+func searchRankExpression(b *queryBuilder, q string) string {
+	return fmt.Sprintf("(word_similarity(%s, %s) + CASE WHEN name ILIKE %s THEN %d ELSE 0 END + CASE WHEN description ILIKE %s THEN %d ELSE 0 END)::float8",
+		b.arg(q), searchDocument, b.arg(likePattern(q)), search.NameWeight, b.arg(likePattern(q)), search.DescriptionWeight)
+}
+
+// 絞り込み条件をクエリの条件として追加
+func taskConditions(b *queryBuilder, filter TaskFilter) {
+	if filter.Trashed {
+		b.where("deleted_at IS NOT NULL")
+	} else {
+		b.where("deleted_at IS NULL")
+	}
+	if filter.ParentID != nil {
+		b.where("parent_id = ?", *filter.ParentID)
+	}
+	if filter.ProjectID != nil {
+		b.where("project_id = ?", *filter.ProjectID)
+	} else if !filter.IncludeArchived {
+		b.where("project_id IN (SELECT id FROM projects WHERE archived_at IS NULL)")
+	}
+	if filter.Member != nil {
+		b.where("project_id IN (" + memberProjects(b, *filter.Member) + ")")
+	}
+	if len(filter.Statuses) > 0 {
+		b.where("status = ANY(?)", pq.Array(filter.Statuses))
+	}
+	if len(filter.Priorities) > 0 {
+		b.where("priority = ANY(?)", pq.Array(filter.Priorities))
+	}
+	if filter.Query != "" {
+		// 部分一致はトライグラム索引で、表記ゆれは word_similarity のしきい値（<%）で判定する
+		b.where("(name ILIKE ? OR description ILIKE ? OR ? <% "+searchDocument+")",
+			likePattern(filter.Query), likePattern(filter.Query), filter.Query)
+	}
+	if filter.Name != "" {
+		b.where("name ILIKE ?", likePattern(filter.Name))
+	}
+	if filter.Description != "" {
+		b.where("description ILIKE ?", likePattern(filter.Description))
+	}
+	if ids := uniqueInts(filter.LabelIDs); len(ids) > 0 {
+		if filter.LabelMatch == LabelMatchAll {
+			b.where("(SELECT COUNT(DISTINCT tl.label_id) FROM task_labels tl WHERE tl.task_id = tasks.id AND tl.label_id = ANY(?)) = ?",
+				pq.Array(ids), len(ids))
+		} else {
+			b.where("EXISTS (SELECT 1 FROM task_labels tl WHERE tl.task_id = tasks.id AND tl.label_id = ANY(?))", pq.Array(ids))
+		}
+	}
+	if filter.HasLabels != nil {
+		cond := "EXISTS (SELECT 1 FROM task_labels tl WHERE tl.task_id = tasks.id)"
+		if !*filter.HasLabels {
+			cond = "NOT " + cond
+		}
+		b.where(cond)
+	}
+	if len(filter.Assignees) > 0 {
+		b.where("EXISTS (SELECT 1 FROM task_assignees ta JOIN users u ON u.id = ta.user_id WHERE ta.task_id = tasks.id AND u.username = ANY(?))",
+			pq.Array(filter.Assignees))
+	}
+	if filter.Unassigned != nil {
+		cond := "NOT EXISTS (SELECT 1 FROM task_assignees ta WHERE ta.task_id = tasks.id)"
+		if !*filter.Unassigned {
+			cond = "EXISTS (SELECT 1 FROM task_assignees ta WHERE ta.task_id = tasks.id)"
+		}
+		b.where(cond)
+	}
+	rangeConditions(b, "start_date", filter.StartDate)
+	rangeConditions(b, "end_date", filter.EndDate)
+	rangeConditions(b, "created_at", filter.CreatedAt)
+	rangeConditions(b, "updated_at", filter.UpdatedAt)
+	if filter.Overdue != nil {
+		if *filter.Overdue {
+			b.where("(end_date < ? AND status <> 'Completed')", filter.now().UTC())
+		} else {
+			b.where("(end_date IS NULL OR end_date >= ? OR status = 'Completed')", filter.now().UTC())
+		}
+	}
+}
+
+// 日時の範囲を条件に追加
+// 列はタイムゾーンのないTIMESTAMPでUTCの日時を保存しているため、境界もUTCに変換して渡す
+func rangeConditions(b *queryBuilder, column string, r TimeRange) {
+	if r.From != nil {
+		b.where(column+" >= ?", r.From.UTC())
+	}
+	if r.To != nil {
+		b.where(column+" <= ?", r.To.UTC())
+	}
+}
+
+// IDのタスクをラベル付きで取得
+func (s *PostgresStore) GetTask(ctx context.Context, id int) (api.Task, error) {
+	return getTask(ctx, s.db, id)
+}
+
+// トランザクションの中からも読めるよう、クエリの実行先を受け取る
+func getTask(ctx context.Context, q sqlx.QueryerContext, id int) (api.Task, error) {
+	var taskEntity TaskEntity
+	err := sqlx.GetContext(ctx, q, &taskEntity, "SELECT * FROM tasks WHERE id = $1 AND deleted_at IS NULL", id)
+	if err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+
+	tasks, err := loadTasks(ctx, q, []TaskEntity{taskEntity})
+	if err != nil {
+		return api.Task{}, err
+	}
+	return tasks[0], nil
+}
+
+// タスクをサブタスクのツリー付きで取得
+func (s *PostgresStore) TaskTree(ctx context.Context, id int) (api.Task, error) {
+	root, err := getTask(ctx, s.db, id)
+	if err != nil {
+		return api.Task{}, err
+	}
+
+	// 兄弟は一覧の既定の並び順にする
+	order, err := parseSort("", TaskFilter{})
+	if err != nil {
+		return api.Task{}, err
+	}
+	var entities []TaskEntity
+	query := descendantsCTE("deleted_at IS NULL") + " SELECT * FROM tasks WHERE id IN (SELECT id FROM descendants)" + orderByClause(order)
+	if err := s.db.SelectContext(ctx, &entities, query, id); err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	descendants, err := loadTasks(ctx, s.db, entities)
+	if err != nil {
+		return api.Task{}, err
+	}
+	return buildTree(root, descendants), nil
+}
+
+// タスクを作成
+func (s *PostgresStore) CreateTask(ctx context.Context, projectID int, input api.TaskInput) (int, error) {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	e := taskEntityFromInput(input)
+	if e.ProjectID, err = newTaskProject(ctx, tx, projectID, e.ParentID); err != nil {
+		return 0, err
+	}
+	if err := shareProject(ctx, tx, e.ProjectID); err != nil {
+		return 0, err
+	}
+	if err := normalizeRecurrence(nil, &e); err != nil {
+		return 0, err
+	}
+	if e, err = insertTask(ctx, tx, e); err != nil {
+		return 0, err
+	}
+	if err := checkParent(ctx, tx, e.ID, e.ProjectID, e.ParentID); err != nil {
+		return 0, err
+	}
+
+	// トランザクション確定
+	return e.ID, classify(ctx, tx.Commit())
+}
+
+// 指定したIDのタスクを更新
+func (s *PostgresStore) UpdateTask(ctx context.Context, id int, input api.TaskInput, version int) error {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	before, err := lockTask(ctx, tx, id, version, false)
+	if err != nil {
+		return err
+	}
+	if err := writeTask(ctx, tx, s.opts, before, before.withInput(input)); err != nil {
+		return err
+	}
+
+	// トランザクション確定
+	return classify(ctx, tx.Commit())
+}
+
+// 指定したIDのタスクを部分更新
+func (s *PostgresStore) PatchTask(ctx context.Context, id int, patch TaskPatch, version int) (api.Task, error) {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	before, err := lockTask(ctx, tx, id, version, false)
+	if err != nil {
+		return api.Task{}, err
+	}
+	if err := patchTask(ctx, tx, s.opts, before, patch); err != nil {
+		return api.Task{}, err
+	}
+
+	// トランザクション確定
+	if err := tx.Commit(); err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	return s.GetTask(ctx, id)
+}
+
+// タスクをゴミ箱に移動（ラベルの関連付けは復元できるよう残す）
+func (s *PostgresStore) DeleteTask(ctx context.Context, id int, version int) error {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	before, err := lockTask(ctx, tx, id, version, false)
+	if err != nil {
+		return err
+	}
+	if err := trashTask(ctx, tx, before, s.opts.deletePolicy()); err != nil {
+		return err
+	}
+
+	// トランザクション確定
+	return classify(ctx, tx.Commit())
+}
+
+// ゴミ箱のタスクを元に戻す
+// 一緒にゴミ箱に移動したサブタスクも元に戻し、親がゴミ箱にある場合は最上位のタスクとして戻します
+func (s *PostgresStore) RestoreTask(ctx context.Context, id int, version int) (api.Task, error) {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	before, err := lockTask(ctx, tx, id, version, true)
+	if err != nil {
+		return api.Task{}, err
+	}
+	parentID := before.ParentID
+	if parentID != nil {
+		var active bool
+		if err := tx.GetContext(ctx, &active, "SELECT EXISTS (SELECT 1 FROM tasks WHERE id = $1 AND deleted_at IS NULL)", *parentID); err != nil {
+			return api.Task{}, classify(ctx, err)
+		}
+		if !active {
+			parentID = nil
+		}
+	}
+	var after TaskEntity
+	if err := tx.GetContext(ctx, &after,
+		"UPDATE tasks SET deleted_at = NULL, parent_id = $2, version = version + 1 WHERE id = $1 RETURNING *", id, parentID,
+	); err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	if err := insertAudit(ctx, tx, taskChange(api.Restore, &before, &after)); err != nil {
+		return api.Task{}, err
+	}
+	if err := touchRelated(ctx, tx, &before, &after); err != nil {
+		return api.Task{}, err
+	}
+
+	// 同じ時刻にゴミ箱に移動したサブタスクは、親と一緒に移動したものとして元に戻す
+	var subtasks []TaskEntity
+	query := descendantsCTE("deleted_at = $2") + " SELECT * FROM tasks WHERE id IN (SELECT id FROM descendants) ORDER BY id FOR UPDATE"
+	if err := tx.SelectContext(ctx, &subtasks, query, id, before.DeletedAt); err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	if err := updateEach(ctx, tx, subtasks, api.Restore, "UPDATE tasks SET deleted_at = NULL, version = version + 1 WHERE id = $1 RETURNING *"); err != nil {
+		return api.Task{}, err
+	}
+
+	// トランザクション確定
+	if err := tx.Commit(); err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	return s.GetTask(ctx, id)
+}
+
+// ゴミ箱に移動してから一定期間が過ぎたタスクを完全に削除
+func (s *PostgresStore) PurgeTasks(ctx context.Context, deletedBefore time.Time) (int, error) {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	var purged []TaskEntity
+	if err := tx.SelectContext(ctx, &purged, "DELETE FROM tasks WHERE deleted_at < $1 RETURNING *", deletedBefore); err != nil {
+		return 0, classify(ctx, err)
+	}
+	for i := range purged {
+		if err := insertAudit(ctx, tx, taskChange(api.Purge, &purged[i], nil)); err != nil {
+			return 0, err
+		}
+	}
+
+	// トランザクション確定
+	return len(purged), classify(ctx, tx.Commit())
+}
+
+// 更新の対象となるタスクを行ロック付きで取得し、版とプロジェクトがアーカイブされていないことを確認する
+// trashed が true の場合はゴミ箱のタスク、false の場合はゴミ箱にないタスクだけを対象にします
+func lockTask(ctx context.Context, tx *sqlx.Tx, id int, version int, trashed bool) (TaskEntity, error) {
+	query := "SELECT * FROM tasks WHERE id = $1 AND deleted_at IS NULL FOR UPDATE"
+	if trashed {
+		query = "SELECT * FROM tasks WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE"
+	}
+	var e TaskEntity
+	if err := tx.GetContext(ctx, &e, query, id); err != nil {
+		return TaskEntity{}, classify(ctx, err)
+	}
+	if err := shareProject(ctx, tx, e.ProjectID); err != nil {
+		return TaskEntity{}, err
+	}
+	return e, checkVersion(e.Version, version)
+}
+
+// 作成するタスクのプロジェクトを決める（projectID が0の場合は親タスクのプロジェクトか既定のプロジェクト）
+func newTaskProject(ctx context.Context, tx *sqlx.Tx, projectID int, parentID *int) (int, error) {
+	if projectID != 0 {
+		return projectID, nil
+	}
+	if parentID == nil {
+		return DefaultProjectID, nil
+	}
+	if err := tx.GetContext(ctx, &projectID, "SELECT project_id FROM tasks WHERE id = $1 AND deleted_at IS NULL", *parentID); err != nil {
+		if err = classify(ctx, err); errors.Is(err, ErrNotFound) {
+			return 0, invalidParent(*parentID)
+		}
+		return 0, err
+	}
+	return projectID, nil
+}
+
+// 行ロックを取得したタスクを部分更新し、変更履歴を記録する
+func patchTask(ctx context.Context, tx *sqlx.Tx, opts Options, before TaskEntity, patch TaskPatch) error {
+	e := before
+	patch.apply(&e)
+	if err := checkDateOrder(e); err != nil {
+		return err
+	}
+	return writeTask(ctx, tx, opts, before, e)
+}
+
+// 行ロックを取得したタスクを e の内容で更新し、変更履歴を記録する
+func writeTask(ctx context.Context, tx *sqlx.Tx, opts Options, before, e TaskEntity) error {
+	if parentChanged(before, e) {
+		if err := checkParent(ctx, tx, e.ID, e.ProjectID, e.ParentID); err != nil {
+			return err
+		}
+	}
+	if opts.EnforceDependencies && startsWork(before, e) {
+		if err := checkUnblocked(ctx, tx, e.ID); err != nil {
+			return err
+		}
+	}
+	if err := normalizeRecurrence(&before, &e); err != nil {
+		return err
+	}
+	// 繰り返しのタスクを完了した場合は、規則を次のタスクに引き継ぐ
+	var next *TaskEntity
+	if completesOccurrence(before, e) {
+		var err error
+		if next, err = handOver(&e); err != nil {
+			return err
+		}
+	}
+
+	var after TaskEntity
+	if err := tx.GetContext(ctx, &after, `
+		UPDATE tasks SET name = $1, description = $2, start_date = $3, end_date = $4, priority = $5, status = $6, parent_id = $7,
+			recurrence_rule = $8, recurrence_timezone = $9, recurrence_start = $10, series_id = $11,
+			updated_at = CURRENT_TIMESTAMP, updated_by = $12, version = version + 1
+		WHERE id = $13 RETURNING *`,
+		e.Name, e.Description, e.StartDate, e.EndDate, e.Priority, e.Status, e.ParentID,
+		e.RecurrenceRule, e.RecurrenceTimezone, e.RecurrenceStart, e.SeriesID, actorFromContext(ctx), e.ID,
+	); err != nil {
+		return classify(ctx, err)
+	}
+	if err := insertAudit(ctx, tx, taskChange(api.Update, &before, &after)); err != nil {
+		return err
+	}
+	if err := touchRelated(ctx, tx, &before, &after); err != nil {
+		return err
+	}
+	if next != nil {
+		return createOccurrence(ctx, tx, e.ID, *next)
+	}
+	return nil
+}
+
+// タスクを追加し、変更履歴を記録する
+func insertTask(ctx context.Context, tx *sqlx.Tx, e TaskEntity) (TaskEntity, error) {
+	var created TaskEntity
+	if err := tx.GetContext(ctx, &created, `
+		INSERT INTO tasks (name, description, start_date, end_date, priority, status, parent_id, project_id,
+			recurrence_rule, recurrence_timezone, recurrence_start, series_id, created_by, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $13) RETURNING *`,
+		e.Name, e.Description, e.StartDate, e.EndDate, e.Priority, e.Status, e.ParentID, e.ProjectID,
+		e.RecurrenceRule, e.RecurrenceTimezone, e.RecurrenceStart, e.SeriesID, actorFromContext(ctx),
+	); err != nil {
+		return TaskEntity{}, classify(ctx, err)
+	}
+	if err := insertAudit(ctx, tx, taskChange(api.Create, nil, &created)); err != nil {
+		return TaskEntity{}, err
+	}
+	return created, touchRelated(ctx, tx, nil, &created)
+}
+
+// 繰り返しの次のタスクを作成し、完了したタスク（previousID）の担当者とラベルを引き継ぐ
+func createOccurrence(ctx context.Context, tx *sqlx.Tx, previousID int, next TaskEntity) error {
+	created, err := insertTask(ctx, tx, next)
+	if err != nil {
+		return err
+	}
+	userIDs, err := taskAssigneeIDs(ctx, tx, previousID)
+	if err != nil {
+		return err
+	}
+	if len(userIDs) > 0 {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO task_assignees (task_id, user_id) SELECT $1, user_id FROM task_assignees WHERE task_id = $2", created.ID, previousID); err != nil {
+			return classify(ctx, err)
+		}
+		if err := insertAudit(ctx, tx, taskAssigneesChange(created.ID, []int{}, userIDs)); err != nil {
+			return err
+		}
+	}
+	labelIDs, err := taskLabelIDs(ctx, tx, previousID)
+	if err != nil || len(labelIDs) == 0 {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO task_labels (task_id, label_id) SELECT $1, label_id FROM task_labels WHERE task_id = $2", created.ID, previousID); err != nil {
+		return classify(ctx, err)
+	}
+	return insertAudit(ctx, tx, taskLabelsChange(created.ID, []int{}, labelIDs))
+}
+
+// 行ロックを取得したタスクをゴミ箱に移動し、変更履歴を記録する
+// サブタスクは policy に従って扱います
+func trashTask(ctx context.Context, tx *sqlx.Tx, before TaskEntity, policy DeletePolicy) error {
+	switch policy {
+	case DeleteRestrict:
+		var hasSubtasks bool
+		if err := tx.GetContext(ctx, &hasSubtasks, "SELECT EXISTS (SELECT 1 FROM tasks WHERE parent_id = $1 AND deleted_at IS NULL)", before.ID); err != nil {
+			return classify(ctx, err)
+		}
+		if hasSubtasks {
+			return ErrHasSubtasks
+		}
+	case DeleteCascade:
+		// 孫以下も含めて同じ時刻でゴミ箱に移動し、親を元に戻すときに一緒に戻せるようにする
+		var subtasks []TaskEntity
+		query := descendantsCTE("deleted_at IS NULL") + " SELECT * FROM tasks WHERE id IN (SELECT id FROM descendants) ORDER BY id FOR UPDATE"
+		if err := tx.SelectContext(ctx, &subtasks, query, before.ID); err != nil {
+			return classify(ctx, err)
+		}
+		if err := updateEach(ctx, tx, subtasks, api.Delete, "UPDATE tasks SET deleted_at = CURRENT_TIMESTAMP, version = version + 1 WHERE id = $1 RETURNING *"); err != nil {
+			return err
+		}
+	default:
+		var subtasks []TaskEntity
+		if err := tx.SelectContext(ctx, &subtasks, "SELECT * FROM tasks WHERE parent_id = $1 AND deleted_at IS NULL ORDER BY id FOR UPDATE", before.ID); err != nil {
+			return classify(ctx, err)
+		}
+		if err := updateEach(ctx, tx, subtasks, api.Update,
+			"UPDATE tasks SET parent_id = NULL, updated_at = CURRENT_TIMESTAMP, updated_by = $2, version = version + 1 WHERE id = $1 RETURNING *",
+			actorFromContext(ctx)); err != nil {
+			return err
+		}
+	}
+
+	var after TaskEntity
+	if err := tx.GetContext(ctx, &after,
+		"UPDATE tasks SET deleted_at = CURRENT_TIMESTAMP, version = version + 1 WHERE id = $1 RETURNING *", before.ID,
+	); err != nil {
+		return classify(ctx, err)
+	}
+	if err := insertAudit(ctx, tx, taskChange(api.Delete, &before, &after)); err != nil {
+		return err
+	}
+	return touchRelated(ctx, tx, &before, &after)
+}
+
+// サブタスクの集計は親タスクの、依存先の状態は依存元のタスクの表現の一部なので、
+// before から after への変更でそれらが変わるタスクの版を進める
+// それらのタスク自体を編集したわけではないため、updated_at と updated_by は変えない
+func touchRelated(ctx context.Context, tx *sqlx.Tx, before, after *TaskEntity) error {
+	ids := rollupParents(before, after)
+	if changesBlocking(before, after) {
+		var dependents []int
+		id := after
+		if id == nil {
+			id = before
+		}
+		if err := tx.SelectContext(ctx, &dependents, "SELECT task_id FROM task_dependencies WHERE depends_on_id = $1", id.ID); err != nil {
+			return classify(ctx, err)
+		}
+		ids = append(ids, dependents...)
+	}
+	return bumpVersions(ctx, tx, ids)
+}
+
+// ゴミ箱にないタスクの版だけを進める（ETagを変えて、キャッシュした表現を無効にする）
+func bumpVersions(ctx context.Context, tx *sqlx.Tx, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, "UPDATE tasks SET version = version + 1 WHERE id = ANY($1) AND deleted_at IS NULL", pq.Array(uniqueInts(ids)))
+	return classify(ctx, err)
+}
+
+// taskLabelRow はタスクIDとラベルを1行で受け取るための構造体です
+type taskLabelRow struct {
+	TaskID int `db:"task_id"`
+	api.Label
+}
+
+// 複数のタスクに関連付けられたラベルをまとめて取得し、タスクIDごとに名前順で返す
+func labelsByTask(ctx context.Context, q sqlx.QueryerContext, taskIDs []int) (map[int][]api.Label, error) {
+	labels := make(map[int][]api.Label, len(taskIDs))
+	if len(taskIDs) == 0 {
+		return labels, nil
+	}
+
+	var rows []taskLabelRow
+	query := `
+		SELECT tl.task_id, l.* FROM labels l
+		JOIN task_labels tl ON l.id = tl.label_id
+		WHERE tl.task_id = ANY($1)
+		ORDER BY l.name, l.id
+	`
+	if err := sqlx.SelectContext(ctx, q, &rows, query, pq.Array(taskIDs)); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		labels[row.TaskID] = append(labels[row.TaskID], row.Label)
+	}
+	return labels, nil
+}
+
+// ラベル一覧を取得
+func (s *PostgresStore) ListLabels(ctx context.Context, projectID int) ([]api.Label, error) {
+	var labels []api.Label
+	var err error
+	if projectID == 0 {
+		err = s.db.SelectContext(ctx, &labels, "SELECT * FROM labels ORDER BY name, id")
+	} else {
+		err = s.db.SelectContext(ctx, &labels, "SELECT * FROM labels WHERE project_id = $1 ORDER BY name, id", projectID)
+	}
+	return labels, classify(ctx, err)
+}
+
+// IDのラベルを取得
+func (s *PostgresStore) GetLabel(ctx context.Context, id int) (api.Label, error) {
+	var label api.Label
+	err := s.db.GetContext(ctx, &label, "SELECT * FROM labels WHERE id = $1", id)
+	return label, classify(ctx, err)
+}
+
+// 新しいラベルを作成
+func (s *PostgresStore) CreateLabel(ctx context.Context, projectID int, input api.LabelInput) (int, error) {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	if err := shareProject(ctx, tx, projectID); err != nil {
+		return 0, err
+	}
+	l, err := insertLabel(ctx, tx, projectID, input)
+	if err != nil {
+		return 0, err
+	}
+
+	// トランザクション確定
+	return l.ID, classify(ctx, tx.Commit())
+}
+
+// ラベルを追加し、変更履歴を記録する
+func insertLabel(ctx context.Context, tx *sqlx.Tx, projectID int, input api.LabelInput) (api.Label, error) {
+	var l api.Label
+	if err := tx.GetContext(ctx, &l, "INSERT INTO labels (name, color, project_id, created_by, updated_by) VALUES ($1, $2, $3, $4, $4) RETURNING *",
+		input.Name, input.Color, projectID, actorFromContext(ctx)); err != nil {
+		return api.Label{}, classify(ctx, err)
+	}
+	return l, insertAudit(ctx, tx, labelChange(api.Create, nil, &l))
+}
+
+// 指定したIDのラベルを更新
+func (s *PostgresStore) UpdateLabel(ctx context.Context, id int, input api.LabelInput, version int) error {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	before, err := lockLabel(ctx, tx, id, version)
+	if err != nil {
+		return err
+	}
+	var after api.Label
+	if err := tx.GetContext(ctx, &after,
+		"UPDATE labels SET name = $1, color = $2, updated_at = CURRENT_TIMESTAMP, updated_by = $3, version = version + 1 WHERE id = $4 RETURNING *",
+		input.Name, input.Color, actorFromContext(ctx), id,
+	); err != nil {
+		return classify(ctx, err)
+	}
+	if err := touchLabeledTasks(ctx, tx, id); err != nil {
+		return err
+	}
+	if err := insertAudit(ctx, tx, labelChange(api.Update, &before, &after)); err != nil {
+		return err
+	}
+
+	// トランザクション確定
+	return classify(ctx, tx.Commit())
+}
+
+// taskLabelIDsRow はタスクIDと関連付けられたラベルIDの配列を1行で受け取るための構造体です
+type taskLabelIDsRow struct {
+	TaskID   int           `db:"task_id"`
+	LabelIDs pq.Int64Array `db:"label_ids"`
+}
+
+// 指定したIDのラベルを削除
+// ラベルを外されたタスクにも、ラベルの付け替えとして履歴を残します
+func (s *PostgresStore) DeleteLabel(ctx context.Context, id int, version int) error {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	before, err := lockLabel(ctx, tx, id, version)
+	if err != nil {
+		return err
+	}
+
+	// ラベルが付いているタスクの、削除前のラベルID
+	var rows []taskLabelIDsRow
+	query := `
+		SELECT task_id, array_agg(label_id ORDER BY label_id) AS label_ids FROM task_labels
+		WHERE task_id IN (SELECT task_id FROM task_labels WHERE label_id = $1)
+		GROUP BY task_id ORDER BY task_id
+	`
+	if err := tx.SelectContext(ctx, &rows, query, id); err != nil {
+		return classify(ctx, err)
+	}
+
+	// 関連付けは外部キー制約の ON DELETE CASCADE で削除されるため、その前にタスクの版を進める
+	if err := touchLabeledTasks(ctx, tx, id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM labels WHERE id = $1", id); err != nil {
+		return classify(ctx, err)
+	}
+	if err := insertAudit(ctx, tx, labelChange(api.Delete, &before, nil)); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		var labelsBefore, labelsAfter []int
+		for _, labelID := range row.LabelIDs {
+			labelsBefore = append(labelsBefore, int(labelID))
+			if int(labelID) != id {
+				labelsAfter = append(labelsAfter, int(labelID))
+			}
+		}
+		if err := insertAudit(ctx, tx, taskLabelsChange(row.TaskID, sortedIDs(labelsBefore), sortedIDs(labelsAfter))); err != nil {
+			return err
+		}
+	}
+
+	// トランザクション確定
+	return classify(ctx, tx.Commit())
+}
+
+// ラベルはタスクの表現の一部なので、ラベルが付いているタスクの版を進める
+func touchLabeledTasks(ctx context.Context, tx *sqlx.Tx, labelID int) error {
+	_, err := tx.ExecContext(ctx, "UPDATE tasks SET version = version + 1 WHERE id IN (SELECT task_id FROM task_labels WHERE label_id = $1)", labelID)
+	return classify(ctx, err)
+}
+
+// 更新の対象となるラベルを行ロック付きで取得し、版とプロジェクトがアーカイブされていないことを確認する
+func lockLabel(ctx context.Context, tx *sqlx.Tx, id int, version int) (api.Label, error) {
+	var l api.Label
+	if err := tx.GetContext(ctx, &l, "SELECT * FROM labels WHERE id = $1 FOR UPDATE", id); err != nil {
+		return api.Label{}, classify(ctx, err)
+	}
+	if err := shareProject(ctx, tx, l.ProjectId); err != nil {
+		return api.Label{}, err
+	}
+	return l, checkVersion(l.Version, version)
+}
+
+// タスクに関連付けられたラベルを置き換える
+func (s *PostgresStore) SetTaskLabels(ctx context.Context, taskID int, labelIDs []int, version int) error {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	// タスクの存在と版を確認（同時に削除されないよう行ロックを取得）
+	if _, err := lockTask(ctx, tx, taskID, version, false); err != nil {
+		return err
+	}
+	before, err := taskLabelIDs(ctx, tx, taskID)
+	if err != nil {
+		return err
+	}
+	if err := replaceTaskLabels(ctx, tx, taskID, before, labelIDs); err != nil {
+		return err
+	}
+
+	// トランザクション確定
+	return classify(ctx, tx.Commit())
+}
+
+// タスクに関連付けられたラベルIDを昇順で取得
+func taskLabelIDs(ctx context.Context, tx *sqlx.Tx, taskID int) ([]int, error) {
+	var ids []int
+	if err := tx.SelectContext(ctx, &ids, "SELECT label_id FROM task_labels WHERE task_id = $1 ORDER BY label_id", taskID); err != nil {
+		return nil, classify(ctx, err)
+	}
+	return sortedIDs(ids), nil
+}
+
+// 行ロックを取得したタスクのラベルを before から labelIDs に置き換え、変更履歴を記録する
+func replaceTaskLabels(ctx context.Context, tx *sqlx.Tx, taskID int, before, labelIDs []int) error {
+	// 重複したIDは主キー制約に違反するため、MemoryStore と同じく1つにまとめる
+	labelIDs = uniqueInts(labelIDs)
+
+	// タスクと異なるプロジェクトのラベルは付けられない（存在しないラベルは外部キー制約で拒否する）
+	var foreign []int
+	query := "SELECT id FROM labels WHERE id = ANY($1) AND project_id <> (SELECT project_id FROM tasks WHERE id = $2) ORDER BY id"
+	if err := tx.SelectContext(ctx, &foreign, query, pq.Array(labelIDs), taskID); err != nil {
+		return classify(ctx, err)
+	}
+	if len(foreign) > 0 {
+		return foreignLabel(foreign[0])
+	}
+
+	// 既存のラベル関連を削除
+	if _, err := tx.ExecContext(ctx, "DELETE FROM task_labels WHERE task_id = $1", taskID); err != nil {
+		return fmt.Errorf("delete task labels: %w", classify(ctx, err))
+	}
+
+	// 新しいラベル関連を追加
+	for _, labelID := range labelIDs {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO task_labels (task_id, label_id) VALUES ($1, $2)", taskID, labelID); err != nil {
+			return fmt.Errorf("insert task label %d: %w", labelID, classify(ctx, err))
+		}
+	}
+
+	// ラベルはタスクの表現の一部なので、タスクの版を進める
+	if _, err := tx.ExecContext(ctx, "UPDATE tasks SET updated_at = CURRENT_TIMESTAMP, updated_by = $2, version = version + 1 WHERE id = $1",
+		taskID, actorFromContext(ctx)); err != nil {
+		return classify(ctx, err)
+	}
+	return insertAudit(ctx, tx, taskLabelsChange(taskID, before, sortedIDs(labelIDs)))
+}
@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// タスクの担当者を置き換える
+func (s *PostgresStore) SetTaskAssignees(ctx context.Context, taskID int, userIDs []int, version int) (api.Task, error) {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	// タスクの存在と版を確認（同時に削除されないよう行ロックを取得）
+	if _, err := lockTask(ctx, tx, taskID, version, false); err != nil {
+		return api.Task{}, err
+	}
+	before, err := taskAssigneeIDs(ctx, tx, taskID)
+	if err != nil {
+		return api.Task{}, err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM task_assignees WHERE task_id = $1", taskID); err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	userIDs = uniqueInts(userIDs)
+	if len(userIDs) > 0 {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO task_assignees (task_id, user_id) SELECT $1, unnest($2::int[])",
+			taskID, pq.Array(userIDs)); err != nil {
+			return api.Task{}, classify(ctx, err)
+		}
+	}
+
+	// 担当者はタスクの表現の一部なので、タスクの版を進める
+	if _, err := tx.ExecContext(ctx, "UPDATE tasks SET updated_at = CURRENT_TIMESTAMP, updated_by = $2, version = version + 1 WHERE id = $1",
+		taskID, actorFromContext(ctx)); err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	if err := insertAudit(ctx, tx, taskAssigneesChange(taskID, before, sortedIDs(userIDs))); err != nil {
+		return api.Task{}, err
+	}
+	task, err := getTask(ctx, tx, taskID)
+	if err != nil {
+		return api.Task{}, err
+	}
+
+	// トランザクションのコミット
+	if err := tx.Commit(); err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	return task, nil
+}
+
+// タスクの担当者のユーザーIDを昇順で取得
+func taskAssigneeIDs(ctx context.Context, tx *sqlx.Tx, taskID int) ([]int, error) {
+	var ids []int
+	if err := tx.SelectContext(ctx, &ids, "SELECT user_id FROM task_assignees WHERE task_id = $1 ORDER BY user_id", taskID); err != nil {
+		return nil, classify(ctx, err)
+	}
+	return sortedIDs(ids), nil
+}
+
+// 複数のタスクの担当者をまとめて取得し、タスクIDごとにユーザー名の順で返す
+func assigneesByTask(ctx context.Context, q sqlx.QueryerContext, taskIDs []int) (map[int][]api.Assignee, error) {
+	assignees := make(map[int][]api.Assignee, len(taskIDs))
+	if len(taskIDs) == 0 {
+		return assignees, nil
+	}
+
+	var rows []taskAssigneeRow
+	query := `
+		SELECT ta.task_id, u.id, u.username FROM users u
+		JOIN task_assignees ta ON u.id = ta.user_id
+		WHERE ta.task_id = ANY($1)
+		ORDER BY u.username, u.id
+	`
+	if err := sqlx.SelectContext(ctx, q, &rows, query, pq.Array(taskIDs)); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		assignees[row.TaskID] = append(assignees[row.TaskID], row.Assignee)
+	}
+	return assignees, nil
+}
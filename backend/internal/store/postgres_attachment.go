@@ -0,0 +1,99 @@
+package store
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// タスクの添付ファイルを古い順に取得
+func (s *PostgresStore) ListAttachments(ctx context.Context, taskID int) ([]api.Attachment, error) {
+	var exists bool
+	if err := s.db.GetContext(ctx, &exists, "SELECT EXISTS (SELECT 1 FROM tasks WHERE id = $1 AND deleted_at IS NULL)", taskID); err != nil {
+		return nil, classify(ctx, err)
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	var entities []AttachmentEntity
+	if err := s.db.SelectContext(ctx, &entities, "SELECT * FROM task_attachments WHERE task_id = $1 ORDER BY id", taskID); err != nil {
+		return nil, classify(ctx, err)
+	}
+	result := make([]api.Attachment, len(entities))
+	for i, e := range entities {
+		result[i] = e.ToAPIAttachment()
+	}
+	return result, nil
+}
+
+// 添付ファイルのメタデータを取得
+func (s *PostgresStore) GetAttachment(ctx context.Context, taskID, id int) (AttachmentEntity, error) {
+	var a AttachmentEntity
+	query := `
+		SELECT a.* FROM task_attachments a JOIN tasks t ON t.id = a.task_id
+		WHERE a.id = $1 AND a.task_id = $2 AND t.deleted_at IS NULL
+	`
+	if err := s.db.GetContext(ctx, &a, query, id, taskID); err != nil {
+		return AttachmentEntity{}, classify(ctx, err)
+	}
+	return a, nil
+}
+
+// 添付ファイルのメタデータを追加
+func (s *PostgresStore) CreateAttachment(ctx context.Context, e AttachmentEntity) (api.Attachment, error) {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return api.Attachment{}, classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	// 追加する間にタスクがゴミ箱に移動されないよう共有ロックを取得する
+	if err := shareTask(ctx, tx, e.TaskID); err != nil {
+		return api.Attachment{}, err
+	}
+	var a AttachmentEntity
+	query := `
+		INSERT INTO task_attachments (task_id, filename, content_type, size, checksum, blob_key, uploaded_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *
+	`
+	if err := tx.GetContext(ctx, &a, query,
+		e.TaskID, e.Filename, e.ContentType, e.Size, e.Checksum, e.BlobKey, actorFromContext(ctx)); err != nil {
+		return api.Attachment{}, classify(ctx, err)
+	}
+
+	// トランザクションのコミット
+	if err := tx.Commit(); err != nil {
+		return api.Attachment{}, classify(ctx, err)
+	}
+	return a.ToAPIAttachment(), nil
+}
+
+// 添付ファイルのメタデータを削除
+func (s *PostgresStore) DeleteAttachment(ctx context.Context, taskID, id int) (AttachmentEntity, error) {
+	var a AttachmentEntity
+	query := `
+		DELETE FROM task_attachments a USING tasks t
+		WHERE a.id = $1 AND a.task_id = $2 AND t.id = a.task_id AND t.deleted_at IS NULL
+		RETURNING a.*
+	`
+	if err := s.db.GetContext(ctx, &a, query, id, taskID); err != nil {
+		return AttachmentEntity{}, classify(ctx, err)
+	}
+	return a, nil
+}
+
+// どの添付ファイルからも参照されていない本体のキーを返す
+func (s *PostgresStore) UnreferencedBlobs(ctx context.Context, keys []string) ([]string, error) {
+	var result []string
+	query := `
+		SELECT k.key FROM unnest($1::text[]) AS k(key)
+		WHERE NOT EXISTS (SELECT 1 FROM task_attachments a WHERE a.blob_key = k.key)
+	`
+	if err := s.db.SelectContext(ctx, &result, query, pq.Array(keys)); err != nil {
+		return nil, classify(ctx, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,131 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// auditRow は audit_log の1行です（changes はJSONのまま受け取る）
+type auditRow struct {
+	ID         int64     `db:"id"`
+	EntityType string    `db:"entity_type"`
+	EntityID   int       `db:"entity_id"`
+	Action     string    `db:"action"`
+	Actor      *string   `db:"actor"`
+	RequestID  *string   `db:"request_id"`
+	Changes    []byte    `db:"changes"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+func (r auditRow) toAPIAuditEntry() (api.AuditEntry, error) {
+	entry := api.AuditEntry{
+		Id:         r.ID,
+		EntityType: api.AuditEntryEntityType(r.EntityType),
+		EntityId:   r.EntityID,
+		Action:     api.AuditEntryAction(r.Action),
+		Actor:      r.Actor,
+		RequestId:  r.RequestID,
+		CreatedAt:  r.CreatedAt,
+	}
+	if err := json.Unmarshal(r.Changes, &entry.Changes); err != nil {
+		return api.AuditEntry{}, fmt.Errorf("decode audit changes %d: %w", r.ID, err)
+	}
+	return entry, nil
+}
+
+// 変更履歴を記録（書き込みと同じトランザクションで呼び出すこと）
+func insertAudit(ctx context.Context, tx sqlx.ExecerContext, r auditRecord) error {
+	if r.empty() {
+		return nil
+	}
+	changes, err := json.Marshal(r.changes)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO audit_log (entity_type, entity_id, action, actor, request_id, changes) VALUES ($1, $2, $3, $4, $5, $6)",
+		r.entityType, r.entityID, r.action, actorFromContext(ctx), requestIDFromContext(ctx), string(changes),
+	)
+	if err != nil {
+		return fmt.Errorf("insert audit log: %w", classify(ctx, err))
+	}
+	return nil
+}
+
+// 変更履歴を新しい順に取得
+func (s *PostgresStore) ListAudit(ctx context.Context, query AuditQuery) (AuditPage, error) {
+	var page AuditPage
+	count := &queryBuilder{}
+	auditConditions(count, query)
+	countQuery, countArgs := count.build("SELECT COUNT(*) FROM audit_log" + count.whereClause())
+	if err := s.db.GetContext(ctx, &page.Total, countQuery, countArgs...); err != nil {
+		return AuditPage{}, classify(ctx, err)
+	}
+
+	b := &queryBuilder{}
+	auditConditions(b, query)
+	sqlQuery, args := b.build("SELECT * FROM audit_log" + b.whereClause() +
+		" ORDER BY id DESC LIMIT " + b.arg(query.PageSize()) + " OFFSET " + b.arg(query.offset()))
+
+	var rows []auditRow
+	if err := s.db.SelectContext(ctx, &rows, sqlQuery, args...); err != nil {
+		return AuditPage{}, classify(ctx, err)
+	}
+	for _, row := range rows {
+		entry, err := row.toAPIAuditEntry()
+		if err != nil {
+			return AuditPage{}, err
+		}
+		page.Entries = append(page.Entries, entry)
+	}
+	return page, nil
+}
+
+// タスクの変更履歴を新しい順に取得
+func (s *PostgresStore) TaskHistory(ctx context.Context, taskID int, query AuditQuery) (AuditPage, error) {
+	query.EntityType = string(api.AuditEntryEntityTypeTask)
+	query.EntityID = taskID
+	page, err := s.ListAudit(ctx, query)
+	if err != nil || page.Total > 0 {
+		return page, err
+	}
+
+	// 履歴を記録する前から存在するタスクは、履歴が空でも見つかったものとして扱う
+	var exists bool
+	if err := s.db.GetContext(ctx, &exists, "SELECT EXISTS (SELECT 1 FROM tasks WHERE id = $1)", taskID); err != nil {
+		return AuditPage{}, classify(ctx, err)
+	}
+	if !exists {
+		return AuditPage{}, ErrNotFound
+	}
+	return page, nil
+}
+
+// 変更履歴の絞り込み条件をクエリの条件として追加
+func auditConditions(b *queryBuilder, query AuditQuery) {
+	if query.EntityType != "" {
+		b.where("entity_type = ?", query.EntityType)
+	}
+	if query.EntityID != 0 {
+		b.where("entity_id = ?", query.EntityID)
+	}
+	if len(query.Actions) > 0 {
+		b.where("action = ANY(?)", pq.Array(query.Actions))
+	}
+	if query.Actor != "" {
+		b.where("actor = ?", query.Actor)
+	}
+	rangeConditions(b, "created_at", query.CreatedAt)
+	if query.Member != nil {
+		// 完全に削除したタスクやラベルはプロジェクトが分からないため、閲覧できないものとして扱う
+		b.where("((entity_type = 'task' AND entity_id IN (SELECT id FROM tasks WHERE project_id IN (" + memberProjects(b, *query.Member) + ")))" +
+			" OR (entity_type = 'label' AND entity_id IN (SELECT id FROM labels WHERE project_id IN (" + memberProjects(b, *query.Member) + ")))" +
+			" OR (entity_type = 'project' AND entity_id IN (" + memberProjects(b, *query.Member) + ")))")
+	}
+}
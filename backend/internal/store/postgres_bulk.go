@@ -0,0 +1,95 @@
+package store
+
+import (
+	"context"
+	"slices"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// タスクを一括で操作
+// タスクごとにセーブポイントを作り、失敗したタスクの変更だけを取り消します
+func (s *PostgresStore) BulkTasks(ctx context.Context, op BulkOperation) ([]BulkItemResult, error) {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	ids := op.targetIDs()
+	if op.Filter != nil {
+		// 上限を超えたかを判定するため1件多く取得する
+		b := &queryBuilder{}
+		taskConditions(b, *op.Filter)
+		query, args := b.build("SELECT id FROM tasks" + b.whereClause() + " ORDER BY id LIMIT " + b.arg(MaxBulkTasks+1))
+		if err := tx.SelectContext(ctx, &ids, query, args...); err != nil {
+			return nil, classify(ctx, err)
+		}
+		if len(ids) > MaxBulkTasks {
+			return nil, tooManyBulkTasks()
+		}
+	}
+
+	results := make([]BulkItemResult, 0, len(ids))
+	for _, id := range ids {
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT bulk_item"); err != nil {
+			return nil, classify(ctx, err)
+		}
+		task, err := bulkItem(ctx, tx, s.opts, op, id)
+		if err != nil {
+			// キャンセルされた場合は一括操作全体を中止する
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT bulk_item"); err != nil {
+				return nil, classify(ctx, err)
+			}
+		} else if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT bulk_item"); err != nil {
+			return nil, classify(ctx, err)
+		}
+		results = append(results, BulkItemResult{ID: id, Task: task, Err: err})
+	}
+
+	// ドライランでは確定せずにロールバックする
+	if op.DryRun {
+		return results, nil
+	}
+	// トランザクション確定
+	return results, classify(ctx, tx.Commit())
+}
+
+// 一括操作をタスク1件に適用し、操作後のタスクを返す
+func bulkItem(ctx context.Context, tx *sqlx.Tx, opts Options, op BulkOperation, id int) (*api.Task, error) {
+	before, err := lockTask(ctx, tx, id, AnyVersion, false)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Action {
+	case BulkDelete:
+		return nil, trashTask(ctx, tx, before, opts.deletePolicy())
+	case BulkAddLabels, BulkRemoveLabels:
+		current, err := taskLabelIDs(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+		// ラベルが変わらないタスクは版を進めない
+		if labels := op.labels(current); !slices.Equal(labels, current) {
+			if err := replaceTaskLabels(ctx, tx, id, current, labels); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		if err := patchTask(ctx, tx, opts, before, op.patch(before)); err != nil {
+			return nil, err
+		}
+	}
+
+	task, err := getTask(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
@@ -0,0 +1,204 @@
+package store
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// タスクのコメントを古い順に取得
+func (s *PostgresStore) ListComments(ctx context.Context, taskID int, query CommentQuery) (CommentPage, error) {
+	var exists bool
+	if err := s.db.GetContext(ctx, &exists, "SELECT EXISTS (SELECT 1 FROM tasks WHERE id = $1 AND deleted_at IS NULL)", taskID); err != nil {
+		return CommentPage{}, classify(ctx, err)
+	}
+	if !exists {
+		return CommentPage{}, ErrNotFound
+	}
+
+	var page CommentPage
+	if err := s.db.GetContext(ctx, &page.Total, "SELECT COUNT(*) FROM task_comments WHERE task_id = $1", taskID); err != nil {
+		return CommentPage{}, classify(ctx, err)
+	}
+	var entities []CommentEntity
+	if err := s.db.SelectContext(ctx, &entities, "SELECT * FROM task_comments WHERE task_id = $1 ORDER BY id LIMIT $2 OFFSET $3",
+		taskID, query.PageSize(), query.offset()); err != nil {
+		return CommentPage{}, classify(ctx, err)
+	}
+	for _, e := range entities {
+		page.Comments = append(page.Comments, e.ToAPIComment())
+	}
+	return page, nil
+}
+
+// コメントを追加
+func (s *PostgresStore) CreateComment(ctx context.Context, taskID int, body string) (api.Comment, error) {
+	body, err := commentBody(body)
+	if err != nil {
+		return api.Comment{}, err
+	}
+
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return api.Comment{}, classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	// 追加する間にタスクがゴミ箱に移動されないよう、版を進めるタスクの行ロックを取得する
+	if _, err := lockTask(ctx, tx, taskID, AnyVersion, false); err != nil {
+		return api.Comment{}, err
+	}
+	var c CommentEntity
+	if err := tx.GetContext(ctx, &c, "INSERT INTO task_comments (task_id, author, body) VALUES ($1, $2, $3) RETURNING *",
+		taskID, actorFromContext(ctx), body); err != nil {
+		return api.Comment{}, classify(ctx, err)
+	}
+	// コメント数はタスクの表現の一部なので、タスクの版を進める
+	if err := bumpVersions(ctx, tx, []int{taskID}); err != nil {
+		return api.Comment{}, err
+	}
+
+	// トランザクションのコミット
+	if err := tx.Commit(); err != nil {
+		return api.Comment{}, classify(ctx, err)
+	}
+	return c.ToAPIComment(), nil
+}
+
+// コメントの本文を編集
+func (s *PostgresStore) UpdateComment(ctx context.Context, taskID, id int, body string, version int) (api.Comment, error) {
+	body, err := commentBody(body)
+	if err != nil {
+		return api.Comment{}, err
+	}
+
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return api.Comment{}, classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	before, err := lockComment(ctx, tx, taskID, id, version)
+	if err != nil {
+		return api.Comment{}, err
+	}
+	// 本文が変わらない編集は履歴に残さない
+	if body == before.Body {
+		return before.ToAPIComment(), nil
+	}
+	revision := revisionOf(before)
+	if _, err := tx.ExecContext(ctx, "INSERT INTO task_comment_revisions (comment_id, version, body, created_at) VALUES ($1, $2, $3, $4)",
+		revision.CommentID, revision.Version, revision.Body, revision.CreatedAt); err != nil {
+		return api.Comment{}, classify(ctx, err)
+	}
+	var c CommentEntity
+	if err := tx.GetContext(ctx, &c, `
+		UPDATE task_comments SET body = $1, edited_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id = $2 RETURNING *`, body, id); err != nil {
+		return api.Comment{}, classify(ctx, err)
+	}
+
+	// トランザクションのコミット
+	if err := tx.Commit(); err != nil {
+		return api.Comment{}, classify(ctx, err)
+	}
+	return c.ToAPIComment(), nil
+}
+
+// コメントを削除
+func (s *PostgresStore) DeleteComment(ctx context.Context, taskID, id int, version int) error {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockComment(ctx, tx, taskID, id, version); err != nil {
+		return err
+	}
+	// 編集履歴は外部キー制約の ON DELETE CASCADE で削除される
+	if _, err := tx.ExecContext(ctx, "DELETE FROM task_comments WHERE id = $1", id); err != nil {
+		return classify(ctx, err)
+	}
+	if err := bumpVersions(ctx, tx, []int{taskID}); err != nil {
+		return err
+	}
+
+	// トランザクションのコミット
+	if err := tx.Commit(); err != nil {
+		return classify(ctx, err)
+	}
+	return nil
+}
+
+// コメントの編集履歴を新しい順に取得
+func (s *PostgresStore) CommentHistory(ctx context.Context, taskID, id int) ([]api.CommentRevision, error) {
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM task_comments c JOIN tasks t ON t.id = c.task_id
+			WHERE c.id = $1 AND c.task_id = $2 AND t.deleted_at IS NULL
+		)
+	`
+	if err := s.db.GetContext(ctx, &exists, query, id, taskID); err != nil {
+		return nil, classify(ctx, err)
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	var revisions []commentRevision
+	if err := s.db.SelectContext(ctx, &revisions, "SELECT * FROM task_comment_revisions WHERE comment_id = $1 ORDER BY version DESC", id); err != nil {
+		return nil, classify(ctx, err)
+	}
+	result := make([]api.CommentRevision, len(revisions))
+	for i, r := range revisions {
+		result[i] = r.toAPICommentRevision()
+	}
+	return result, nil
+}
+
+// ゴミ箱にないタスクのコメントの行ロックを取得し、版と投稿者を確認する
+func lockComment(ctx context.Context, tx *sqlx.Tx, taskID, id int, version int) (CommentEntity, error) {
+	var c CommentEntity
+	query := `
+		SELECT c.* FROM task_comments c JOIN tasks t ON t.id = c.task_id
+		WHERE c.id = $1 AND c.task_id = $2 AND t.deleted_at IS NULL
+		FOR UPDATE OF c
+	`
+	if err := tx.GetContext(ctx, &c, query, id, taskID); err != nil {
+		return CommentEntity{}, classify(ctx, err)
+	}
+	if err := checkVersion(c.Version, version); err != nil {
+		return CommentEntity{}, err
+	}
+	if err := checkAuthor(ctx, c); err != nil {
+		return CommentEntity{}, err
+	}
+	return c, nil
+}
+
+// commentCountRow はタスクごとのコメント数の集計結果の1行です
+type commentCountRow struct {
+	TaskID int `db:"task_id"`
+	Count  int `db:"count"`
+}
+
+// タスクIDごとのコメント数を取得（コメントがないタスクは含まない）
+func commentCountsByTask(ctx context.Context, q sqlx.QueryerContext, taskIDs []int) (map[int]int, error) {
+	var rows []commentCountRow
+	query := "SELECT task_id, COUNT(*) AS count FROM task_comments WHERE task_id = ANY($1) GROUP BY task_id"
+	if err := sqlx.SelectContext(ctx, q, &rows, query, pq.Array(taskIDs)); err != nil {
+		return nil, err
+	}
+	counts := make(map[int]int, len(rows))
+	for _, row := range rows {
+		counts[row.TaskID] = row.Count
+	}
+	return counts, nil
+}
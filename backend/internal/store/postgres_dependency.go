@@ -0,0 +1,175 @@
+package store
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// dependencyLock は依存関係の追加を直列化するアドバイザリロックのキーです
+// 同時に逆向きの依存関係を追加すると、それぞれの検証をすり抜けて循環ができるのを防ぎます
+const dependencyLock = 0x64657073 // "deps"
+
+// 依存関係を追加
+func (s *PostgresStore) AddTaskDependency(ctx context.Context, taskID, dependsOnID int, version int) (api.Task, error) {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockTask(ctx, tx, taskID, version, false); err != nil {
+		return api.Task{}, err
+	}
+	if taskID == dependsOnID {
+		return api.Task{}, dependencyCycle(taskID, dependsOnID)
+	}
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", dependencyLock); err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+
+	var active bool
+	if err := tx.GetContext(ctx, &active, "SELECT EXISTS (SELECT 1 FROM tasks WHERE id = $1 AND deleted_at IS NULL)", dependsOnID); err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	if !active {
+		return api.Task{}, invalidDependency(dependsOnID)
+	}
+
+	// 依存先の依存先をたどって自分自身が含まれていれば循環
+	// ゴミ箱のタスクを経由する依存関係も、元に戻したときに循環しないよう含めて判定する
+	var cycle bool
+	query := `
+		WITH RECURSIVE upstream AS (
+			SELECT depends_on_id AS id FROM task_dependencies WHERE task_id = $1
+			UNION
+			SELECT d.depends_on_id FROM task_dependencies d JOIN upstream u ON d.task_id = u.id
+		)
+		SELECT EXISTS (SELECT 1 FROM upstream WHERE id = $2)
+	`
+	if err := tx.GetContext(ctx, &cycle, query, dependsOnID, taskID); err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	if cycle {
+		return api.Task{}, dependencyCycle(taskID, dependsOnID)
+	}
+
+	before, err := dependencyIDs(ctx, tx, taskID)
+	if err != nil {
+		return api.Task{}, err
+	}
+	result, err := tx.ExecContext(ctx, "INSERT INTO task_dependencies (task_id, depends_on_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", taskID, dependsOnID)
+	if err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return api.Task{}, classify(ctx, err)
+	} else if n == 0 {
+		return api.Task{}, duplicateDependency(taskID, dependsOnID)
+	}
+	if err := touchDependencies(ctx, tx, taskID, before, append(before, dependsOnID)); err != nil {
+		return api.Task{}, err
+	}
+
+	task, err := getTask(ctx, tx, taskID)
+	if err != nil {
+		return api.Task{}, err
+	}
+	// トランザクション確定
+	return task, classify(ctx, tx.Commit())
+}
+
+// 依存関係を削除
+func (s *PostgresStore) RemoveTaskDependency(ctx context.Context, taskID, dependsOnID int, version int) error {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockTask(ctx, tx, taskID, version, false); err != nil {
+		return err
+	}
+	before, err := dependencyIDs(ctx, tx, taskID)
+	if err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, "DELETE FROM task_dependencies WHERE task_id = $1 AND depends_on_id = $2", taskID, dependsOnID)
+	if err != nil {
+		return classify(ctx, err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return classify(ctx, err)
+	} else if n == 0 {
+		return ErrNotFound
+	}
+
+	var after []int
+	for _, id := range before {
+		if id != dependsOnID {
+			after = append(after, id)
+		}
+	}
+	if err := touchDependencies(ctx, tx, taskID, before, after); err != nil {
+		return err
+	}
+
+	// トランザクション確定
+	return classify(ctx, tx.Commit())
+}
+
+// タスクが依存するタスクのIDを昇順で取得（ゴミ箱のタスクも含む）
+func dependencyIDs(ctx context.Context, tx *sqlx.Tx, taskID int) ([]int, error) {
+	var ids []int
+	if err := tx.SelectContext(ctx, &ids, "SELECT depends_on_id FROM task_dependencies WHERE task_id = $1 ORDER BY depends_on_id", taskID); err != nil {
+		return nil, classify(ctx, err)
+	}
+	return ids, nil
+}
+
+// 依存関係はタスクの表現の一部なので、タスクの版を進めて変更履歴を記録する
+func touchDependencies(ctx context.Context, tx *sqlx.Tx, taskID int, before, after []int) error {
+	if _, err := tx.ExecContext(ctx, "UPDATE tasks SET updated_at = CURRENT_TIMESTAMP, updated_by = $2, version = version + 1 WHERE id = $1",
+		taskID, actorFromContext(ctx)); err != nil {
+		return classify(ctx, err)
+	}
+	return insertAudit(ctx, tx, taskDependenciesChange(taskID, sortedIDs(before), sortedIDs(after)))
+}
+
+// 完了していない依存先がないことを確認する
+func checkUnblocked(ctx context.Context, tx *sqlx.Tx, taskID int) error {
+	deps, err := dependenciesByTask(ctx, tx, []int{taskID})
+	if err != nil {
+		return classify(ctx, err)
+	}
+	var task api.Task
+	setDependencies(&task, deps[taskID])
+	if *task.Blocked {
+		return blockedTask(taskID, task.BlockedBy)
+	}
+	return nil
+}
+
+// 複数のタスクが依存するタスク（ゴミ箱のものを除く）と状態をまとめて取得し、依存先のIDの順に返す
+func dependenciesByTask(ctx context.Context, q sqlx.QueryerContext, taskIDs []int) (map[int][]dependency, error) {
+	var rows []dependency
+	query := `
+		SELECT d.task_id, d.depends_on_id, t.status FROM task_dependencies d
+		JOIN tasks t ON t.id = d.depends_on_id
+		WHERE d.task_id = ANY($1) AND t.deleted_at IS NULL
+		ORDER BY d.task_id, d.depends_on_id
+	`
+	if err := sqlx.SelectContext(ctx, q, &rows, query, pq.Array(taskIDs)); err != nil {
+		return nil, err
+	}
+
+	deps := make(map[int][]dependency, len(taskIDs))
+	for _, row := range rows {
+		deps[row.TaskID] = append(deps[row.TaskID], row)
+	}
+	return deps, nil
+}
@@ -0,0 +1,76 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// 制約名とリクエスト上の項目名の対応
+var constraintFields = map[string]string{
+	"tasks_priority_check":        "priority",
+	"tasks_status_check":          "status",
+	"task_labels_pkey":            "label_ids",
+	"task_labels_label_id_fkey":   "label_ids",
+	"users_username":              "username",
+	"task_assignees_user_id_fkey": "user_ids",
+	"projects_name":               "name",
+	"tasks_project_id_fkey":       "project_id",
+	"labels_project_id_fkey":      "project_id",
+	"project_members_role_check":  "role",
+}
+
+// classify はデータベースのエラーをストアのエラー分類に変換します
+func classify(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	// キャンセルやタイムアウトはコンテキストのエラーとして返す
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("%w: %v", ctxErr, err)
+	}
+
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+
+	var kind error
+	switch pqErr.Code {
+	case "23505": // unique_violation
+		kind = ErrConflict
+	case "23503": // foreign_key_violation
+		kind = ErrInvalidReference
+	case "23502", "23514", "22001": // not_null_violation, check_violation, string_data_right_truncation
+		kind = ErrConstraint
+	case "57014": // query_canceled
+		return fmt.Errorf("%w: %v", context.Canceled, err)
+	default:
+		return err
+	}
+
+	field := constraintFields[pqErr.Constraint]
+	if field == "" {
+		field = pqErr.Column
+	}
+	return &ConstraintError{
+		Kind:       kind,
+		Field:      field,
+		Constraint: pqErr.Constraint,
+		Err:        err,
+	}
+}
+
+// checkVersion は行ロックで読み取った版が期待する版と一致するかを確認します
+func checkVersion(current, expected int) error {
+	if expected != AnyVersion && current != expected {
+		return ErrVersionMismatch
+	}
+	return nil
+}
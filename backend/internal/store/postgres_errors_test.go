@@ -0,0 +1,50 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestClassify(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		err       error
+		want      error
+		wantField string
+	}{
+		{"no rows", context.Background(), sql.ErrNoRows, ErrNotFound, ""},
+		{"wrapped no rows", context.Background(), fmt.Errorf("select: %w", sql.ErrNoRows), ErrNotFound, ""},
+		{"unique violation", context.Background(), &pq.Error{Code: "23505", Constraint: "task_labels_pkey"}, ErrConflict, "label_ids"},
+		{"foreign key violation", context.Background(), &pq.Error{Code: "23503", Constraint: "task_labels_label_id_fkey"}, ErrInvalidReference, "label_ids"},
+		{"check violation", context.Background(), &pq.Error{Code: "23514", Constraint: "tasks_status_check"}, ErrConstraint, "status"},
+		{"not null violation", context.Background(), &pq.Error{Code: "23502", Column: "name"}, ErrConstraint, "name"},
+		{"query canceled", context.Background(), &pq.Error{Code: "57014"}, context.Canceled, ""},
+		{"canceled context", canceled, errors.New("driver: bad connection"), context.Canceled, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classify(tt.ctx, tt.err)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("classify(%v) = %v, want %v", tt.err, err, tt.want)
+			}
+			var constraintErr *ConstraintError
+			if errors.As(err, &constraintErr) && constraintErr.Field != tt.wantField {
+				t.Errorf("field = %q, want %q", constraintErr.Field, tt.wantField)
+			}
+		})
+	}
+
+	other := errors.New("connection refused")
+	if err := classify(context.Background(), other); err != other {
+		t.Errorf("classify(%v) = %v, want the error unchanged", other, err)
+	}
+}
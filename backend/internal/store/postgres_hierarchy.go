@@ -0,0 +1,116 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// hierarchyLock は親子関係の変更を直列化するアドバイザリロックのキーです
+// 同時に親を付け替えると、それぞれの検証をすり抜けて循環ができるのを防ぎます
+const hierarchyLock = 0x7461736b // "task"
+
+// descendantsCTE は $1 のタスクの子孫（cond に一致するものをたどる）を descendants とするWITH句を返します
+// UNION で重複を除くため、万一循環があっても再帰は終了します
+func descendantsCTE(cond string) string {
+	return `WITH RECURSIVE descendants AS (
+		SELECT id FROM tasks WHERE parent_id = $1 AND ` + cond + `
+		UNION
+		SELECT t.id FROM tasks t JOIN descendants d ON t.parent_id = d.id WHERE ` + cond + `
+	)`
+}
+
+// 親に指定したタスクが projectID のプロジェクトに存在し、taskID 自身やその子孫でないことを確認する
+func checkParent(ctx context.Context, tx *sqlx.Tx, taskID, projectID int, parentID *int) error {
+	if parentID == nil {
+		return nil
+	}
+	if *parentID == taskID {
+		return parentCycle(taskID, *parentID)
+	}
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", hierarchyLock); err != nil {
+		return classify(ctx, err)
+	}
+
+	var parentProjectID int
+	if err := tx.GetContext(ctx, &parentProjectID, "SELECT project_id FROM tasks WHERE id = $1 AND deleted_at IS NULL", *parentID); err != nil {
+		if err = classify(ctx, err); errors.Is(err, ErrNotFound) {
+			return invalidParent(*parentID)
+		}
+		return err
+	}
+	if parentProjectID != projectID {
+		return foreignParent(*parentID)
+	}
+
+	// 親の祖先をたどって自分自身が含まれていれば循環
+	var cycle bool
+	query := `
+		WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id FROM tasks WHERE id = $1
+			UNION
+			SELECT t.id, t.parent_id FROM tasks t JOIN ancestors a ON t.id = a.parent_id
+		)
+		SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = $2)
+	`
+	if err := tx.GetContext(ctx, &cycle, query, *parentID, taskID); err != nil {
+		return classify(ctx, err)
+	}
+	if cycle {
+		return parentCycle(taskID, *parentID)
+	}
+	return nil
+}
+
+// 行ロックを取得したタスクを1件ずつ query（$1 がID、$2 以降が args）で更新し、変更履歴を action として記録する
+func updateEach(ctx context.Context, tx *sqlx.Tx, tasks []TaskEntity, action api.AuditEntryAction, query string, args ...interface{}) error {
+	for i := range tasks {
+		var after TaskEntity
+		if err := tx.GetContext(ctx, &after, query, append([]interface{}{tasks[i].ID}, args...)...); err != nil {
+			return classify(ctx, err)
+		}
+		if err := insertAudit(ctx, tx, taskChange(action, &tasks[i], &after)); err != nil {
+			return err
+		}
+		if err := touchRelated(ctx, tx, &tasks[i], &after); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rollupRow は親タスクと状態ごとのサブタスクの件数を受け取るための構造体です
+type rollupRow struct {
+	ParentID int    `db:"parent_id"`
+	Status   string `db:"status"`
+	Count    int    `db:"count"`
+}
+
+// 複数のタスクのサブタスク（ゴミ箱のものを除く）の集計をまとめて取得する
+func rollupsByTask(ctx context.Context, q sqlx.QueryerContext, taskIDs []int) (map[int]*api.SubtaskRollup, error) {
+	var rows []rollupRow
+	query := `
+		SELECT parent_id, status, COUNT(*) AS count FROM tasks
+		WHERE parent_id = ANY($1) AND deleted_at IS NULL
+		GROUP BY parent_id, status
+	`
+	if err := sqlx.SelectContext(ctx, q, &rows, query, pq.Array(taskIDs)); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int]map[string]int)
+	for _, row := range rows {
+		if counts[row.ParentID] == nil {
+			counts[row.ParentID] = make(map[string]int)
+		}
+		counts[row.ParentID][row.Status] = row.Count
+	}
+	rollups := make(map[int]*api.SubtaskRollup, len(counts))
+	for id, c := range counts {
+		rollups[id] = subtaskRollup(c)
+	}
+	return rollups, nil
+}
@@ -0,0 +1,182 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// メンバーの一覧で読み込む列（ユーザー名はユーザーから結合する）
+const memberColumns = "m.user_id, u.username, m.role, m.created_at, m.updated_at"
+
+// プロジェクトのメンバーをユーザー名の順に取得
+func (s *PostgresStore) ListProjectMembers(ctx context.Context, projectID int) ([]api.ProjectMember, error) {
+	if _, err := s.GetProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+	var members []api.ProjectMember
+	query := "SELECT " + memberColumns + " FROM project_members m JOIN users u ON u.id = m.user_id WHERE m.project_id = $1 ORDER BY u.username"
+	err := s.db.SelectContext(ctx, &members, query, projectID)
+	return members, classify(ctx, err)
+}
+
+// ユーザーが参加しているプロジェクトのIDごとの役割を取得
+func (s *PostgresStore) ProjectRoles(ctx context.Context, userID int) (map[int]api.ProjectRole, error) {
+	var rows []struct {
+		ProjectID int             `db:"project_id"`
+		Role      api.ProjectRole `db:"role"`
+	}
+	if err := s.db.SelectContext(ctx, &rows, "SELECT project_id, role FROM project_members WHERE user_id = $1", userID); err != nil {
+		return nil, classify(ctx, err)
+	}
+	roles := make(map[int]api.ProjectRole, len(rows))
+	for _, row := range rows {
+		roles[row.ProjectID] = row.Role
+	}
+	return roles, nil
+}
+
+// ユーザーをプロジェクトのメンバーにするか、役割を変更する
+func (s *PostgresStore) SetProjectMember(ctx context.Context, projectID, userID int, role api.ProjectRole) (api.ProjectMember, error) {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return api.ProjectMember{}, classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.GetContext(ctx, &exists, "SELECT EXISTS (SELECT 1 FROM projects WHERE id = $1)", projectID); err != nil {
+		return api.ProjectMember{}, classify(ctx, err)
+	}
+	if !exists {
+		return api.ProjectMember{}, ErrNotFound
+	}
+	var u UserEntity
+	if err := tx.GetContext(ctx, &u, "SELECT * FROM users WHERE id = $1", userID); err != nil {
+		return api.ProjectMember{}, classify(ctx, err)
+	}
+	before, err := lockMember(ctx, tx, projectID, userID)
+	if err != nil {
+		return api.ProjectMember{}, err
+	}
+	if before == nil {
+		m, err := insertMember(ctx, tx, projectID, u, role)
+		if err != nil {
+			return api.ProjectMember{}, err
+		}
+		// トランザクション確定
+		return m, classify(ctx, tx.Commit())
+	}
+	if before.Role == role {
+		return *before, nil
+	}
+	if before.Role == api.ProjectRoleAdmin {
+		if err := checkOtherAdmins(ctx, tx, projectID); err != nil {
+			return api.ProjectMember{}, err
+		}
+	}
+
+	m := *before
+	if err := tx.GetContext(ctx, &m.UpdatedAt,
+		"UPDATE project_members SET role = $3, updated_at = CURRENT_TIMESTAMP WHERE project_id = $1 AND user_id = $2 RETURNING updated_at",
+		projectID, userID, role,
+	); err != nil {
+		return api.ProjectMember{}, classify(ctx, err)
+	}
+	m.Role = role
+	if err := insertAudit(ctx, tx, projectMemberChange(projectID, m.Username, &before.Role, &m.Role)); err != nil {
+		return api.ProjectMember{}, err
+	}
+
+	// トランザクション確定
+	return m, classify(ctx, tx.Commit())
+}
+
+// ユーザーをプロジェクトのメンバーから外す
+func (s *PostgresStore) RemoveProjectMember(ctx context.Context, projectID, userID int) error {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	m, err := lockMember(ctx, tx, projectID, userID)
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		return ErrNotFound
+	}
+	if m.Role == api.ProjectRoleAdmin {
+		if err := checkOtherAdmins(ctx, tx, projectID); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM project_members WHERE project_id = $1 AND user_id = $2", projectID, userID); err != nil {
+		return classify(ctx, err)
+	}
+	if err := insertAudit(ctx, tx, projectMemberChange(projectID, m.Username, &m.Role, nil)); err != nil {
+		return err
+	}
+
+	// トランザクション確定
+	return classify(ctx, tx.Commit())
+}
+
+// タスクが属するプロジェクトのIDを取得
+func (s *PostgresStore) TaskProjectID(ctx context.Context, taskID int) (int, error) {
+	var projectID int
+	err := s.db.GetContext(ctx, &projectID, "SELECT project_id FROM tasks WHERE id = $1", taskID)
+	return projectID, classify(ctx, err)
+}
+
+// メンバーを行ロック付きで取得する（メンバーでない場合は nil）
+func lockMember(ctx context.Context, tx *sqlx.Tx, projectID, userID int) (*api.ProjectMember, error) {
+	var m api.ProjectMember
+	query := "SELECT " + memberColumns + " FROM project_members m JOIN users u ON u.id = m.user_id WHERE m.project_id = $1 AND m.user_id = $2 FOR UPDATE OF m"
+	if err := tx.GetContext(ctx, &m, query, projectID, userID); err != nil {
+		if err = classify(ctx, err); errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+// 対象のほかに admin がいることを確認する
+// 同時に admin を外して admin がいなくならないよう、admin の行をすべてロックします
+func checkOtherAdmins(ctx context.Context, tx *sqlx.Tx, projectID int) error {
+	var admins []int
+	if err := tx.SelectContext(ctx, &admins, "SELECT user_id FROM project_members WHERE project_id = $1 AND role = 'admin' FOR UPDATE", projectID); err != nil {
+		return classify(ctx, err)
+	}
+	if len(admins) <= 1 {
+		return ErrLastAdmin
+	}
+	return nil
+}
+
+// ユーザーをプロジェクトのメンバーに追加し、変更履歴を記録する
+func insertMember(ctx context.Context, tx *sqlx.Tx, projectID int, u UserEntity, role api.ProjectRole) (api.ProjectMember, error) {
+	m := api.ProjectMember{UserId: u.ID, Username: u.Username, Role: role}
+	if err := tx.QueryRowxContext(ctx,
+		"INSERT INTO project_members (project_id, user_id, role) VALUES ($1, $2, $3) RETURNING created_at, updated_at",
+		projectID, u.ID, role,
+	).Scan(&m.CreatedAt, &m.UpdatedAt); err != nil {
+		return api.ProjectMember{}, classify(ctx, err)
+	}
+	if err := insertAudit(ctx, tx, projectMemberChange(projectID, u.Username, nil, &role)); err != nil {
+		return api.ProjectMember{}, err
+	}
+	return m, nil
+}
+
+// scope のユーザーがいずれかの役割で参加しているプロジェクトのIDを返すサブクエリ
+func memberProjects(b *queryBuilder, scope MemberScope) string {
+	return "SELECT project_id FROM project_members WHERE user_id = " + b.arg(scope.UserID) + " AND role = ANY(" + b.arg(pq.Array(scope.roleNames())) + ")"
+}
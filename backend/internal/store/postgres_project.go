@@ -0,0 +1,262 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// プロジェクト一覧を取得
+func (s *PostgresStore) ListProjects(ctx context.Context, archived bool) ([]api.Project, error) {
+	var projects []api.Project
+	err := s.db.SelectContext(ctx, &projects, "SELECT * FROM projects WHERE (archived_at IS NOT NULL) = $1 ORDER BY name, id", archived)
+	return projects, classify(ctx, err)
+}
+
+// IDのプロジェクトを取得
+func (s *PostgresStore) GetProject(ctx context.Context, id int) (api.Project, error) {
+	var p api.Project
+	err := s.db.GetContext(ctx, &p, "SELECT * FROM projects WHERE id = $1", id)
+	return p, classify(ctx, err)
+}
+
+// プロジェクトを作成
+func (s *PostgresStore) CreateProject(ctx context.Context, input api.ProjectInput) (api.Project, error) {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return api.Project{}, classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	var p api.Project
+	if err := tx.GetContext(ctx, &p, "INSERT INTO projects (name, description, created_by, updated_by) VALUES ($1, $2, $3, $3) RETURNING *",
+		input.Name, input.Description, actorFromContext(ctx)); err != nil {
+		return api.Project{}, classify(ctx, err)
+	}
+	if err := insertAudit(ctx, tx, projectChange(api.Create, nil, &p)); err != nil {
+		return api.Project{}, err
+	}
+
+	// 作成したユーザーを admin にする（認証なしで作成した場合はメンバーを追加しない）
+	if actor := actorFromContext(ctx); actor != nil {
+		var u UserEntity
+		err := tx.GetContext(ctx, &u, "SELECT * FROM users WHERE username = $1", *actor)
+		if err = classify(ctx, err); err != nil && !errors.Is(err, ErrNotFound) {
+			return api.Project{}, err
+		}
+		if err == nil {
+			if _, err := insertMember(ctx, tx, p.Id, u, api.ProjectRoleAdmin); err != nil {
+				return api.Project{}, err
+			}
+		}
+	}
+
+	// トランザクション確定
+	return p, classify(ctx, tx.Commit())
+}
+
+// 指定したIDのプロジェクトを更新
+func (s *PostgresStore) UpdateProject(ctx context.Context, id int, input api.ProjectInput, version int) (api.Project, error) {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return api.Project{}, classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	before, err := lockProject(ctx, tx, id, version)
+	if err != nil {
+		return api.Project{}, err
+	}
+	if err := checkWritable(before); err != nil {
+		return api.Project{}, err
+	}
+	var after api.Project
+	if err := tx.GetContext(ctx, &after,
+		"UPDATE projects SET name = $1, description = $2, updated_at = CURRENT_TIMESTAMP, updated_by = $3, version = version + 1 WHERE id = $4 RETURNING *",
+		input.Name, input.Description, actorFromContext(ctx), id,
+	); err != nil {
+		return api.Project{}, classify(ctx, err)
+	}
+	if err := insertAudit(ctx, tx, projectChange(api.Update, &before, &after)); err != nil {
+		return api.Project{}, err
+	}
+
+	// トランザクション確定
+	return after, classify(ctx, tx.Commit())
+}
+
+// プロジェクトをアーカイブするか、アーカイブから戻す
+func (s *PostgresStore) SetProjectArchived(ctx context.Context, id int, archived bool, version int) (api.Project, error) {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return api.Project{}, classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	// 行ロックにより、タスクやラベルを書き込み中のトランザクション（共有ロックを持つ）が終わるまで待つ
+	before, err := lockProject(ctx, tx, id, version)
+	if err != nil {
+		return api.Project{}, err
+	}
+	if (before.ArchivedAt != nil) == archived {
+		return before, nil
+	}
+	query := "UPDATE projects SET archived_at = NULL, updated_at = CURRENT_TIMESTAMP, updated_by = $2, version = version + 1 WHERE id = $1 RETURNING *"
+	if archived {
+		query = "UPDATE projects SET archived_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP, updated_by = $2, version = version + 1 WHERE id = $1 RETURNING *"
+	}
+	var after api.Project
+	if err := tx.GetContext(ctx, &after, query, id, actorFromContext(ctx)); err != nil {
+		return api.Project{}, classify(ctx, err)
+	}
+	if err := insertAudit(ctx, tx, projectChange(api.Update, &before, &after)); err != nil {
+		return api.Project{}, err
+	}
+
+	// トランザクション確定
+	return after, classify(ctx, tx.Commit())
+}
+
+// タスクをサブタスクごと別のプロジェクトに移動し、ラベルを移動先のラベルに付け替える
+func (s *PostgresStore) MoveTask(ctx context.Context, id int, move TaskMove, version int) (api.Task, error) {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	root, err := lockTask(ctx, tx, id, version, false)
+	if err != nil {
+		return api.Task{}, err
+	}
+	if err := shareProject(ctx, tx, move.ProjectID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return api.Task{}, invalidProject(move.ProjectID)
+		}
+		return api.Task{}, err
+	}
+	if root.ProjectID == move.ProjectID {
+		return getTask(ctx, tx, id)
+	}
+
+	// 親子関係を変えずに移動するため、サブタスクはゴミ箱のものも含めて移動する
+	var subtasks []TaskEntity
+	query := descendantsCTE("TRUE") + " SELECT * FROM tasks WHERE id IN (SELECT id FROM descendants) ORDER BY id FOR UPDATE"
+	if err := tx.SelectContext(ctx, &subtasks, query, id); err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	taskIDs := []int{id}
+	for _, e := range subtasks {
+		taskIDs = append(taskIDs, e.ID)
+	}
+
+	var source, targets []api.Label
+	query = `
+		SELECT DISTINCT l.* FROM labels l JOIN task_labels tl ON l.id = tl.label_id
+		WHERE tl.task_id = ANY($1)
+		ORDER BY l.name, l.id
+	`
+	if err := tx.SelectContext(ctx, &source, query, pq.Array(taskIDs)); err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	if err := tx.SelectContext(ctx, &targets, "SELECT * FROM labels WHERE project_id = $1 ORDER BY name, id", move.ProjectID); err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	plan, err := planLabelRemap(move, source, targets)
+	if err != nil {
+		return api.Task{}, err
+	}
+	for _, l := range plan.create {
+		created, err := insertLabel(ctx, tx, move.ProjectID, api.LabelInput{Name: l.Name, Color: l.Color})
+		if err != nil {
+			return api.Task{}, err
+		}
+		plan.mapped[l.ID] = created.ID
+	}
+
+	// 親は移動しないため、移動するタスクは最上位のタスクにする
+	var after TaskEntity
+	if err := tx.GetContext(ctx, &after,
+		"UPDATE tasks SET project_id = $2, parent_id = NULL, updated_at = CURRENT_TIMESTAMP, updated_by = $3, version = version + 1 WHERE id = $1 RETURNING *",
+		id, move.ProjectID, actorFromContext(ctx),
+	); err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	if err := insertAudit(ctx, tx, taskChange(api.Update, &root, &after)); err != nil {
+		return api.Task{}, err
+	}
+	if err := touchRelated(ctx, tx, &root, &after); err != nil {
+		return api.Task{}, err
+	}
+	if err := updateEach(ctx, tx, subtasks, api.Update,
+		"UPDATE tasks SET project_id = $2, updated_at = CURRENT_TIMESTAMP, updated_by = $3, version = version + 1 WHERE id = $1 RETURNING *",
+		move.ProjectID, actorFromContext(ctx)); err != nil {
+		return api.Task{}, err
+	}
+	for _, taskID := range taskIDs {
+		if err := remapTaskLabels(ctx, tx, taskID, plan); err != nil {
+			return api.Task{}, err
+		}
+	}
+
+	task, err := getTask(ctx, tx, id)
+	if err != nil {
+		return api.Task{}, err
+	}
+	// トランザクション確定
+	if err := tx.Commit(); err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	return task, nil
+}
+
+// 移動したタスクのラベルを付け替え、変更履歴を記録する（タスクの版は移動で進めるため、ここでは進めない）
+func remapTaskLabels(ctx context.Context, tx *sqlx.Tx, taskID int, plan labelRemap) error {
+	before, err := taskLabelIDs(ctx, tx, taskID)
+	if err != nil || len(before) == 0 {
+		return err
+	}
+	after := plan.remap(before)
+	if _, err := tx.ExecContext(ctx, "DELETE FROM task_labels WHERE task_id = $1", taskID); err != nil {
+		return classify(ctx, err)
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO task_labels (task_id, label_id) SELECT $1, unnest($2::int[])", taskID, pq.Array(after)); err != nil {
+		return classify(ctx, err)
+	}
+	return insertAudit(ctx, tx, taskLabelsChange(taskID, before, after))
+}
+
+// 更新の対象となるプロジェクトを行ロック付きで取得し、版を確認する
+func lockProject(ctx context.Context, tx *sqlx.Tx, id int, version int) (api.Project, error) {
+	var p api.Project
+	if err := tx.GetContext(ctx, &p, "SELECT * FROM projects WHERE id = $1 FOR UPDATE", id); err != nil {
+		return api.Project{}, classify(ctx, err)
+	}
+	return p, checkVersion(p.Version, version)
+}
+
+// プロジェクトがアーカイブされていないことを確認する
+// 書き込みが終わるまでアーカイブされないよう、プロジェクトの共有ロックを取得します
+func shareProject(ctx context.Context, tx *sqlx.Tx, id int) error {
+	var p api.Project
+	if err := tx.GetContext(ctx, &p, "SELECT * FROM projects WHERE id = $1 FOR SHARE", id); err != nil {
+		return classify(ctx, err)
+	}
+	return checkWritable(p)
+}
+
+// ゴミ箱にないタスクの共有ロックを取得し、プロジェクトがアーカイブされていないことを確認する（添付ファイルの追加で使う）
+func shareTask(ctx context.Context, tx *sqlx.Tx, id int) error {
+	var projectID int
+	if err := tx.GetContext(ctx, &projectID, "SELECT project_id FROM tasks WHERE id = $1 AND deleted_at IS NULL FOR SHARE", id); err != nil {
+		return classify(ctx, err)
+	}
+	return shareProject(ctx, tx, projectID)
+}
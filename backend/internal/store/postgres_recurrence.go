@@ -0,0 +1,55 @@
+package store
+
+import (
+	"context"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// タスクの次の繰り返しを取得
+func (s *PostgresStore) PreviewRecurrence(ctx context.Context, id int, n int) (api.RecurrencePreview, error) {
+	var e TaskEntity
+	if err := s.db.GetContext(ctx, &e, "SELECT * FROM tasks WHERE id = $1 AND deleted_at IS NULL", id); err != nil {
+		return api.RecurrencePreview{}, classify(ctx, err)
+	}
+	return recurrencePreview(e, n)
+}
+
+// 繰り返しのタスクを次の繰り返しの日付に移す
+func (s *PostgresStore) SkipOccurrence(ctx context.Context, id int, version int) (api.Task, error) {
+	return s.updateRecurrence(ctx, id, version, skipOccurrence)
+}
+
+// タスクの繰り返しを終了する
+func (s *PostgresStore) EndRecurrence(ctx context.Context, id int, version int) (api.Task, error) {
+	return s.updateRecurrence(ctx, id, version, endRecurrence)
+}
+
+// 行ロックを取得したタスクを change で変更し、更新後のタスクを返す
+func (s *PostgresStore) updateRecurrence(ctx context.Context, id int, version int, change func(TaskEntity) (TaskEntity, error)) (api.Task, error) {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return api.Task{}, classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	before, err := lockTask(ctx, tx, id, version, false)
+	if err != nil {
+		return api.Task{}, err
+	}
+	e, err := change(before)
+	if err != nil {
+		return api.Task{}, err
+	}
+	if err := writeTask(ctx, tx, s.opts, before, e); err != nil {
+		return api.Task{}, err
+	}
+
+	task, err := getTask(ctx, tx, id)
+	if err != nil {
+		return api.Task{}, err
+	}
+	// トランザクション確定
+	return task, classify(ctx, tx.Commit())
+}
@@ -0,0 +1,98 @@
+package store
+
+import (
+	"context"
+)
+
+// ユーザーを追加
+func (s *PostgresStore) CreateUser(ctx context.Context, username, passwordHash string) (UserEntity, error) {
+	var u UserEntity
+	if err := s.db.GetContext(ctx, &u, "INSERT INTO users (username, password_hash) VALUES ($1, $2) RETURNING *",
+		username, passwordHash); err != nil {
+		return UserEntity{}, classify(ctx, err)
+	}
+	return u, nil
+}
+
+// 指定したIDのユーザーを取得
+func (s *PostgresStore) GetUser(ctx context.Context, id int) (UserEntity, error) {
+	var u UserEntity
+	if err := s.db.GetContext(ctx, &u, "SELECT * FROM users WHERE id = $1", id); err != nil {
+		return UserEntity{}, classify(ctx, err)
+	}
+	return u, nil
+}
+
+// ユーザー名でユーザーを取得
+func (s *PostgresStore) GetUserByUsername(ctx context.Context, username string) (UserEntity, error) {
+	var u UserEntity
+	if err := s.db.GetContext(ctx, &u, "SELECT * FROM users WHERE username = $1", username); err != nil {
+		return UserEntity{}, classify(ctx, err)
+	}
+	return u, nil
+}
+
+// リフレッシュトークンを記録
+func (s *PostgresStore) CreateRefreshToken(ctx context.Context, token RefreshTokenEntity) error {
+	_, err := s.db.ExecContext(ctx, "INSERT INTO refresh_tokens (id, user_id, expires_at) VALUES ($1, $2, $3)",
+		token.ID, token.UserID, token.ExpiresAt)
+	return classify(ctx, err)
+}
+
+// リフレッシュトークンを新しいものに置き換える
+func (s *PostgresStore) RotateRefreshToken(ctx context.Context, id string, next RefreshTokenEntity) (UserEntity, error) {
+	// トランザクション開始
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return UserEntity{}, classify(ctx, err)
+	}
+	defer tx.Rollback()
+
+	// 同じトークンでの同時の更新を直列化する
+	var token RefreshTokenEntity
+	if err := tx.GetContext(ctx, &token,
+		"SELECT * FROM refresh_tokens WHERE id = $1 AND expires_at > CURRENT_TIMESTAMP FOR UPDATE", id); err != nil {
+		return UserEntity{}, classify(ctx, err)
+	}
+	if token.RevokedAt != nil {
+		// 使い回しを検知したので、そのユーザーのトークンをすべて失効させる
+		if _, err := tx.ExecContext(ctx,
+			"UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND revoked_at IS NULL", token.UserID); err != nil {
+			return UserEntity{}, classify(ctx, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return UserEntity{}, classify(ctx, err)
+		}
+		return UserEntity{}, ErrTokenRevoked
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE id = $1", id); err != nil {
+		return UserEntity{}, classify(ctx, err)
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO refresh_tokens (id, user_id, expires_at) VALUES ($1, $2, $3)",
+		next.ID, token.UserID, next.ExpiresAt); err != nil {
+		return UserEntity{}, classify(ctx, err)
+	}
+	// 期限切れの記録を削除する
+	if _, err := tx.ExecContext(ctx,
+		"DELETE FROM refresh_tokens WHERE user_id = $1 AND expires_at <= CURRENT_TIMESTAMP", token.UserID); err != nil {
+		return UserEntity{}, classify(ctx, err)
+	}
+	var u UserEntity
+	if err := tx.GetContext(ctx, &u, "SELECT * FROM users WHERE id = $1", token.UserID); err != nil {
+		return UserEntity{}, classify(ctx, err)
+	}
+
+	// トランザクションのコミット
+	if err := tx.Commit(); err != nil {
+		return UserEntity{}, classify(ctx, err)
+	}
+	return u, nil
+}
+
+// リフレッシュトークンを失効させる
+func (s *PostgresStore) RevokeRefreshToken(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx,
+		"UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE id = $1 AND revoked_at IS NULL", id)
+	return classify(ctx, err)
+}
@@ -0,0 +1,142 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// DefaultProjectID は既定のプロジェクトのIDです
+// プロジェクトを導入する前のタスクとラベルはこのプロジェクトに属し、プロジェクトを指定せずに作成したものもここに作成します
+const DefaultProjectID = 1
+
+// ErrProjectArchived はアーカイブしたプロジェクトのタスクやラベルを変更しようとした場合に返されます
+var ErrProjectArchived = errors.New("store: project is archived")
+
+// ProjectStore はプロジェクトとプロジェクト間のタスクの移動の永続化を抽象化します
+// アーカイブしたプロジェクトは読み取り専用で、プロジェクト自身とそのタスク・ラベルの変更は ErrProjectArchived を返します
+type ProjectStore interface {
+	// ListProjects は archived が true の場合はアーカイブしたプロジェクトを、false の場合はそれ以外を名前順で返します
+	ListProjects(ctx context.Context, archived bool) ([]api.Project, error)
+	GetProject(ctx context.Context, id int) (api.Project, error)
+	// CreateProject はプロジェクトを作成します。同じ名前のプロジェクトがある場合は ErrConflict を返します
+	CreateProject(ctx context.Context, input api.ProjectInput) (api.Project, error)
+	UpdateProject(ctx context.Context, id int, input api.ProjectInput, version int) (api.Project, error)
+	// SetProjectArchived はプロジェクトをアーカイブするか、アーカイブから戻します
+	// 既にその状態の場合は何も変更せずに現在のプロジェクトを返します
+	SetProjectArchived(ctx context.Context, id int, archived bool, version int) (api.Project, error)
+	// MoveTask はタスクをサブタスク（ゴミ箱のものを含む）ごと別のプロジェクトに移し、移動したタスクを返します
+	// 親タスクは移動しないため、移動したタスクは最上位のタスクになります
+	MoveTask(ctx context.Context, id int, move TaskMove, version int) (api.Task, error)
+}
+
+// TaskMove はタスクの移動先と、ラベルの付け替え方です
+// ラベルは LabelMap、移動先の同じ名前のラベル、DropMissingLabels の順に付け替え先を決めます
+type TaskMove struct {
+	ProjectID int
+	// LabelMap は移動元のラベルIDごとの移動先のラベルIDです
+	LabelMap map[int]int
+	// DropMissingLabels が true の場合は付け替え先のないラベルを外し、false の場合は同じ名前と色のラベルを移動先に作成します
+	DropMissingLabels bool
+}
+
+// labelRemap は移動元のラベルを移動先のラベルに付け替える方法です
+type labelRemap struct {
+	// mapped は移動元のラベルIDごとの移動先のラベルIDです（含まれないラベルは外す）
+	mapped map[int]int
+	// create は移動先に同じ名前と色で作成する移動元のラベルです（作成後に mapped に追加する）
+	create []api.Label
+}
+
+// planLabelRemap は移動するタスクに付いているラベル（source）の付け替え先を、移動先のラベル（targets、名前順）から決めます
+func planLabelRemap(move TaskMove, source, targets []api.Label) (labelRemap, error) {
+	plan := labelRemap{mapped: make(map[int]int)}
+	inTarget := make(map[int]bool, len(targets))
+	byName := make(map[string]int, len(targets))
+	for _, l := range targets {
+		inTarget[l.ID] = true
+		// 同じ名前のラベルが複数ある場合はIDの小さいものを使う
+		if _, ok := byName[l.Name]; !ok {
+			byName[l.Name] = l.ID
+		}
+	}
+	for _, l := range source {
+		if to, ok := move.LabelMap[l.ID]; ok {
+			if !inTarget[to] {
+				return labelRemap{}, &ConstraintError{
+					Kind:       ErrInvalidReference,
+					Field:      "label_map",
+					Constraint: "task_labels_project",
+					Err:        fmt.Errorf("label %d does not belong to project %d", to, move.ProjectID),
+				}
+			}
+			plan.mapped[l.ID] = to
+		} else if to, ok := byName[l.Name]; ok {
+			plan.mapped[l.ID] = to
+		} else if !move.DropMissingLabels {
+			plan.create = append(plan.create, l)
+		}
+	}
+	return plan, nil
+}
+
+// remap は付け替え後のラベルIDを昇順で返します
+func (p labelRemap) remap(labelIDs []int) []int {
+	result := []int{}
+	for _, id := range labelIDs {
+		if to, ok := p.mapped[id]; ok {
+			result = append(result, to)
+		}
+	}
+	return sortedIDs(result)
+}
+
+// 移動先や作成先に指定したプロジェクトが存在しない場合のエラー
+func invalidProject(projectID int) error {
+	return &ConstraintError{
+		Kind:       ErrInvalidReference,
+		Field:      "project_id",
+		Constraint: "tasks_project_id_fkey",
+		Err:        fmt.Errorf("project %d does not exist", projectID),
+	}
+}
+
+// 同じ名前のプロジェクトがある場合のエラー
+func duplicateProjectName(name string) error {
+	return &ConstraintError{
+		Kind:       ErrConflict,
+		Field:      "name",
+		Constraint: "projects_name",
+		Err:        fmt.Errorf("project name %q is already taken", name),
+	}
+}
+
+// タスクと異なるプロジェクトのラベルを付けようとした場合のエラー
+func foreignLabel(labelID int) error {
+	return &ConstraintError{
+		Kind:       ErrInvalidReference,
+		Field:      "label_ids",
+		Constraint: "task_labels_project",
+		Err:        fmt.Errorf("label %d belongs to another project", labelID),
+	}
+}
+
+// 親に別のプロジェクトのタスクを指定した場合のエラー
+func foreignParent(parentID int) error {
+	return &ConstraintError{
+		Kind:       ErrInvalidReference,
+		Field:      "parent_id",
+		Constraint: "tasks_parent_project",
+		Err:        fmt.Errorf("parent task %d belongs to another project", parentID),
+	}
+}
+
+// checkWritable はアーカイブしたプロジェクトでないことを確認します
+func checkWritable(p api.Project) error {
+	if p.ArchivedAt != nil {
+		return ErrProjectArchived
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+func TestPlanLabelRemap(t *testing.T) {
+	source := []api.Label{{ID: 1, Name: "bug"}, {ID: 2, Name: "docs"}, {ID: 3, Name: "ops"}}
+	targets := []api.Label{{ID: 10, Name: "bug"}, {ID: 11, Name: "bug"}, {ID: 12, Name: "chore"}}
+	tests := []struct {
+		name       string
+		move       TaskMove
+		wantMapped string
+		wantCreate []int
+	}{
+		// 同じ名前のラベルが複数あればIDの小さいものに付け替え、ないものは作成する
+		{"by name", TaskMove{}, "map[1:10]", []int{2, 3}},
+		{"label map first", TaskMove{LabelMap: map[int]int{1: 11, 2: 12}}, "map[1:11 2:12]", []int{3}},
+		{"drop missing", TaskMove{DropMissingLabels: true}, "map[1:10]", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := planLabelRemap(tt.move, source, targets)
+			if err != nil {
+				t.Fatalf("planLabelRemap: %v", err)
+			}
+			var created []int
+			for _, l := range plan.create {
+				created = append(created, l.ID)
+			}
+			if fmt.Sprint(plan.mapped) != tt.wantMapped || fmt.Sprint(created) != fmt.Sprint(tt.wantCreate) {
+				t.Errorf("plan = %v, create %v, want %s, create %v", plan.mapped, created, tt.wantMapped, tt.wantCreate)
+			}
+		})
+	}
+
+	// 移動先のプロジェクトにないラベルには付け替えられない
+	_, err := planLabelRemap(TaskMove{LabelMap: map[int]int{1: 1}}, source, targets)
+	var constraint *ConstraintError
+	if !errors.As(err, &constraint) || !errors.Is(err, ErrInvalidReference) || constraint.Field != "label_map" {
+		t.Errorf("planLabelRemap with a foreign label error = %v, want invalid reference on label_map", err)
+	}
+}
+
+func TestMemoryStoreMoveTask(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{})
+	target, err := s.CreateProject(ctx, api.ProjectInput{Name: "side project"})
+	if err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	targetBug, err := s.CreateLabel(ctx, target.Id, api.LabelInput{Name: "bug", Color: "#ff0000"})
+	if err != nil {
+		t.Fatalf("CreateLabel: %v", err)
+	}
+	bug, err := s.CreateLabel(ctx, DefaultProjectID, api.LabelInput{Name: "bug", Color: "#ff0000"})
+	if err != nil {
+		t.Fatalf("CreateLabel: %v", err)
+	}
+	docs, err := s.CreateLabel(ctx, DefaultProjectID, api.LabelInput{Name: "docs", Color: "#0000ff"})
+	if err != nil {
+		t.Fatalf("CreateLabel: %v", err)
+	}
+
+	parent := newTestTask(t, s, taskInput("epic", "Middle", "NotStarted"))
+	root := newTestSubtask(t, s, "story", parent)
+	child := newTestSubtask(t, s, "subtask", root)
+	if err := s.SetTaskLabels(ctx, root, []int{bug}, AnyVersion); err != nil {
+		t.Fatalf("SetTaskLabels: %v", err)
+	}
+	if err := s.SetTaskLabels(ctx, child, []int{docs}, AnyVersion); err != nil {
+		t.Fatalf("SetTaskLabels: %v", err)
+	}
+
+	moved, err := s.MoveTask(ctx, root, TaskMove{ProjectID: target.Id}, AnyVersion)
+	if err != nil {
+		t.Fatalf("MoveTask: %v", err)
+	}
+	// 親は移動しないので、移動したタスクは最上位になる
+	if *moved.ProjectId != target.Id || moved.ParentId != nil {
+		t.Errorf("moved task = %+v, want a top-level task in project %d", moved, target.Id)
+	}
+	if len(moved.Labels) != 1 || moved.Labels[0].ID != targetBug {
+		t.Errorf("moved labels = %+v, want the target project's bug label", moved.Labels)
+	}
+	movedChild := getTestTask(t, s, child)
+	if *movedChild.ProjectId != target.Id || *movedChild.ParentId != root {
+		t.Errorf("subtask = %+v, want it moved with its parent", movedChild)
+	}
+	// 移動先にないラベルは同じ名前と色で作成する
+	if len(movedChild.Labels) != 1 || movedChild.Labels[0].Name != "docs" || movedChild.Labels[0].ProjectId != target.Id {
+		t.Errorf("subtask labels = %+v, want docs created in the target project", movedChild.Labels)
+	}
+	if got := getTestTask(t, s, parent).Subtasks; got != nil && got.Total != 0 {
+		t.Errorf("parent rollup = %+v, want no subtasks", got)
+	}
+
+	_, err = s.MoveTask(ctx, root, TaskMove{ProjectID: 999}, AnyVersion)
+	if !errors.Is(err, ErrInvalidReference) {
+		t.Errorf("MoveTask to a missing project error = %v, want ErrInvalidReference", err)
+	}
+	if _, err := s.SetProjectArchived(ctx, DefaultProjectID, true, AnyVersion); err != nil {
+		t.Fatalf("SetProjectArchived: %v", err)
+	}
+	if _, err := s.MoveTask(ctx, root, TaskMove{ProjectID: DefaultProjectID}, AnyVersion); !errors.Is(err, ErrProjectArchived) {
+		t.Errorf("MoveTask to an archived project error = %v, want ErrProjectArchived", err)
+	}
+}
+
+func TestMemoryStoreArchivedProjectIsReadOnly(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{})
+	task := newTestTask(t, s, taskInput("task", "Middle", "NotStarted"))
+	project, err := s.SetProjectArchived(ctx, DefaultProjectID, true, AnyVersion)
+	if err != nil {
+		t.Fatalf("SetProjectArchived: %v", err)
+	}
+	if project.ArchivedAt == nil {
+		t.Fatalf("archived project = %+v, want archived_at", project)
+	}
+	archived, err := s.ListProjects(ctx, true)
+	if err != nil || len(archived) != 1 || archived[0].Id != DefaultProjectID {
+		t.Errorf("ListProjects(archived) = %+v, %v", archived, err)
+	}
+
+	if _, err := s.CreateLabel(ctx, DefaultProjectID, api.LabelInput{Name: "bug", Color: "#ff0000"}); !errors.Is(err, ErrProjectArchived) {
+		t.Errorf("CreateLabel error = %v, want ErrProjectArchived", err)
+	}
+	if err := s.DeleteTask(ctx, task, AnyVersion); !errors.Is(err, ErrProjectArchived) {
+		t.Errorf("DeleteTask error = %v, want ErrProjectArchived", err)
+	}
+	if _, err := s.UpdateProject(ctx, DefaultProjectID, api.ProjectInput{Name: "renamed"}, AnyVersion); !errors.Is(err, ErrProjectArchived) {
+		t.Errorf("UpdateProject error = %v, want ErrProjectArchived", err)
+	}
+
+	if _, err := s.SetProjectArchived(ctx, DefaultProjectID, false, AnyVersion); err != nil {
+		t.Fatalf("SetProjectArchived(false): %v", err)
+	}
+	if err := s.DeleteTask(ctx, task, AnyVersion); err != nil {
+		t.Errorf("DeleteTask after unarchiving: %v", err)
+	}
+}
+
+func TestMemoryStoreProjectNameIsUnique(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{})
+	if _, err := s.CreateProject(ctx, api.ProjectInput{Name: "side project"}); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	other, err := s.CreateProject(ctx, api.ProjectInput{Name: "other"})
+	if err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	if _, err := s.CreateProject(ctx, api.ProjectInput{Name: "side project"}); !errors.Is(err, ErrConflict) {
+		t.Errorf("CreateProject with a taken name error = %v, want ErrConflict", err)
+	}
+	if _, err := s.UpdateProject(ctx, other.Id, api.ProjectInput{Name: "side project"}, AnyVersion); !errors.Is(err, ErrConflict) {
+		t.Errorf("UpdateProject with a taken name error = %v, want ErrConflict", err)
+	}
+}
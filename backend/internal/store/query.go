@@ -0,0 +1,59 @@
+package store
+
+import (
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// queryBuilder はWHERE句の条件と引数を組み立てます
+// 条件には値を直接埋め込まず、"?" のプレースホルダーと引数の組で追加します
+// 最後に build でPostgreSQLの "$1" 形式に変換します
+type queryBuilder struct {
+	conds []string
+	args  []interface{}
+}
+
+// where は条件を1つ追加します（複数の条件はANDで結合されます）
+func (b *queryBuilder) where(cond string, args ...interface{}) {
+	b.conds = append(b.conds, cond)
+	b.args = append(b.args, args...)
+}
+
+// arg は引数を追加し、対応するプレースホルダーを返します
+func (b *queryBuilder) arg(v interface{}) string {
+	b.args = append(b.args, v)
+	return "?"
+}
+
+// whereClause はここまでの条件をWHERE句にして返します（条件がない場合は空）
+func (b *queryBuilder) whereClause() string {
+	if len(b.conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(b.conds, " AND ")
+}
+
+// build はクエリのプレースホルダーをPostgreSQL形式に変換し、引数と一緒に返します
+func (b *queryBuilder) build(query string) (string, []interface{}) {
+	return sqlx.Rebind(sqlx.DOLLAR, query), b.args
+}
+
+// likePattern はLIKEの特殊文字をエスケープして部分一致のパターンにします
+func likePattern(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + r.Replace(s) + "%"
+}
+
+// uniqueInts は重複を除いた値を元の順序で返します
+func uniqueInts(values []int) []int {
+	seen := make(map[int]bool, len(values))
+	var result []int
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
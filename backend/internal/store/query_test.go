@@ -0,0 +1,115 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	var b queryBuilder
+	b.where("status = ANY(?)", []string{"NotStarted"})
+	b.where("name ILIKE " + b.arg(likePattern("50%_off\\")))
+	query, args := b.build("SELECT id FROM tasks" + b.whereClause())
+
+	if want := "SELECT id FROM tasks WHERE status = ANY($1) AND name ILIKE $2"; query != want {
+		t.Errorf("query = %q, want %q", query, want)
+	}
+	if want := `[[NotStarted] %50\%\_off\\%]`; fmt.Sprint(args) != want {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+	if clause := (&queryBuilder{}).whereClause(); clause != "" {
+		t.Errorf("whereClause without conditions = %q, want empty", clause)
+	}
+}
+
+// オフセット付きの日時はUTCに変換してからTIMESTAMPの列と比べる
+func TestTaskConditionsUTC(t *testing.T) {
+	from, err := time.Parse(time.RFC3339, "2024-01-01T00:00:00+09:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &queryBuilder{}
+	taskConditions(b, TaskFilter{EndDate: TimeRange{From: &from}})
+	query, args := b.build("SELECT id FROM tasks" + b.whereClause())
+
+	if want := "SELECT id FROM tasks WHERE deleted_at IS NULL AND project_id IN (SELECT id FROM projects WHERE archived_at IS NULL) AND end_date >= $1"; query != want {
+		t.Errorf("query = %q, want %q", query, want)
+	}
+	bound, ok := args[0].(time.Time)
+	if !ok || bound.Location() != time.UTC || bound.Format(time.RFC3339) != "2023-12-31T15:00:00Z" {
+		t.Errorf("bound = %v, want 2023-12-31T15:00:00Z in UTC", args[0])
+	}
+}
+
+func TestMemoryStoreListTasksFilter(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{})
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := func(d int) *time.Time {
+		v := now.AddDate(0, 0, d)
+		return &v
+	}
+	red, err := s.CreateLabel(ctx, DefaultProjectID, api.LabelInput{Name: "red", Color: "#FF0000"})
+	if err != nil {
+		t.Fatalf("CreateLabel: %v", err)
+	}
+	blue, err := s.CreateLabel(ctx, DefaultProjectID, api.LabelInput{Name: "blue", Color: "#0000FF"})
+	if err != nil {
+		t.Fatalf("CreateLabel: %v", err)
+	}
+
+	tasks := []struct {
+		input  api.TaskInput
+		labels []int
+	}{
+		{api.TaskInput{Name: "late report", Priority: api.TaskInputPriorityHigh, Status: api.TaskInputStatusInProgress, StartDate: day(-10), EndDate: day(-1)}, []int{red}},
+		{api.TaskInput{Name: "done report", Priority: api.TaskInputPriorityLow, Status: api.TaskInputStatusCompleted, EndDate: day(-2)}, []int{red, blue}},
+		{api.TaskInput{Name: "future plan", Priority: api.TaskInputPriorityMiddle, Status: api.TaskInputStatusNotStarted, StartDate: day(1), EndDate: day(5)}, []int{blue}},
+		{api.TaskInput{Name: "no dates", Priority: api.TaskInputPriorityHigh, Status: api.TaskInputStatusNotStarted}, nil},
+	}
+	for _, task := range tasks {
+		id := newTestTask(t, s, task.input)
+		if err := s.SetTaskLabels(ctx, id, task.labels, AnyVersion); err != nil {
+			t.Fatalf("SetTaskLabels(%q): %v", task.input.Name, err)
+		}
+	}
+
+	yes, no := true, false
+	tests := []struct {
+		name   string
+		filter TaskFilter
+		want   string
+	}{
+		{"statuses", TaskFilter{Statuses: []string{"NotStarted", "Completed"}}, "[done report future plan no dates]"},
+		{"priorities", TaskFilter{Priorities: []string{"High"}}, "[late report no dates]"},
+		{"name", TaskFilter{Name: "REPORT"}, "[done report late report]"},
+		{"any label", TaskFilter{LabelIDs: []int{red, blue}, LabelMatch: LabelMatchAny}, "[done report future plan late report]"},
+		{"all labels", TaskFilter{LabelIDs: []int{red, blue}, LabelMatch: LabelMatchAll}, "[done report]"},
+		{"without labels", TaskFilter{HasLabels: &no}, "[no dates]"},
+		// 範囲を指定すると日付のないタスクは含まれない
+		{"end date range", TaskFilter{EndDate: TimeRange{From: day(-2), To: day(0)}}, "[done report late report]"},
+		{"start date from", TaskFilter{StartDate: TimeRange{From: day(0)}}, "[future plan]"},
+		// 完了したタスクは期限を過ぎていても期限切れではない
+		{"overdue", TaskFilter{Overdue: &yes, Now: now}, "[late report]"},
+		{"not overdue", TaskFilter{Overdue: &no, Now: now}, "[done report future plan no dates]"},
+		{"combined", TaskFilter{Priorities: []string{"High"}, HasLabels: &yes}, "[late report]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := s.ListTasks(ctx, TaskQuery{Filter: tt.filter, Sort: "name"})
+			if err != nil {
+				t.Fatalf("ListTasks: %v", err)
+			}
+			if got := fmt.Sprint(taskNames(page.Tasks)); got != tt.want {
+				t.Errorf("tasks = %s, want %s", got, tt.want)
+			}
+			if page.Total != len(page.Tasks) {
+				t.Errorf("Total = %d, want %d", page.Total, len(page.Tasks))
+			}
+		})
+	}
+}
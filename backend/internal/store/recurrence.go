@@ -0,0 +1,226 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/recurrence"
+)
+
+// 繰り返しのプレビューで返す件数の既定値と上限
+const (
+	DefaultOccurrences = 5
+	MaxOccurrences     = 100
+)
+
+// DefaultTimezone は繰り返しのタイムゾーンを指定しなかった場合に使うタイムゾーンです
+const DefaultTimezone = "UTC"
+
+// RecurrenceStore は繰り返しタスクの操作を抽象化します
+// 繰り返しのタスクを完了すると、TaskStore の更新と同じトランザクションで次のタスクが作られます
+type RecurrenceStore interface {
+	// PreviewRecurrence はタスクの次の繰り返しを最大 n 件返します。繰り返しでないタスクでは ErrNotFound を返します
+	PreviewRecurrence(ctx context.Context, id int, n int) (api.RecurrencePreview, error)
+	// SkipOccurrence は繰り返しのタスクを次の繰り返しの日付に移し、更新後のタスクを返します
+	SkipOccurrence(ctx context.Context, id int, version int) (api.Task, error)
+	// EndRecurrence はタスクから繰り返しの規則を外し、完了しても次のタスクを作らないようにします
+	EndRecurrence(ctx context.Context, id int, version int) (api.Task, error)
+}
+
+// anchor は繰り返しの基準となる日時です（開始日、なければ終了日）
+func (e TaskEntity) anchor() *time.Time {
+	if e.StartDate != nil {
+		return e.StartDate
+	}
+	return e.EndDate
+}
+
+// normalizeRecurrence は繰り返しの規則とタイムゾーンを検証して正規化します
+// 規則を新しく設定したか変更した場合は、現在の日付を COUNT を数える起点にします（作成時の before はnil）
+func normalizeRecurrence(before, e *TaskEntity) error {
+	if e.RecurrenceRule == nil {
+		e.RecurrenceTimezone = nil
+		e.RecurrenceStart = nil
+		return nil
+	}
+
+	tz := DefaultTimezone
+	if e.RecurrenceTimezone != nil && *e.RecurrenceTimezone != "" {
+		tz = *e.RecurrenceTimezone
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil || tz == "Local" {
+		return invalidRecurrence("recurrence_timezone", fmt.Errorf("unknown time zone %q", tz))
+	}
+	rule, err := recurrence.Parse(*e.RecurrenceRule, loc)
+	if err != nil {
+		return invalidRecurrence("recurrence_rule", err)
+	}
+	anchor := e.anchor()
+	if anchor == nil {
+		return invalidRecurrence("recurrence_rule", errors.New("a recurring task needs start_date or end_date"))
+	}
+
+	normalized := rule.String()
+	e.RecurrenceRule = &normalized
+	e.RecurrenceTimezone = &tz
+	if before == nil || before.RecurrenceStart == nil ||
+		stringValue(before.RecurrenceRule) != normalized || stringValue(before.RecurrenceTimezone) != tz {
+		start := *anchor
+		e.RecurrenceStart = &start
+	}
+	return nil
+}
+
+// occurrences はタスクの日付より後の繰り返しを最大 n 件返します
+// 開始日と終了日の両方がある場合は、期間の長さを保ったまま移します
+func occurrences(e TaskEntity, n int) ([]api.TaskOccurrence, error) {
+	loc, err := time.LoadLocation(stringValue(e.RecurrenceTimezone))
+	if err != nil {
+		return nil, err
+	}
+	rule, err := recurrence.Parse(stringValue(e.RecurrenceRule), loc)
+	if err != nil {
+		return nil, err
+	}
+	anchor := e.anchor()
+	start := e.RecurrenceStart
+	if start == nil {
+		start = anchor
+	}
+
+	schedule := recurrence.Schedule{Rule: rule, Start: *start, Location: loc}
+	var result []api.TaskOccurrence
+	for _, t := range schedule.After(*anchor, n) {
+		shift := t.Sub(*anchor)
+		result = append(result, api.TaskOccurrence{
+			StartDate: shiftTime(e.StartDate, shift),
+			EndDate:   shiftTime(e.EndDate, shift),
+		})
+	}
+	return result, nil
+}
+
+func shiftTime(t *time.Time, d time.Duration) *time.Time {
+	if t == nil {
+		return nil
+	}
+	shifted := t.Add(d).UTC()
+	return &shifted
+}
+
+// nextOccurrence は繰り返しのタスクを完了したときに作る次のタスクを返します（繰り返しが終わった場合は false）
+// 次のタスクは未着手の状態で、規則と繰り返しのIDを引き継ぎます
+func nextOccurrence(e TaskEntity) (TaskEntity, bool, error) {
+	next, err := occurrences(e, 1)
+	if err != nil || len(next) == 0 {
+		return TaskEntity{}, false, err
+	}
+	n := e
+	n.ID = 0
+	n.StartDate, n.EndDate = next[0].StartDate, next[0].EndDate
+	n.Status = string(api.TaskStatusNotStarted)
+	n.SeriesID = seriesOf(e)
+	n.DeletedAt = nil
+	n.Version = 1
+	return n, true, nil
+}
+
+// seriesOf は繰り返しのID（最初のタスクのID）を返します
+func seriesOf(e TaskEntity) *int {
+	if e.SeriesID != nil {
+		return e.SeriesID
+	}
+	id := e.ID
+	return &id
+}
+
+// completesOccurrence は繰り返しのタスクが完了になるかどうか
+func completesOccurrence(before, after TaskEntity) bool {
+	return after.RecurrenceRule != nil &&
+		after.Status == string(api.TaskStatusCompleted) && before.Status != after.Status
+}
+
+// handOver は完了した繰り返しのタスクの規則を外し、次のタスクに引き継ぐ準備をします
+// 次のタスクがない（繰り返しが終わった）場合は nil を返します
+func handOver(e *TaskEntity) (*TaskEntity, error) {
+	next, ok, err := nextOccurrence(*e)
+	if err != nil {
+		return nil, err
+	}
+	e.SeriesID = seriesOf(*e)
+	e.RecurrenceRule, e.RecurrenceTimezone, e.RecurrenceStart = nil, nil, nil
+	if !ok {
+		return nil, nil
+	}
+	return &next, nil
+}
+
+// skipOccurrence は繰り返しのタスクを次の繰り返しの日付に移したエンティティを返します
+func skipOccurrence(e TaskEntity) (TaskEntity, error) {
+	if e.RecurrenceRule == nil {
+		return TaskEntity{}, notRecurring(e.ID)
+	}
+	next, ok, err := nextOccurrence(e)
+	if err != nil {
+		return TaskEntity{}, err
+	}
+	if !ok {
+		return TaskEntity{}, &ConstraintError{
+			Kind:       ErrConstraint,
+			Field:      "recurrence_rule",
+			Constraint: "tasks_recurrence_ended",
+			Err:        fmt.Errorf("task %d has no further occurrences", e.ID),
+		}
+	}
+	e.StartDate, e.EndDate = next.StartDate, next.EndDate
+	return e, nil
+}
+
+// endRecurrence は繰り返しの規則を外したエンティティを返します
+func endRecurrence(e TaskEntity) (TaskEntity, error) {
+	if e.RecurrenceRule == nil {
+		return TaskEntity{}, notRecurring(e.ID)
+	}
+	e.SeriesID = seriesOf(e)
+	e.RecurrenceRule = nil
+	return e, nil
+}
+
+// recurrencePreview はタスクの次の繰り返しを最大 n 件返します
+func recurrencePreview(e TaskEntity, n int) (api.RecurrencePreview, error) {
+	if e.RecurrenceRule == nil {
+		return api.RecurrencePreview{}, notRecurring(e.ID)
+	}
+	if n < 1 {
+		n = DefaultOccurrences
+	}
+	if n > MaxOccurrences {
+		n = MaxOccurrences
+	}
+	list, err := occurrences(e, n)
+	if err != nil {
+		return api.RecurrencePreview{}, err
+	}
+	return api.RecurrencePreview{
+		Rule:        *e.RecurrenceRule,
+		Timezone:    stringValue(e.RecurrenceTimezone),
+		Occurrences: append([]api.TaskOccurrence{}, list...),
+	}, nil
+}
+
+func notRecurring(id int) error {
+	return fmt.Errorf("%w: task %d is not recurring", ErrNotFound, id)
+}
+
+func invalidRecurrence(field string, err error) error {
+	return &ConstraintError{
+		Kind:       ErrConstraint,
+		Field:      field,
+		Constraint: "tasks_recurrence_rule",
+		Err:        err,
+	}
+}
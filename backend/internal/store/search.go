@@ -0,0 +1,30 @@
+package store
+
+import (
+	"github.com/yuchi1128/task-management-system/backend/api"
+	"github.com/yuchi1128/task-management-system/backend/internal/search"
+)
+
+// ハイライトの抜粋の最大文字数
+const (
+	nameSnippetLength        = 100
+	descriptionSnippetLength = 80
+)
+
+// searchMatch は全文検索の結果に含める関連度とハイライトを作成します
+func searchMatch(q string, e TaskEntity) *api.SearchMatch {
+	match := &api.SearchMatch{Rank: e.SearchRank}
+	match.Highlights.Name = search.Highlight(e.Name, q, nameSnippetLength)
+	if e.Description != nil && *e.Description != "" {
+		description := search.Highlight(*e.Description, q, descriptionSnippetLength)
+		match.Highlights.Description = &description
+	}
+	return match
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,137 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sortを指定しなかった場合のタスク一覧の並び順
+const (
+	DefaultTaskSort = "priority,end_date"
+	// DefaultSearchSort は全文検索（TaskFilter.Query）を指定した場合の並び順です
+	DefaultSearchSort = "relevance"
+	// DefaultTrashSort はゴミ箱の並び順です（最近削除したものから）
+	DefaultTrashSort = "-deleted_at"
+)
+
+// priorityRank と statusRank は列挙値を意味の順に並べるための順位です
+var (
+	priorityRank = map[string]int{"High": 1, "Middle": 2, "Low": 3}
+	statusRank   = map[string]int{"NotStarted": 1, "InProgress": 2, "Completed": 3}
+)
+
+// taskSortKeys は並び替えに使えるキーの一覧です
+// SQLに埋め込むのはここに定義した式だけなので、利用者の入力がSQLに入ることはありません
+var taskSortKeys = map[string]sortKey{
+	"relevance": {
+		column:   "search_rank",
+		kind:     kindFloat,
+		reversed: true,
+		value:    func(e TaskEntity) interface{} { return e.SearchRank },
+	},
+	"priority": {
+		column: rankExpression("priority", []string{"High", "Middle", "Low"}),
+		kind:   kindInt,
+		value:  func(e TaskEntity) interface{} { return priorityRank[e.Priority] },
+	},
+	"status": {
+		column: rankExpression("status", []string{"NotStarted", "InProgress", "Completed"}),
+		kind:   kindInt,
+		value:  func(e TaskEntity) interface{} { return statusRank[e.Status] },
+	},
+	"name": {
+		column: "name",
+		kind:   kindString,
+		value:  func(e TaskEntity) interface{} { return e.Name },
+	},
+	"start_date": {
+		column: "start_date",
+		kind:   kindTime,
+		value:  func(e TaskEntity) interface{} { return timeValue(e.StartDate) },
+	},
+	"end_date": {
+		column: "end_date",
+		kind:   kindTime,
+		value:  func(e TaskEntity) interface{} { return timeValue(e.EndDate) },
+	},
+	"created_at": {
+		column: "created_at",
+		kind:   kindTime,
+		value:  func(e TaskEntity) interface{} { return e.CreatedAt },
+	},
+	"updated_at": {
+		column: "updated_at",
+		kind:   kindTime,
+		value:  func(e TaskEntity) interface{} { return e.UpdatedAt },
+	},
+	"deleted_at": {
+		column: "deleted_at",
+		kind:   kindTime,
+		value:  func(e TaskEntity) interface{} { return timeValue(e.DeletedAt) },
+	},
+	"id": {
+		column: "id",
+		kind:   kindInt,
+		value:  func(e TaskEntity) interface{} { return e.ID },
+	},
+}
+
+// parseSort は "-priority,end_date,name" 形式の指定を並び順に変換します
+// 先頭の "-" は逆順を表します。順序を確定させるため、最後に必ずIDを加えます
+// 空の場合は絞り込み条件に応じた既定の並び順を使います
+// relevance は全文検索の場合のみ指定できます
+func parseSort(spec string, filter TaskFilter) ([]sortKey, error) {
+	searching := filter.Query != ""
+	if spec == "" {
+		switch {
+		case searching:
+			spec = DefaultSearchSort
+		case filter.Trashed:
+			spec = DefaultTrashSort
+		default:
+			spec = DefaultTaskSort
+		}
+	}
+
+	var order []sortKey
+	seen := make(map[string]bool)
+	for _, field := range strings.Split(spec, ",") {
+		field = strings.TrimSpace(field)
+		desc := strings.HasPrefix(field, "-")
+		name := strings.TrimPrefix(field, "-")
+
+		key, ok := taskSortKeys[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown sort key %q", ErrInvalidSort, name)
+		}
+		if name == "relevance" && !searching {
+			return nil, fmt.Errorf("%w: relevance requires a search query", ErrInvalidSort)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%w: duplicate sort key %q", ErrInvalidSort, name)
+		}
+		seen[name] = true
+
+		key.name = name
+		key.desc = desc != key.reversed
+		order = append(order, key)
+	}
+
+	if !seen["id"] {
+		key := taskSortKeys["id"]
+		key.name = "id"
+		order = append(order, key)
+	}
+	return order, nil
+}
+
+// rankExpression は列挙値を順位に変換するCASE式を返します
+func rankExpression(column string, values []string) string {
+	var b strings.Builder
+	b.WriteString("(CASE " + column)
+	for i, v := range values {
+		fmt.Fprintf(&b, " WHEN '%s' THEN %d", v, i+1)
+	}
+	b.WriteString(" END)")
+	return b.String()
+}
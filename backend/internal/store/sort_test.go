@@ -0,0 +1,111 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   string
+		filter TaskFilter
+		want   string
+	}{
+		{"default", "", TaskFilter{}, "priority,end_date,id"},
+		{"search default", "", TaskFilter{Query: "report"}, "-relevance,id"},
+		{"trash default", "", TaskFilter{Trashed: true}, "-deleted_at,id"},
+		{"multiple keys", "-priority, end_date,name", TaskFilter{}, "-priority,end_date,name,id"},
+		{"explicit id", "-id", TaskFilter{}, "-id"},
+		{"relevance while searching", "-relevance", TaskFilter{Query: "report"}, "relevance,id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := parseSort(tt.spec, tt.filter)
+			if err != nil {
+				t.Fatalf("parseSort(%q): %v", tt.spec, err)
+			}
+			if got := orderSignature(order); got != tt.want {
+				t.Errorf("parseSort(%q) = %q, want %q", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSortErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{"unknown key", "owner"},
+		// 列名以外の式は受け付けない
+		{"sql injection", "name;DROP TABLE tasks"},
+		{"duplicate key", "name,-name"},
+		{"empty key", "name,"},
+		{"relevance without search", "relevance"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseSort(tt.spec, TaskFilter{}); !errors.Is(err, ErrInvalidSort) {
+				t.Errorf("parseSort(%q) = %v, want ErrInvalidSort", tt.spec, err)
+			}
+		})
+	}
+}
+
+func TestOrderByClause(t *testing.T) {
+	order, err := parseSort("-priority,end_date", TaskFilter{})
+	if err != nil {
+		t.Fatalf("parseSort: %v", err)
+	}
+	want := " ORDER BY (CASE priority WHEN 'High' THEN 1 WHEN 'Middle' THEN 2 WHEN 'Low' THEN 3 END) DESC NULLS LAST, end_date ASC NULLS LAST, id ASC NULLS LAST"
+	if got := orderByClause(order); got != want {
+		t.Errorf("orderByClause =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMemoryStoreListTasksSort(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Options{})
+	day := func(d int) *time.Time {
+		v := time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+		return &v
+	}
+	for _, input := range []api.TaskInput{
+		{Name: "a", Priority: api.TaskInputPriorityLow, EndDate: day(1)},
+		{Name: "b", Priority: api.TaskInputPriorityHigh},
+		{Name: "c", Priority: api.TaskInputPriorityMiddle, EndDate: day(3)},
+		{Name: "d", Priority: api.TaskInputPriorityHigh, EndDate: day(2)},
+	} {
+		input.Status = api.TaskInputStatusNotStarted
+		newTestTask(t, s, input)
+	}
+
+	tests := []struct {
+		sort string
+		want string
+	}{
+		// 優先度は文字列ではなく High, Middle, Low の順
+		{"", "[d b c a]"},
+		{"-priority,name", "[a c b d]"},
+		// 終了日のないタスクは昇順でも降順でも末尾
+		{"end_date", "[a d c b]"},
+		{"-end_date", "[c d a b]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			page, err := s.ListTasks(ctx, TaskQuery{Sort: tt.sort})
+			if err != nil {
+				t.Fatalf("ListTasks: %v", err)
+			}
+			if got := fmt.Sprint(taskNames(page.Tasks)); got != tt.want {
+				t.Errorf("sort %q = %s, want %s", tt.sort, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// ページサイズの既定値と上限
+const (
+	DefaultPageSize = 100
+	MaxPageSize     = 1000
+)
+
+// ラベルによる絞り込みの一致条件
+const (
+	// LabelMatchAny は指定したラベルのいずれかを持つタスクに一致します
+	LabelMatchAny = "any"
+	// LabelMatchAll は指定したラベルをすべて持つタスクに一致します
+	LabelMatchAll = "all"
+)
+
+// TimeRange は日時の範囲です（From, To を含み、nilの側は制限しません）
+type TimeRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// TaskFilter はタスク一覧取得時の絞り込み条件です
+// 空のフィールドは条件に含めず、指定した条件はすべてANDで結合します
+type TaskFilter struct {
+	// Statuses, Priorities はいずれかに一致するタスクに絞り込みます
+	Statuses   []string
+	Priorities []string
+	// Query は名前と説明の全文検索の語です（search パッケージの規則で一致を判定します）
+	Query string
+	// Name, Description は大文字小文字を区別しない部分一致です
+	Name        string
+	Description string
+	// LabelIDs は LabelMatch に従ってラベルで絞り込みます
+	LabelIDs   []int
+	LabelMatch string
+	// HasLabels はラベルの有無で絞り込みます
+	HasLabels *bool
+	// Assignees は指定したユーザー名のユーザーのいずれかが担当するタスクに絞り込みます
+	Assignees []string
+	// Unassigned が true の場合は担当者のいないタスクに、false の場合は担当者のいるタスクに絞り込みます
+	Unassigned *bool
+	StartDate  TimeRange
+	EndDate    TimeRange
+	CreatedAt  TimeRange
+	UpdatedAt  TimeRange
+	// ParentID は指定したタスクの直下のサブタスクに絞り込みます
+	ParentID *int
+	// ProjectID は指定したプロジェクトのタスクに絞り込みます
+	ProjectID *int
+	// IncludeArchived が false の場合、ProjectID を指定しなければアーカイブしたプロジェクトのタスクを除きます
+	IncludeArchived bool
+	// Member を指定した場合は、そのユーザーがメンバーとして参加しているプロジェクトのタスクに絞り込みます
+	Member *MemberScope
+	// Trashed が true の場合はゴミ箱のタスクだけを、false の場合はゴミ箱以外のタスクを対象にします
+	Trashed bool
+	// Overdue は期限切れ（終了日が Now より前で未完了）かどうかで絞り込みます
+	Overdue *bool
+	// Now は期限切れの判定に使う現在時刻です（ゼロ値の場合は呼び出し時の時刻）
+	Now time.Time
+}
+
+// now は期限切れの判定に使う現在時刻を返します
+func (f TaskFilter) now() time.Time {
+	if f.Now.IsZero() {
+		return time.Now()
+	}
+	return f.Now
+}
+
+// TaskQuery はタスク一覧取得の条件とページングの指定です
+// Cursor を指定した場合はキーセットページングとなり、Page は無視されます
+type TaskQuery struct {
+	Filter TaskFilter
+	// Sort は "-priority,end_date" 形式の並び順です
+	// 空の場合は DefaultTaskSort、全文検索では関連度の高い順になります
+	Sort   string
+	Page   int
+	Limit  int
+	Cursor string
+}
+
+// TaskPage はタスク一覧の1ページ分の結果です
+type TaskPage struct {
+	Tasks []api.Task
+	// Total はページングに関係なく条件に一致するタスクの総数です
+	Total int
+	// NextCursor は次のページを取得するためのカーソルです（最終ページでは空）
+	NextCursor string
+}
+
+// AnyVersion は版を確認せずに更新・削除することを表します
+// それ以外の版を指定した場合、現在の版と一致しなければ ErrVersionMismatch を返します
+const AnyVersion = 0
+
+// TaskStore はタスクの永続化を抽象化します
+// 更新系のメソッドは楽観的排他制御のため、更新前に期待する版を受け取ります
+type TaskStore interface {
+	ListTasks(ctx context.Context, query TaskQuery) (TaskPage, error)
+	GetTask(ctx context.Context, id int) (api.Task, error)
+	// TaskTree はタスクを、ゴミ箱にない子孫を children に入れたツリーとして返します
+	TaskTree(ctx context.Context, id int) (api.Task, error)
+	// CreateTask は projectID のプロジェクトにタスクを作成します
+	// projectID が0の場合は親タスクのプロジェクト、親もない場合は DefaultProjectID のプロジェクトに作成します
+	CreateTask(ctx context.Context, projectID int, input api.TaskInput) (int, error)
+	UpdateTask(ctx context.Context, id int, input api.TaskInput, version int) error
+	// PatchTask は指定されたフィールドだけを更新し、更新後のタスクを返します
+	PatchTask(ctx context.Context, id int, patch TaskPatch, version int) (api.Task, error)
+	// DeleteTask はタスクをゴミ箱に移動します。ゴミ箱のタスクは他のメソッドからは存在しないものとして扱います
+	DeleteTask(ctx context.Context, id int, version int) error
+	// RestoreTask はゴミ箱のタスクをラベルの関連付けごと元に戻します
+	RestoreTask(ctx context.Context, id int, version int) (api.Task, error)
+	// PurgeTasks は deletedBefore より前にゴミ箱に移動したタスクを完全に削除し、削除した件数を返します
+	PurgeTasks(ctx context.Context, deletedBefore time.Time) (int, error)
+	// BulkTasks は一括操作を1つのトランザクションで実行し、タスクごとの結果を返します
+	// 個々のタスクの失敗は結果に含め、一括操作全体を実行できない場合だけエラーを返します
+	BulkTasks(ctx context.Context, op BulkOperation) ([]BulkItemResult, error)
+}
+
+// LabelStore はラベルとタスクへの関連付けの永続化を抽象化します
+type LabelStore interface {
+	// ListLabels は projectID のプロジェクトのラベルを名前順で返します（0の場合はすべてのプロジェクト）
+	ListLabels(ctx context.Context, projectID int) ([]api.Label, error)
+	GetLabel(ctx context.Context, id int) (api.Label, error)
+	CreateLabel(ctx context.Context, projectID int, input api.LabelInput) (int, error)
+	// UpdateLabel と DeleteLabel は、ラベルが付いているタスクの表現も変わるため、それらのタスクの版も進めます
+	UpdateLabel(ctx context.Context, id int, input api.LabelInput, version int) error
+	DeleteLabel(ctx context.Context, id int, version int) error
+	// SetTaskLabels はラベルの関連付けをタスクの一部として扱い、タスクの版を進めます
+	// 重複したラベルIDは1つにまとめ、タスクと異なるプロジェクトのラベルは ErrInvalidReference で拒否します
+	SetTaskLabels(ctx context.Context, taskID int, labelIDs []int, version int) error
+}
+
+// AuditStore はタスク、ラベルとプロジェクトの変更履歴の参照を抽象化します
+// 履歴は TaskStore, LabelStore, ProjectStore の書き込みと同じトランザクションで記録されます
+type AuditStore interface {
+	// ListAudit は条件に一致する変更履歴を新しい順に返します
+	ListAudit(ctx context.Context, query AuditQuery) (AuditPage, error)
+	// TaskHistory はゴミ箱や完全に削除したものを含むタスクの変更履歴を新しい順に返します
+	// タスクも履歴も存在しない場合は ErrNotFound を返します
+	TaskHistory(ctx context.Context, taskID int, query AuditQuery) (AuditPage, error)
+}
+
+// Store はサーバーが使用するすべてのストアをまとめたものです
+type Store interface {
+	TaskStore
+	LabelStore
+	ProjectStore
+	MemberStore
+	AuditStore
+	DependencyStore
+	RecurrenceStore
+	CommentStore
+	AttachmentStore
+	UserStore
+	AssigneeStore
+}
+
+// PageSize はページサイズを既定値と上限の範囲に収めて返します
+func (q TaskQuery) PageSize() int {
+	return clampPageSize(q.Limit)
+}
+
+func clampPageSize(limit int) int {
+	switch {
+	case limit < 1:
+		return DefaultPageSize
+	case limit > MaxPageSize:
+		return MaxPageSize
+	default:
+		return limit
+	}
+}
+
+// offset はページ番号と件数からOFFSETを計算します
+func (q TaskQuery) offset() int {
+	if q.Cursor != "" || q.Page < 1 {
+		return 0
+	}
+	return (q.Page - 1) * q.PageSize()
+}
@@ -0,0 +1,62 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/yuchi1128/task-management-system/backend/api"
+)
+
+// ErrTokenRevoked は失効したリフレッシュトークンを使おうとした場合に返されます
+// 盗まれたトークンが使い回された可能性があるため、そのユーザーのリフレッシュトークンはすべて失効させます
+var ErrTokenRevoked = errors.New("store: refresh token revoked")
+
+// UserStore はユーザーとリフレッシュトークンの永続化を抽象化します
+type UserStore interface {
+	// CreateUser はユーザーを追加します。ユーザー名が使われている場合は ErrConflict を返します
+	CreateUser(ctx context.Context, username, passwordHash string) (UserEntity, error)
+	GetUser(ctx context.Context, id int) (UserEntity, error)
+	GetUserByUsername(ctx context.Context, username string) (UserEntity, error)
+	// CreateRefreshToken はログインで発行したリフレッシュトークンを記録します
+	CreateRefreshToken(ctx context.Context, token RefreshTokenEntity) error
+	// RotateRefreshToken は id のリフレッシュトークンを失効させ、代わりに next を記録してユーザーを返します
+	// 記録がない場合や期限切れの場合は ErrNotFound、失効済みの場合は ErrTokenRevoked を返します
+	RotateRefreshToken(ctx context.Context, id string, next RefreshTokenEntity) (UserEntity, error)
+	// RevokeRefreshToken はリフレッシュトークンを失効させます（ログアウト）。記録がなくてもエラーにしません
+	RevokeRefreshToken(ctx context.Context, id string) error
+}
+
+// UserEntity はデータベースのユーザーを表すエンティティです
+type UserEntity struct {
+	ID       int    `db:"id"`
+	Username string `db:"username"`
+	// PasswordHash は bcrypt でハッシュ化したパスワードです
+	PasswordHash string    `db:"password_hash"`
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
+
+// ToAPIUser はUserEntityをapi.Userに変換します（パスワードのハッシュは含めません）
+func (e UserEntity) ToAPIUser() api.User {
+	return api.User{Id: e.ID, Username: e.Username, CreatedAt: e.CreatedAt}
+}
+
+// RefreshTokenEntity は発行したリフレッシュトークンの記録です（トークン自体は保存しません）
+type RefreshTokenEntity struct {
+	// ID はトークンの jti です
+	ID        string     `db:"id"`
+	UserID    int        `db:"user_id"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+func duplicateUsername(err error) error {
+	return &ConstraintError{
+		Kind:       ErrConflict,
+		Field:      "username",
+		Constraint: "users_username",
+		Err:        err,
+	}
+}
@@ -0,0 +1,55 @@
+// Package trash はゴミ箱に移動したタスクの定期的な完全削除を提供します
+package trash
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Store はゴミ箱のタスクを完全に削除できるストアです
+type Store interface {
+	PurgeTasks(ctx context.Context, deletedBefore time.Time) (int, error)
+}
+
+// Purger はゴミ箱で保持期間を過ぎたタスクを定期的に完全削除します
+type Purger struct {
+	store     Store
+	retention time.Duration
+	interval  time.Duration
+}
+
+// NewPurger は retention より前にゴミ箱に移動したタスクを interval ごとに削除する Purger を返します
+func NewPurger(store Store, retention, interval time.Duration) *Purger {
+	return &Purger{store: store, retention: retention, interval: interval}
+}
+
+// Run は ctx がキャンセルされるまで完全削除を繰り返します（起動直後にも1回実行します）
+func (p *Purger) Run(ctx context.Context) {
+	log.Printf("Purging trashed tasks older than %s every %s", p.retention, p.interval)
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.PurgeOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeOnce は保持期間を過ぎたタスクを1回だけ完全削除します
+func (p *Purger) PurgeOnce(ctx context.Context) {
+	n, err := p.store.PurgeTasks(ctx, time.Now().Add(-p.retention))
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Error purging trashed tasks: %v", err)
+		}
+		return
+	}
+	if n > 0 {
+		log.Printf("Purged %d trashed tasks", n)
+	}
+}
@@ -0,0 +1,82 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// fakeStore は PurgeTasks に渡された日時を記録します
+type fakeStore struct {
+	mu      sync.Mutex
+	cutoffs []time.Time
+	err     error
+}
+
+func (s *fakeStore) PurgeTasks(ctx context.Context, deletedBefore time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cutoffs = append(s.cutoffs, deletedBefore)
+	return 1, s.err
+}
+
+func (s *fakeStore) calls() []time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]time.Time(nil), s.cutoffs...)
+}
+
+func TestPurgeOnce(t *testing.T) {
+	s := &fakeStore{}
+	retention := 30 * 24 * time.Hour
+	before := time.Now()
+	NewPurger(s, retention, time.Hour).PurgeOnce(context.Background())
+	after := time.Now()
+
+	cutoffs := s.calls()
+	if len(cutoffs) != 1 {
+		t.Fatalf("PurgeTasks called %d times, want 1", len(cutoffs))
+	}
+	if cutoff := cutoffs[0]; cutoff.Before(before.Add(-retention)) || cutoff.After(after.Add(-retention)) {
+		t.Errorf("cutoff = %v, want %s before now", cutoff, retention)
+	}
+
+	// 失敗しても次の実行を続けられるよう、エラーは記録するだけ
+	s.err = errors.New("connection refused")
+	NewPurger(s, retention, time.Hour).PurgeOnce(context.Background())
+}
+
+func TestRun(t *testing.T) {
+	s := &fakeStore{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		NewPurger(s, time.Hour, 10*time.Millisecond).Run(ctx)
+		close(done)
+	}()
+
+	// 起動直後の1回と、間隔ごとの実行を待つ
+	deadline := time.Now().Add(5 * time.Second)
+	for len(s.calls()) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("PurgeTasks called %d times, want at least 2", len(s.calls()))
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after the context was canceled")
+	}
+}
@@ -0,0 +1,94 @@
+'use client';
+
+import { useState } from 'react';
+import { useRouter } from 'next/navigation';
+import { useQueryClient } from '@tanstack/react-query';
+import { isAxiosError } from 'axios';
+import { Container, Typography, Box, Button, TextField, Paper, Alert } from '@mui/material';
+import { login, register } from '@/lib/api';
+
+export default function LoginPage() {
+  const router = useRouter();
+  const queryClient = useQueryClient();
+  const [mode, setMode] = useState<'login' | 'register'>('login');
+  const [username, setUsername] = useState('');
+  const [password, setPassword] = useState('');
+  const [error, setError] = useState<string | null>(null);
+  const [submitting, setSubmitting] = useState(false);
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    setError(null);
+    setSubmitting(true);
+    try {
+      if (mode === 'register') {
+        await register(username, password);
+      }
+      await login(username, password);
+      // 前のユーザーのキャッシュを捨てる
+      queryClient.clear();
+      router.push('/');
+    } catch (err) {
+      if (isAxiosError(err) && err.response?.status === 401) {
+        setError('ユーザー名またはパスワードが正しくありません');
+      } else if (isAxiosError(err) && err.response?.status === 409) {
+        setError('このユーザー名は既に使われています');
+      } else if (isAxiosError(err) && err.response?.status === 400) {
+        setError('ユーザー名は3〜32文字の英数字、パスワードは8文字以上で入力してください');
+      } else {
+        setError(mode === 'register' ? '登録に失敗しました' : 'ログインに失敗しました');
+      }
+    } finally {
+      setSubmitting(false);
+    }
+  };
+
+  return (
+    <Container maxWidth="xs" sx={{ mt: 8 }}>
+      <Paper sx={{ p: 4 }}>
+        <Typography variant="h5" sx={{ mb: 3, fontWeight: 'bold' }}>
+          {mode === 'register' ? 'ユーザー登録' : 'ログイン'}
+        </Typography>
+        <Box component="form" onSubmit={handleSubmit}>
+          {error && (
+            <Alert severity="error" sx={{ mb: 2 }}>
+              {error}
+            </Alert>
+          )}
+          <TextField
+            label="ユーザー名"
+            value={username}
+            onChange={(e) => setUsername(e.target.value)}
+            fullWidth
+            required
+            autoComplete="username"
+            sx={{ mb: 2 }}
+          />
+          <TextField
+            label="パスワード"
+            type="password"
+            value={password}
+            onChange={(e) => setPassword(e.target.value)}
+            fullWidth
+            required
+            autoComplete={mode === 'register' ? 'new-password' : 'current-password'}
+            sx={{ mb: 3 }}
+          />
+          <Button type="submit" variant="contained" fullWidth disabled={submitting}>
+            {mode === 'register' ? '登録してログイン' : 'ログイン'}
+          </Button>
+          <Button
+            fullWidth
+            sx={{ mt: 1 }}
+            onClick={() => {
+              setMode(mode === 'register' ? 'login' : 'register');
+              setError(null);
+            }}
+          >
+            {mode === 'register' ? 'アカウントをお持ちの方はこちら' : '新しくアカウントを作成'}
+          </Button>
+        </Box>
+      </Paper>
+    </Container>
+  );
+}
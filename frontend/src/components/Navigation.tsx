@@ -2,11 +2,28 @@
 
 import { AppBar, Toolbar, Typography, Button } from '@mui/material';
 import { useRouter, usePathname } from 'next/navigation';
+import { useQuery, useQueryClient } from '@tanstack/react-query';
+import { getMe, logout } from '@/lib/api';
 
 export default function Navigation() {
   const router = useRouter();
   const pathname = usePathname();
-  
+  const queryClient = useQueryClient();
+
+  // ログインしているユーザー（ログイン画面では取得しない）
+  const { data: me } = useQuery({
+    queryKey: ['me'],
+    queryFn: getMe,
+    enabled: pathname !== '/login',
+    retry: false,
+  });
+
+  const handleLogout = async () => {
+    await logout().catch(() => undefined);
+    queryClient.clear();
+    router.push('/login');
+  };
+
   return (
     <AppBar position="static" sx={{ bgcolor: '#fff', color: '#171717', boxShadow: '0 1px 3px rgba(0,0,0,0.1)' }}>
       <Toolbar>
@@ -35,6 +52,14 @@ export default function Navigation() {
         >
           ラベル管理
         </Button>
+        {me && pathname !== '/login' && (
+          <>
+            <Typography sx={{ mx: 2, color: '#666' }}>{me.username}</Typography>
+            <Button color="inherit" onClick={handleLogout}>
+              ログアウト
+            </Button>
+          </>
+        )}
       </Toolbar>
     </AppBar>
   );
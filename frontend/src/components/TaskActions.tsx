@@ -58,8 +58,13 @@ export default function TaskActions({ task }: TaskActionsProps) {
 
   const updateMutation = useMutation({
     mutationFn: async (data: TaskInput) => {
-      // タスクを更新
-      await updateTask(task.id, data);
+      // タスクを更新（PUTは全体の置き換えなので、フォームにない親タスクと繰り返しは維持する）
+      await updateTask(task.id, {
+        ...data,
+        parent_id: task.parent_id ?? null,
+        recurrence_rule: task.recurrence_rule ?? null,
+        recurrence_timezone: task.recurrence_timezone ?? null,
+      });
       // ラベルを更新
       await updateTaskLabels(task.id, selectedLabelIds);
     },
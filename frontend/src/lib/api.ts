@@ -1,4 +1,4 @@
-import axios, { AxiosInstance } from 'axios';
+import axios, { AxiosError, AxiosInstance, InternalAxiosRequestConfig } from 'axios';
 
 // バックエンドのベースURL
 const API_BASE_URL = 'http://localhost:8080';
@@ -10,12 +10,96 @@ const api: AxiosInstance = axios.create({
   },
 });
 
+// トークンは localStorage に保存する
+const ACCESS_TOKEN_KEY = 'access_token';
+const REFRESH_TOKEN_KEY = 'refresh_token';
+
+export interface User {
+  id: number;
+  username: string;
+  created_at: string;
+}
+
+export interface TokenPair {
+  access_token: string;
+  refresh_token: string;
+  token_type: 'Bearer';
+  expires_in: number;
+}
+
+const saveTokens = (tokens: TokenPair) => {
+  localStorage.setItem(ACCESS_TOKEN_KEY, tokens.access_token);
+  localStorage.setItem(REFRESH_TOKEN_KEY, tokens.refresh_token);
+};
+
+const clearTokens = () => {
+  localStorage.removeItem(ACCESS_TOKEN_KEY);
+  localStorage.removeItem(REFRESH_TOKEN_KEY);
+};
+
+export const isLoggedIn = (): boolean =>
+  typeof window !== 'undefined' && localStorage.getItem(REFRESH_TOKEN_KEY) !== null;
+
+// リクエストにアクセストークンを付ける
+api.interceptors.request.use((config) => {
+  const token = typeof window !== 'undefined' ? localStorage.getItem(ACCESS_TOKEN_KEY) : null;
+  if (token) {
+    config.headers.Authorization = `Bearer ${token}`;
+  }
+  return config;
+});
+
+// 同時に401になったリクエストでリフレッシュを1回にまとめる
+let refreshing: Promise<string> | null = null;
+
+const refreshAccessToken = (): Promise<string> => {
+  if (!refreshing) {
+    const refreshToken = localStorage.getItem(REFRESH_TOKEN_KEY);
+    refreshing = axios
+      .post<TokenPair>(`${API_BASE_URL}/auth/refresh`, { refresh_token: refreshToken })
+      .then((response) => {
+        saveTokens(response.data);
+        return response.data.access_token;
+      })
+      .finally(() => {
+        refreshing = null;
+      });
+  }
+  return refreshing;
+};
+
+// アクセストークンが期限切れなら発行し直して再送し、それでも401ならログイン画面に移る
+api.interceptors.response.use(undefined, async (error: AxiosError) => {
+  const config = error.config as (InternalAxiosRequestConfig & { _retried?: boolean }) | undefined;
+  if (error.response?.status !== 401 || !config || typeof window === 'undefined') {
+    return Promise.reject(error);
+  }
+  if (!config._retried && localStorage.getItem(REFRESH_TOKEN_KEY)) {
+    config._retried = true;
+    try {
+      const token = await refreshAccessToken();
+      config.headers.Authorization = `Bearer ${token}`;
+      return api(config);
+    } catch {
+      // リフレッシュトークンも無効
+    }
+  }
+  clearTokens();
+  if (window.location.pathname !== '/login') {
+    window.location.href = '/login';
+  }
+  return Promise.reject(error);
+});
+
 export interface Label {
   id: number;
   name: string;
   color: string;
+  project_id: number;
   created_at: string;
   updated_at: string;
+  created_by?: string;
+  updated_by?: string;
 }
 
 export interface LabelInput {
@@ -23,6 +107,32 @@ export interface LabelInput {
   color: string;
 }
 
+export interface Project {
+  id: number;
+  name: string;
+  description?: string | null;
+  archived_at?: string | null;
+  created_at: string;
+  updated_at: string;
+  version: number;
+}
+
+// プロジェクトでの役割（viewer は閲覧、editor はタスクとラベルの編集、admin はプロジェクトとメンバーの管理もできる）
+export type ProjectRole = 'viewer' | 'editor' | 'admin';
+
+export interface ProjectMember {
+  user_id: number;
+  username: string;
+  role: ProjectRole;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface Assignee {
+  id: number;
+  username: string;
+}
+
 export interface Task {
   id: number;
   name: string;
@@ -33,7 +143,19 @@ export interface Task {
   status: 'NotStarted' | 'InProgress' | 'Completed';
   created_at: string;
   updated_at: string;
+  parent_id?: number | null;
+  project_id?: number;
+  depends_on?: number[];
+  blocked_by?: number[];
+  blocked?: boolean;
+  recurrence_rule?: string;
+  recurrence_timezone?: string;
+  series_id?: number;
+  comment_count?: number;
   labels?: Label[];
+  assignees?: Assignee[];
+  created_by?: string;
+  updated_by?: string;
 }
 
 export interface TaskInput {
@@ -43,12 +165,26 @@ export interface TaskInput {
   end_date?: string;
   priority: 'High' | 'Middle' | 'Low';
   status: 'NotStarted' | 'InProgress' | 'Completed';
+  parent_id?: number | null;
+  recurrence_rule?: string | null;
+  recurrence_timezone?: string | null;
 }
 
 // タスクのAPIメソッド
+// next_cursor をたどって全件を取得する
 export const getTasks = async (): Promise<Task[]> => {
-  const response = await api.get('/tasks');
-  return response.data.tasks;
+  const tasks: Task[] = [];
+  let cursor: string | null = null;
+  do {
+    const params: Record<string, string | number> = { page_size: 1000 };
+    if (cursor) {
+      params.cursor = cursor;
+    }
+    const response = await api.get('/tasks', { params });
+    tasks.push(...(response.data.tasks ?? []));
+    cursor = response.data.next_cursor;
+  } while (cursor);
+  return tasks;
 };
 
 export const createTask = async (task: TaskInput, labelIds?: number[]): Promise<void> => {
@@ -97,4 +233,75 @@ export const deleteLabel = async (id: number): Promise<void> => {
 // タスクにラベルを関連付ける
 export const updateTaskLabels = async (taskId: number, labelIds: number[]): Promise<void> => {
   await api.put(`/tasks/${taskId}/labels`, { label_ids: labelIds });
-};
\ No newline at end of file
+};
+
+// タスクの担当者を置き換える
+export const updateTaskAssignees = async (taskId: number, userIds: number[]): Promise<Task> => {
+  const response = await api.put(`/tasks/${taskId}/assignees`, { user_ids: userIds });
+  return response.data;
+};
+
+// ログインしているユーザーが担当するタスクを状態ごとに取得する
+export interface MyTasks {
+  not_started: Task[];
+  in_progress: Task[];
+  completed: Task[];
+}
+
+export const getMyTasks = async (): Promise<MyTasks> => {
+  const response = await api.get('/me/tasks');
+  return response.data;
+};
+
+// プロジェクトのAPIメソッド
+export const getProjects = async (archived = false): Promise<Project[]> => {
+  const response = await api.get('/projects', { params: { archived } });
+  return response.data.projects;
+};
+
+// タスクをサブタスクごと別のプロジェクトに移動する（付け替え先のないラベルは移動先に作成する）
+export const moveTask = async (taskId: number, projectId: number): Promise<Task> => {
+  const response = await api.post(`/tasks/${taskId}/move`, { project_id: projectId });
+  return response.data;
+};
+
+// プロジェクトのメンバーを取得する
+export const getProjectMembers = async (projectId: number): Promise<ProjectMember[]> => {
+  const response = await api.get(`/projects/${projectId}/members`);
+  return response.data.members;
+};
+
+// ユーザーをプロジェクトのメンバーにするか、役割を変更する（admin のみ）
+export const setProjectMember = async (projectId: number, userId: number, role: ProjectRole): Promise<ProjectMember> => {
+  const response = await api.put(`/projects/${projectId}/members/${userId}`, { role });
+  return response.data;
+};
+
+// ユーザーをプロジェクトのメンバーから外す（admin のみ）
+export const removeProjectMember = async (projectId: number, userId: number): Promise<void> => {
+  await api.delete(`/projects/${projectId}/members/${userId}`);
+};
+
+// 認証のAPIメソッド
+export const register = async (username: string, password: string): Promise<User> => {
+  const response = await api.post('/auth/register', { username, password });
+  return response.data;
+};
+
+export const login = async (username: string, password: string): Promise<void> => {
+  const response = await api.post<TokenPair>('/auth/login', { username, password });
+  saveTokens(response.data);
+};
+
+export const logout = async (): Promise<void> => {
+  const refreshToken = localStorage.getItem(REFRESH_TOKEN_KEY);
+  clearTokens();
+  if (refreshToken) {
+    await api.post('/auth/logout', { refresh_token: refreshToken });
+  }
+};
+
+export const getMe = async (): Promise<User> => {
+  const response = await api.get('/me');
+  return response.data;
+};
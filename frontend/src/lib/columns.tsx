@@ -1,6 +1,6 @@
 import { Box } from '@mui/material';
 import { GridColDef, GridSortModel } from '@mui/x-data-grid';
-import { Task, Label } from '@/lib/api';
+import { Task, Label, Assignee } from '@/lib/api';
 import TaskActions from '@/components/TaskActions';
 import ArrowUpwardIcon from '@mui/icons-material/ArrowUpward';
 import ArrowDownwardIcon from '@mui/icons-material/ArrowDownward';
@@ -60,6 +60,13 @@ export const getTaskColumns = (sortModel: GridSortModel): GridColDef[] => {
         );
       }
     },
+    {
+      field: 'assignees',
+      headerName: '担当者',
+      width: 130,
+      sortable: false,
+      valueGetter: (value: Assignee[] | undefined) => (value || []).map((a) => a.username).join(', '),
+    },
     {
       field: 'priority',
       headerName: '優先度',